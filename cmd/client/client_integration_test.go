@@ -172,6 +172,53 @@ func Test_ListServices_CLI(t *testing.T) {
 	})
 }
 
+func Test_Usage_CLI(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		ctx, _ := testutil.Trace(t)
+
+		service := createService(ctx, t)
+		if service == nil {
+			return
+		}
+
+		output, err := cmd.ExecuteWithParams(ctx, []string{
+			"service", "usage",
+			"--address", addr,
+			"--insecure", "true",
+			"--service", service.GetId(),
+			"--from", time.Now().UTC().Format(time.DateOnly),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !bytes.Contains(output, []byte("Characters")) || !bytes.Contains(output, []byte("Total")) {
+			t.Errorf("want output to contain usage table, got '%s'", string(output))
+		}
+	})
+
+	t.Run("error, invalid date", func(t *testing.T) {
+		t.Parallel()
+		ctx, _ := testutil.Trace(t)
+
+		_, err := cmd.ExecuteWithParams(ctx, []string{
+			"service", "usage",
+			"--address", addr,
+			"--insecure", "true",
+			"--service", gofakeit.UUID(),
+			"--from", "yesterday",
+		})
+
+		if want := "parse cli parameter 'from'"; err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("want error '%v' to contain '%s'", err, want)
+		}
+	})
+}
+
 //nolint:gocognit,cyclop
 func Test_TranslationFileUpload_CLI(t *testing.T) {
 	t.Parallel()
@@ -23,18 +23,23 @@ func newLsCmd(svc *Service) *cobra.Command {
 			ctx, cancelFunc := context.WithTimeout(cmd.Context(), timeout)
 			defer cancelFunc()
 
-			resp, err := svc.client.ListServices(ctx, &translatev1.ListServicesRequest{})
+			projectID, err := cmd.Flags().GetString("project")
+			if err != nil {
+				return fmt.Errorf("list services: get cli parameter 'project': %w", err)
+			}
+
+			resp, err := svc.client.ListServices(ctx, &translatev1.ListServicesRequest{ProjectId: projectID})
 			if err != nil {
 				return fmt.Errorf("list services: send gRPC request: %w", err)
 			}
 
 			headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
 			columnFmt := color.New(color.FgYellow).SprintfFunc()
-			tbl := table.New("ID", "Name")
+			tbl := table.New("ID", "Name", "Project ID")
 			tbl.WithHeaderFormatter(headerFmt).WithFirstColumnFormatter(columnFmt)
 
 			for _, v := range resp.GetServices() {
-				tbl.AddRow(v.GetId(), v.GetName())
+				tbl.AddRow(v.GetId(), v.GetName(), v.GetProjectId())
 			}
 
 			tbl.WithWriter(cmd.OutOrStdout())
@@ -44,5 +49,7 @@ func newLsCmd(svc *Service) *cobra.Command {
 		},
 	}
 
+	lsCmd.Flags().String("project", "", "project UUID, all projects if not set")
+
 	return lsCmd
 }
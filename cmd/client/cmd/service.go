@@ -31,6 +31,7 @@ func newServiceCmd(svc *Service) *cobra.Command {
 	serviceCmd.AddCommand(newUploadCmd(svc))
 	serviceCmd.AddCommand(newDownloadCmd(svc))
 	serviceCmd.AddCommand(newLsCmd(svc))
+	serviceCmd.AddCommand(newUsageCmd(svc))
 
 	return serviceCmd
 }
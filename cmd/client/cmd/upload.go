@@ -81,7 +81,7 @@ func newUploadCmd(svc *Service) *cobra.Command {
 				return fmt.Errorf("upload file: schema to translate schema: %w", err)
 			}
 
-			_, err = svc.client.UploadTranslationFile(ctx,
+			resp, err := svc.client.UploadTranslationFile(ctx,
 				&translatev1.UploadTranslationFileRequest{
 					Language:             language,
 					Data:                 data,
@@ -99,6 +99,14 @@ func newUploadCmd(svc *Service) *cobra.Command {
 				return fmt.Errorf("upload file: output response to stdout: %w", err)
 			}
 
+			if resp.LanguageConfidence != nil {
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Detected language: %s (confidence %.2f).\n",
+					resp.GetLanguage(), resp.GetLanguageConfidence())
+				if err != nil {
+					return fmt.Errorf("upload file: output response to stdout: %w", err)
+				}
+			}
+
 			return nil
 		},
 	}
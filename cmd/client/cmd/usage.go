@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newUsageCmd(svc *Service) *cobra.Command {
+	usageCmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show machine translation usage of a service",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			timeout, err := cmd.InheritedFlags().GetDuration("timeout")
+			if err != nil {
+				return fmt.Errorf("usage: get cli parameter 'timeout': %w", err)
+			}
+
+			ctx, cancelFunc := context.WithTimeout(cmd.Context(), timeout)
+			defer cancelFunc()
+
+			serviceID, err := cmd.Flags().GetString("service")
+			if err != nil {
+				return fmt.Errorf("usage: get cli parameter 'service': %w", err)
+			}
+
+			req := &translatev1.GetUsageRequest{ServiceId: serviceID}
+
+			from, err := dateFlag(cmd, "from")
+			if err != nil {
+				return fmt.Errorf("usage: %w", err)
+			}
+
+			if !from.IsZero() {
+				req.StartTime = timestamppb.New(from)
+			}
+
+			to, err := dateFlag(cmd, "to")
+			if err != nil {
+				return fmt.Errorf("usage: %w", err)
+			}
+
+			// The last day is included, the period ends at the start of the next day.
+			if !to.IsZero() {
+				req.EndTime = timestamppb.New(to.AddDate(0, 0, 1))
+			}
+
+			resp, err := svc.client.GetUsage(ctx, req)
+			if err != nil {
+				return fmt.Errorf("usage: send gRPC request: %w", err)
+			}
+
+			headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+			columnFmt := color.New(color.FgYellow).SprintfFunc()
+			tbl := table.New("Date", "Translator", "Characters", "Requests")
+			tbl.WithHeaderFormatter(headerFmt).WithFirstColumnFormatter(columnFmt)
+
+			for _, v := range resp.GetUsage() {
+				tbl.AddRow(v.GetDate().AsTime().Format(time.DateOnly), v.GetTranslator(),
+					strconv.FormatInt(v.GetCharacters(), 10), strconv.FormatInt(v.GetRequests(), 10))
+			}
+
+			tbl.AddRow("Total", "",
+				strconv.FormatInt(resp.GetTotalCharacters(), 10), strconv.FormatInt(resp.GetTotalRequests(), 10))
+
+			tbl.WithWriter(cmd.OutOrStdout())
+			tbl.Print()
+
+			return nil
+		},
+	}
+
+	usageFlags := usageCmd.Flags()
+	usageFlags.String("service", "", "service UUID")
+	usageFlags.String("from", "", "first day of the period as YYYY-MM-DD (default start of the current month)")
+	usageFlags.String("to", "", "last day of the period as YYYY-MM-DD (default today)")
+
+	err := usageCmd.MarkFlagRequired("service")
+	if err != nil {
+		log.Panicf("usage cmd: set field 'service' as required: %v", err)
+	}
+
+	return usageCmd
+}
+
+// dateFlag returns the day set by the date flag in UTC, or zero time if the flag is not set.
+func dateFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get cli parameter '%s': %w", name, err)
+	}
+
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse cli parameter '%s': %w", name, err)
+	}
+
+	return date, nil
+}
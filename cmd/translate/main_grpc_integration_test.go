@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/google/uuid"
@@ -19,6 +20,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // -------------Translation File-------------.
@@ -694,11 +696,29 @@ func Test_ListTranslations_gRPC(t *testing.T) {
 			request:  &translatev1.ListTranslationsRequest{ServiceId: uuid.New().String()},
 			wantCode: codes.OK,
 		},
+		{
+			name: "Happy path, filter and paginate",
+			request: &translatev1.ListTranslationsRequest{
+				ServiceId: service.GetId(),
+				Statuses:  []translatev1.Message_Status{translatev1.Message_TRANSLATED},
+				PageSize:  1,
+				ReadMask:  &field_mask.FieldMask{Paths: []string{"language", "messages.id"}},
+			},
+			wantCode: codes.OK,
+		},
 		{
 			name:     "Invalid argument, ServiceID not provided",
 			request:  &translatev1.ListTranslationsRequest{},
 			wantCode: codes.InvalidArgument,
 		},
+		{
+			name: "Invalid argument, unknown read mask path",
+			request: &translatev1.ListTranslationsRequest{
+				ServiceId: service.GetId(),
+				ReadMask:  &field_mask.FieldMask{Paths: []string{"messages.unknown"}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
 	}
 
 	for _, test := range tests {
@@ -716,6 +736,69 @@ func Test_ListTranslations_gRPC(t *testing.T) {
 	}
 }
 
+func Test_DeleteTranslation_gRPC(t *testing.T) {
+	t.Parallel()
+
+	ctx, subtest := testutil.Trace(t)
+
+	// Prepare
+	langs := rand.Languages(4)
+
+	service := createService(ctx, t)
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{Original: true, Language: langs[0].String()})
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{Language: langs[1].String()})
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{Language: langs[2].String()})
+
+	serviceWithOriginal := createService(ctx, t)
+	createTranslation(ctx, t, serviceWithOriginal.GetId(),
+		&translatev1.Translation{Original: true, Language: langs[0].String()})
+
+	tests := []struct {
+		request  *translatev1.DeleteTranslationRequest
+		name     string
+		wantCode codes.Code
+	}{
+		{
+			name:     "Happy Path",
+			request:  &translatev1.DeleteTranslationRequest{ServiceId: service.GetId(), Language: langs[1].String()},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Happy Path, last original translation",
+			request: &translatev1.DeleteTranslationRequest{
+				ServiceId: serviceWithOriginal.GetId(),
+				Language:  langs[0].String(),
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "Not found, translation not found",
+			request:  &translatev1.DeleteTranslationRequest{ServiceId: service.GetId(), Language: langs[3].String()},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Invalid argument, language not provided",
+			request:  &translatev1.DeleteTranslationRequest{ServiceId: service.GetId()},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Failed precondition, original translation with other translations",
+			request:  &translatev1.DeleteTranslationRequest{ServiceId: service.GetId(), Language: langs[0].String()},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, test := range tests {
+		subtest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
+			_, err := client.DeleteTranslation(ctx, test.request)
+
+			if status.Code(err) != test.wantCode {
+				t.Errorf("want status '%s', got '%s'", test.wantCode, status.Code(err))
+			}
+		})
+	}
+}
+
 func Test_UpdateTranslationFromMask_gRPC(t *testing.T) {
 	t.Parallel()
 
@@ -766,6 +849,7 @@ func Test_UpdateTranslationFromMask_gRPC(t *testing.T) {
 			{
 				Language: lang.String(),
 				Original: false,
+				Etag:     "2", // created and updated
 				Messages: []*translatev1.Message{
 					{
 						Id:          "Hello",
@@ -898,6 +982,91 @@ func Test_UpdateTranslation_gRPC(t *testing.T) {
 	}
 }
 
+func Test_UpdateETag_gRPC(t *testing.T) {
+	t.Parallel()
+
+	ctx, _ := testutil.Trace(t)
+
+	// Prepare
+	service := createService(ctx, t)
+	translation := createTranslation(ctx, t, service.GetId(), &translatev1.Translation{Original: true})
+
+	loadedService, err := client.GetService(ctx, &translatev1.GetServiceRequest{Id: service.GetId()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// updateService and updateTranslation return the etag of the updated resource.
+	updateService := func(etag string) (string, error) {
+		resp, inErr := client.UpdateService(ctx, &translatev1.UpdateServiceRequest{
+			Service:    &translatev1.Service{Id: service.GetId(), Name: gofakeit.Name(), Etag: etag},
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+		})
+
+		return resp.GetEtag(), inErr //nolint:wrapcheck
+	}
+
+	updateTranslation := func(etag string) (string, error) {
+		resp, inErr := client.UpdateTranslation(ctx, &translatev1.UpdateTranslationRequest{
+			ServiceId:   service.GetId(),
+			Translation: &translatev1.Translation{Language: translation.GetLanguage(), Original: true, Etag: etag},
+		})
+
+		return resp.GetEtag(), inErr //nolint:wrapcheck
+	}
+
+	// Steps depend on the previous ones, run sequentially.
+	tests := []struct {
+		update   func(etag string) (string, error)
+		name     string
+		etag     string
+		wantCode codes.Code
+	}{
+		{
+			name:     "Service with etag",
+			etag:     loadedService.GetEtag(),
+			update:   updateService,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "Service with stale etag",
+			etag:     loadedService.GetEtag(),
+			update:   updateService,
+			wantCode: codes.Aborted,
+		},
+		{
+			name:     "Translation with etag",
+			etag:     translation.GetEtag(),
+			update:   updateTranslation,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "Translation with stale etag",
+			etag:     translation.GetEtag(),
+			update:   updateTranslation,
+			wantCode: codes.Aborted,
+		},
+		{
+			name:     "Translation with invalid etag",
+			etag:     "invalid",
+			update:   updateTranslation,
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		etag, err := test.update(test.etag)
+		if status.Code(err) != test.wantCode {
+			t.Errorf("%s: want status '%s', got '%s'", test.name, test.wantCode, status.Code(err))
+		}
+
+		if err == nil && (etag == "" || etag == test.etag) {
+			t.Errorf("%s: want new etag, got '%s'", test.name, etag)
+		}
+	}
+}
+
 // matchingTranslationExistsInService checks incoming translation is equal to translation
 // with same language returned from listTranslations.
 func matchingTranslationExistsInService(
@@ -932,3 +1101,211 @@ func matchingTranslationExistsInService(
 		}
 	}
 }
+
+// -------------Jobs-------------.
+
+func Test_Jobs_gRPC(t *testing.T) {
+	t.Parallel()
+
+	ctx, subtest := testutil.Trace(t)
+
+	// Prepare
+	langs := rand.Languages(2)
+	service := createService(ctx, t)
+
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{
+		Original: true,
+		Language: langs[0].String(),
+		Messages: []*translatev1.Message{{Id: "Hello", Message: "Hello"}},
+	})
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{Language: langs[1].String()})
+
+	// Missing messages are added as untranslated to other languages, and a job is enqueued.
+	_, err := client.UpdateTranslation(ctx, &translatev1.UpdateTranslationRequest{
+		ServiceId:            service.GetId(),
+		PopulateTranslations: true,
+		Translation: &translatev1.Translation{
+			Original: true,
+			Language: langs[0].String(),
+			Messages: []*translatev1.Message{{Id: "Hello", Message: "Hello, World"}},
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	jobs, err := client.ListJobs(ctx, &translatev1.ListJobsRequest{ServiceId: service.GetId()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(jobs.GetOperations()) != 1 {
+		t.Errorf("want 1 job, got %d", len(jobs.GetOperations()))
+		return
+	}
+
+	subtest("Job is done", func(ctx context.Context, t *testing.T) { //nolint:thelper
+		name := jobs.GetOperations()[0].GetName()
+
+		for range 50 {
+			op, getErr := client.GetJob(ctx, &translatev1.GetJobRequest{Id: name})
+			if getErr != nil {
+				t.Error(getErr)
+				return
+			}
+
+			if op.GetDone() {
+				if op.GetError() != nil {
+					t.Errorf("want no error, got %v", op.GetError())
+				}
+
+				return
+			}
+
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		t.Errorf("want job '%s' done", name)
+	})
+
+	tests := []struct {
+		request  *translatev1.GetJobRequest
+		name     string
+		wantCode codes.Code
+	}{
+		{
+			name:     "Not found",
+			request:  &translatev1.GetJobRequest{Id: gofakeit.UUID()},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Invalid argument, malformed id",
+			request:  &translatev1.GetJobRequest{Id: "jobs/1"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		subtest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
+			_, err := client.GetJob(ctx, test.request)
+			if status.Code(err) != test.wantCode {
+				t.Errorf("want status '%s', got '%s'", test.wantCode, status.Code(err))
+			}
+		})
+	}
+}
+
+func Test_GetUsage_gRPC(t *testing.T) {
+	t.Parallel()
+
+	ctx, subtest := testutil.Trace(t)
+
+	// Prepare
+	service := createService(ctx, t)
+
+	tests := []struct {
+		request  *translatev1.GetUsageRequest
+		name     string
+		wantCode codes.Code
+	}{
+		{
+			name:     "Happy Path",
+			request:  &translatev1.GetUsageRequest{ServiceId: service.GetId()},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "Not found",
+			request:  &translatev1.GetUsageRequest{ServiceId: gofakeit.UUID()},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Start time after end time",
+			request: &translatev1.GetUsageRequest{
+				ServiceId: service.GetId(),
+				StartTime: timestamppb.Now(),
+				EndTime:   timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		subtest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
+			_, err := client.GetUsage(ctx, test.request)
+
+			if status.Code(err) != test.wantCode {
+				t.Errorf("want status '%s', got '%s'", test.wantCode, status.Code(err))
+			}
+		})
+	}
+}
+
+func Test_WatchTranslations_gRPC(t *testing.T) {
+	t.Parallel()
+
+	ctx, _ := testutil.Trace(t)
+
+	// Prepare
+	service := createService(ctx, t)
+	lang := rand.Language()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.WatchTranslations(watchCtx, &translatev1.WatchTranslationsRequest{ServiceId: service.GetId()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// The server sends the headers once watching.
+	_, err = stream.Header()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{
+		Original: true,
+		Language: lang.String(),
+		Messages: []*translatev1.Message{{Id: "greeting", Message: "Hello"}},
+	})
+
+	_, err = client.DeleteTranslation(ctx,
+		&translatev1.DeleteTranslationRequest{ServiceId: service.GetId(), Language: lang.String()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Test
+	for _, want := range []translatev1.TranslationEvent_Type{
+		translatev1.TranslationEvent_CREATED,
+		translatev1.TranslationEvent_DELETED,
+	} {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if event.GetType() != want || event.GetMessage().GetId() != "greeting" || event.GetLanguage() != lang.String() {
+			t.Errorf("want %s event of 'greeting' in '%s', got %v", want, lang, event)
+		}
+	}
+
+	// A resume token of another server is rejected.
+	stream, err = client.WatchTranslations(ctx,
+		&translatev1.WatchTranslationsRequest{ServiceId: service.GetId(), ResumeToken: "epoch.1"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = stream.Recv()
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("want status '%s', got '%s'", codes.OutOfRange, status.Code(err))
+	}
+}
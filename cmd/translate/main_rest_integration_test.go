@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/text/language"
 	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 var otelClient = &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
@@ -628,6 +629,81 @@ func Test_ListServices_REST(t *testing.T) {
 	}
 }
 
+// ------------------Project------------------
+
+func Test_Projects_REST(t *testing.T) {
+	t.Parallel()
+
+	ctx, _ := testutil.Trace(t)
+
+	do := func(method, path string, body []byte, wantCode int) []byte {
+		u := url.URL{
+			Scheme: "http",
+			Host:   net.JoinHostPort(host, port),
+			Path:   "v1/projects" + path,
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		resp, err := otelClient.Do(req)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		if wantCode != resp.StatusCode {
+			t.Errorf("%s %s: want status code %d, got %d", method, path, wantCode, resp.StatusCode)
+		}
+
+		return b
+	}
+
+	var project translatev1.Project
+
+	err := protojson.Unmarshal(do(http.MethodPost, "", []byte(`{"name":"Team"}`), http.StatusOK), &project)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var service translatev1.Service
+
+	err = protojson.Unmarshal(
+		do(http.MethodPost, "/"+project.GetId()+"/services", []byte(`{"name":"Web"}`), http.StatusOK), &service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var services translatev1.ListServicesResponse
+
+	err = protojson.Unmarshal(do(http.MethodGet, "/"+project.GetId()+"/services", nil, http.StatusOK), &services)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(services.GetServices()) != 1 || services.GetServices()[0].GetId() != service.GetId() {
+		t.Errorf("want service '%s' in project, got %v", service.GetId(), services.GetServices())
+	}
+
+	// A project with services cannot be deleted.
+	do(http.MethodDelete, "/"+project.GetId(), nil, http.StatusBadRequest)
+	do(http.MethodPost, "/"+gofakeit.UUID()+"/services", []byte(`{"name":"Web"}`), http.StatusNotFound)
+}
+
 // ------------------Translation------------------
 
 // POST.
@@ -867,6 +943,65 @@ func Test_UpdateTranslation_REST(t *testing.T) {
 	}
 }
 
+// DELETE.
+func Test_DeleteTranslation_REST(t *testing.T) {
+	t.Parallel()
+
+	ctx, subtest := testutil.Trace(t)
+
+	// Prepare
+	langs := rand.Languages(3)
+
+	service := createService(ctx, t)
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{Original: true, Language: langs[0].String()})
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{Language: langs[1].String()})
+
+	tests := []struct {
+		name     string
+		language string
+		wantCode int
+	}{
+		{
+			name:     "Happy Path",
+			language: langs[1].String(),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Not Found",
+			language: langs[2].String(),
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		subtest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
+			u := url.URL{
+				Scheme: "http",
+				Host:   net.JoinHostPort(host, port),
+				Path:   "v1/services/" + service.GetId() + "/translations/" + test.language,
+			}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			resp, err := otelClient.Do(req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			defer resp.Body.Close()
+
+			if test.wantCode != resp.StatusCode {
+				t.Errorf("want status code %d, got %d", test.wantCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
 // GET.
 func Test_GetTranslations_REST(t *testing.T) {
 	t.Parallel()
@@ -945,3 +1080,230 @@ func Test_GetTranslations_REST(t *testing.T) {
 		})
 	}
 }
+
+func Test_MessageRevisions_REST(t *testing.T) {
+	t.Parallel()
+
+	ctx, _ := testutil.Trace(t)
+
+	// Prepare
+	service := createService(ctx, t)
+	lang := rand.Language().String()
+
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{
+		Language: lang,
+		Messages: []*translatev1.Message{{Id: "Hello", Message: "World"}},
+	})
+
+	do := func(method, path string, body []byte, wantCode int) []byte {
+		u := url.URL{
+			Scheme: "http",
+			Host:   net.JoinHostPort(host, port),
+			Path:   "v1/services/" + service.GetId() + "/translations/" + lang + path,
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		req.Header.Set("Grpc-Metadata-Actor", "alice")
+
+		resp, err := otelClient.Do(req)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		if wantCode != resp.StatusCode {
+			t.Errorf("%s %s: want status code %d, got %d", method, path, wantCode, resp.StatusCode)
+		}
+
+		return b
+	}
+
+	do(http.MethodPut, "", []byte(`{"messages":[{"id":"Hello","message":"Universe"}]}`), http.StatusOK)
+
+	var revisions translatev1.ListMessageRevisionsResponse
+
+	err := protojson.Unmarshal(do(http.MethodGet, "/messages/Hello/revisions", nil, http.StatusOK), &revisions)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(revisions.GetRevisions()) != 2 {
+		t.Errorf("want 2 revisions, got %d", len(revisions.GetRevisions()))
+		return
+	}
+
+	if latest := revisions.GetRevisions()[0]; latest.GetNewMessage() != "Universe" || latest.GetActor() != "alice" {
+		t.Errorf("want latest revision 'Universe' by alice, got %v", latest)
+	}
+
+	body := fmt.Appendf(nil, `{"revision_id":%q}`, revisions.GetRevisions()[1].GetId())
+
+	var msg translatev1.Message
+
+	err = protojson.Unmarshal(do(http.MethodPost, "/messages/Hello:revert", body, http.StatusOK), &msg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if msg.GetMessage() != "World" {
+		t.Errorf("want reverted message 'World', got '%s'", msg.GetMessage())
+	}
+
+	do(http.MethodPost, "/messages/Hello:revert", []byte(`{"revision_id":"`+gofakeit.UUID()+`"}`), http.StatusNotFound)
+}
+
+// ------------------Search------------------
+
+func Test_SearchMessages_REST(t *testing.T) {
+	t.Parallel()
+
+	ctx, _ := testutil.Trace(t)
+
+	// Prepare
+	// Messages of other tests share the server, the searched word is unique.
+	word := gofakeit.LetterN(12)
+	service := createService(ctx, t)
+
+	createTranslation(ctx, t, service.GetId(), &translatev1.Translation{
+		Language: language.Latvian.String(),
+		Messages: []*translatev1.Message{{Id: "checkout", Message: "Apmaksāt " + word}},
+	})
+
+	u := url.URL{
+		Scheme:   "http",
+		Host:     net.JoinHostPort(host, port),
+		Path:     "v1/messages:search",
+		RawQuery: url.Values{"query": {word}, "languages": {"lv"}}.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Test
+	resp, err := otelClient.Do(req)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("want status code %d, got %d", http.StatusOK, resp.StatusCode)
+		return
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var got translatev1.SearchMessagesResponse
+
+	err = protojson.Unmarshal(b, &got)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(got.GetResults()) != 1 || got.GetResults()[0].GetServiceId() != service.GetId() ||
+		len(got.GetResults()[0].GetMessageHighlights()) != 1 {
+		t.Errorf("want highlighted message 'checkout' of service '%s', got %v", service.GetId(), got.GetResults())
+	}
+}
+
+// ------------------Webhook------------------
+
+func Test_Webhooks_REST(t *testing.T) {
+	t.Parallel()
+
+	ctx, _ := testutil.Trace(t)
+
+	service := createService(ctx, t)
+
+	do := func(method, path string, body []byte, wantCode int) []byte {
+		u := url.URL{
+			Scheme: "http",
+			Host:   net.JoinHostPort(host, port),
+			Path:   "v1/services/" + service.GetId() + "/webhooks" + path,
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		resp, err := otelClient.Do(req)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		if wantCode != resp.StatusCode {
+			t.Errorf("%s %s: want status code %d, got %d", method, path, wantCode, resp.StatusCode)
+		}
+
+		return b
+	}
+
+	var webhook translatev1.Webhook
+
+	err := protojson.Unmarshal(do(http.MethodPost, "",
+		[]byte(`{"url":"https://example.com/hook","secret":"secret","events":["JOB_FINISHED"]}`), http.StatusOK),
+		&webhook)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if webhook.GetSecret() != "" {
+		t.Error("want secret omitted from the response")
+	}
+
+	var webhooks translatev1.ListWebhooksResponse
+
+	err = protojson.Unmarshal(do(http.MethodGet, "", nil, http.StatusOK), &webhooks)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(webhooks.GetWebhooks()) != 1 || webhooks.GetWebhooks()[0].GetId() != webhook.GetId() {
+		t.Errorf("want webhook '%s', got %v", webhook.GetId(), webhooks.GetWebhooks())
+	}
+
+	do(http.MethodGet, "/"+webhook.GetId()+"/deliveries", nil, http.StatusOK)
+	do(http.MethodPost, "", []byte(`{"url":"example.com","secret":"secret","events":["JOB_FINISHED"]}`),
+		http.StatusBadRequest)
+	do(http.MethodDelete, "/"+webhook.GetId(), nil, http.StatusOK)
+	do(http.MethodDelete, "/"+webhook.GetId(), nil, http.StatusNotFound)
+	do(http.MethodGet, "/"+webhook.GetId()+"/deliveries", nil, http.StatusNotFound)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.expect.digital/translate/pkg/backup"
+	"go.expect.digital/translate/pkg/repo/factory"
+)
+
+func newBackupCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "backup FILE",
+		Short: "Write an archive of the whole database to FILE, - for stdout",
+		Long: `Write an archive of all projects and services with their translations, message revisions, usage, jobs,
+webhooks and webhook deliveries to FILE, - for stdout.
+
+The archive is read in a single transaction of the database selected with --db, and can be restored to
+any supported database with "translate restore". A running service can be backed up with the Backup admin RPC,
+enabled with --admin-rpc, as BadgerDB can only be opened by a single process.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			r, err := factory.NewRepo(ctx, viper.GetString("service.db"))
+			if err != nil {
+				return fmt.Errorf("backup: create new repo: %w", err)
+			}
+
+			defer closeRepo(r)
+
+			var w io.Writer = cmd.OutOrStdout()
+
+			if args[0] != "-" {
+				f, createErr := os.Create(args[0])
+				if createErr != nil {
+					return fmt.Errorf("backup: create file: %w", createErr)
+				}
+
+				defer f.Close()
+
+				w = f
+			}
+
+			stats, err := backup.Write(ctx, w, r)
+			if err != nil {
+				return fmt.Errorf("backup: %w", err)
+			}
+
+			_, err = fmt.Fprintf(cmd.ErrOrStderr(), "Backed up %s.\n", formatBackupStats(stats))
+			if err != nil {
+				return fmt.Errorf("backup: write stats: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newRestoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore FILE",
+		Short: "Restore an archive from FILE, - for stdin, to an empty database",
+		Long: `Restore an archive written by "translate backup" or the Backup RPC from FILE, - for stdin,
+to the database selected with --db. The database must not contain any services.
+If the restore fails, the restored services and projects are deleted again.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			r, err := factory.NewRepo(ctx, viper.GetString("service.db"))
+			if err != nil {
+				return fmt.Errorf("restore: create new repo: %w", err)
+			}
+
+			defer closeRepo(r)
+
+			var rd io.Reader = cmd.InOrStdin()
+
+			if args[0] != "-" {
+				f, openErr := os.Open(args[0])
+				if openErr != nil {
+					return fmt.Errorf("restore: open file: %w", openErr)
+				}
+
+				defer f.Close()
+
+				rd = f
+			}
+
+			stats, err := backup.Restore(ctx, rd, r)
+			if err != nil {
+				return fmt.Errorf("restore: %w", err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Restored %s.\n", formatBackupStats(stats))
+			if err != nil {
+				return fmt.Errorf("restore: write stats: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func formatBackupStats(stats backup.Stats) string {
+	return fmt.Sprintf("%d services, %d translations, %d messages, %d message revisions, %d usage records, %d jobs, "+
+		"%d webhooks, %d webhook deliveries",
+		stats.Services, stats.Translations, stats.Messages, stats.Revisions, stats.Usage, stats.Jobs,
+		stats.Webhooks, stats.WebhookDeliveries)
+}
+
+func init() {
+	rootCmd.AddCommand(newBackupCmd(), newRestoreCmd())
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/repo/factory"
+)
+
+// dbCmd manages the data of the databases.
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage the database data",
+}
+
+func newDBCopyCmd() *cobra.Command {
+	copyCmd := &cobra.Command{
+		Use:   "copy",
+		Short: "Copy services with their translations and usage from one database to another",
+		Long: `Copy services with their translations and usage from one database to another, e.g. from BadgerDB to MySQL.
+
+Both databases are configured the same way as for the service, e.g. with TRANSLATE_DB_MYSQL_HOST.
+Services already copied to the target database are skipped, an interrupted copy can be resumed by running it again.
+Jobs are not copied.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			fromDB, err := cmd.Flags().GetString("from")
+			if err != nil {
+				return fmt.Errorf("db copy: get cli parameter 'from': %w", err)
+			}
+
+			toDB, err := cmd.Flags().GetString("to")
+			if err != nil {
+				return fmt.Errorf("db copy: get cli parameter 'to': %w", err)
+			}
+
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return fmt.Errorf("db copy: get cli parameter 'dry-run': %w", err)
+			}
+
+			if strings.EqualFold(strings.TrimSpace(fromDB), strings.TrimSpace(toDB)) {
+				return fmt.Errorf("db copy: source and target database are the same: '%s'", fromDB)
+			}
+
+			from, err := factory.NewRepo(ctx, fromDB)
+			if err != nil {
+				return fmt.Errorf("db copy: create source repo: %w", err)
+			}
+
+			defer closeRepo(from)
+
+			to, err := factory.NewRepo(ctx, toDB)
+			if err != nil {
+				return fmt.Errorf("db copy: create target repo: %w", err)
+			}
+
+			defer closeRepo(to)
+
+			out := cmd.OutOrStdout()
+
+			stats, err := repo.Copy(ctx, from, to, repo.CopyOpts{
+				DryRun: dryRun,
+				OnService: func(serviceID uuid.UUID, stats repo.CopyStats) {
+					if stats.Skipped > 0 {
+						fmt.Fprintf(out, "Service %s: already copied, skipped.\n", serviceID) //nolint:errcheck
+						return
+					}
+
+					fmt.Fprintf(out, //nolint:errcheck
+						"Service %s: %d translations, %d messages, %d revisions, %d usage records, %d webhooks.\n",
+						serviceID, stats.Translations, stats.Messages, stats.Revisions, stats.Usage, stats.Webhooks)
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("db copy: %w", err)
+			}
+
+			verb := "Copied"
+			if dryRun {
+				verb = "Dry run, would copy"
+			}
+
+			_, err = fmt.Fprintf(out,
+				"%s %d services, %d translations, %d messages, %d revisions, %d usage records, %d webhooks, "+
+					"skipped %d services.\n",
+				verb, stats.Services, stats.Translations, stats.Messages, stats.Revisions, stats.Usage, stats.Webhooks,
+				stats.Skipped)
+			if err != nil {
+				return fmt.Errorf("db copy: write stats: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	copyFlags := copyCmd.Flags()
+	copyFlags.String("from", "badgerdb", "source "+factory.Usage())
+	copyFlags.String("to", "", "target "+factory.Usage())
+	copyFlags.Bool("dry-run", false, "count the data to copy without writing to the target database")
+
+	err := copyCmd.MarkFlagRequired("to")
+	if err != nil {
+		log.Panicf("db copy cmd: set field 'to' as required: %v", err)
+	}
+
+	return copyCmd
+}
+
+func closeRepo(r repo.Repo) {
+	err := r.Close()
+	if err != nil {
+		log.Printf("close repo: %v", err)
+	}
+}
+
+func init() {
+	dbCmd.AddCommand(newDBCopyCmd())
+	rootCmd.AddCommand(dbCmd)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.expect.digital/translate/migrate"
+	"go.expect.digital/translate/pkg/repo/factory"
+)
+
+// migrateCmd manages the schema of the database selected by the --db flag.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the database schema",
+	Long: `Manage the database schema.
+
+MySQL and SQLite migration scripts are embedded in the binary, the applied version is recorded
+in the schema_migrations table. BadgerDB records the version of its key format.`,
+}
+
+func newMigrateUpCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "Apply all pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withMigrator(cmd.Context(), func(ctx context.Context, m migrate.Migrator) error {
+				return m.Up(ctx) //nolint:wrapcheck
+			})
+		},
+	}
+}
+
+func newMigrateDownCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "down [N]",
+		Short: "Roll back the last N applied migrations, one by default",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps := 1
+
+			if len(args) > 0 {
+				var err error
+
+				steps, err = strconv.Atoi(args[0])
+				if err != nil || steps < 1 {
+					return fmt.Errorf("invalid number of migrations: '%s'", args[0])
+				}
+			}
+
+			return withMigrator(cmd.Context(), func(ctx context.Context, m migrate.Migrator) error {
+				return m.Down(ctx, steps) //nolint:wrapcheck
+			})
+		},
+	}
+}
+
+func newMigrateForceCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "force VERSION",
+		Short: "Set the schema version without applying migrations, e.g. to clear the dirty flag of a failed migration",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || version < 0 {
+				return fmt.Errorf("invalid version: '%s'", args[0])
+			}
+
+			return withMigrator(cmd.Context(), func(ctx context.Context, m migrate.Migrator) error {
+				return m.Force(ctx, version) //nolint:wrapcheck
+			})
+		},
+	}
+}
+
+func newMigrateStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the schema version",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withMigrator(cmd.Context(), func(ctx context.Context, m migrate.Migrator) error {
+				status, err := m.Status(ctx)
+				if err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				state := "up to date"
+
+				switch {
+				case status.Dirty:
+					state = "dirty"
+				case status.Version < status.Latest:
+					state = "pending migrations"
+				case status.Version > status.Latest:
+					state = "newer than supported"
+				}
+
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Version: %d\nLatest: %d\nState: %s\n",
+					status.Version, status.Latest, state)
+				if err != nil {
+					return fmt.Errorf("write status: %w", err)
+				}
+
+				return nil
+			})
+		},
+	}
+}
+
+// withMigrator runs fn with the migrator of the database selected by the --db flag.
+func withMigrator(ctx context.Context, fn func(context.Context, migrate.Migrator) error) error {
+	db := viper.GetString("service.db")
+
+	m, err := factory.NewMigrator(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create new migrator: %w", err)
+	}
+
+	defer m.Close()
+
+	err = fn(ctx, m)
+	if err != nil {
+		return fmt.Errorf("migrate %s: %w", db, err)
+	}
+
+	return nil
+}
+
+func init() {
+	migrateCmd.AddCommand(newMigrateUpCmd(), newMigrateDownCmd(), newMigrateForceCmd(), newMigrateStatusCmd())
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -15,6 +16,7 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.expect.digital/translate/migrate"
 	"go.expect.digital/translate/pkg/fuzzy"
 	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
 	"go.expect.digital/translate/pkg/repo/factory"
@@ -68,12 +70,24 @@ func RootCmdRunE(cmd *cobra.Command, _ []string) error {
 		}
 	}()
 
-	grpcServer := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(server.ActorInterceptor),
+	)
 	// Gracefully stops gRPC server.
 	defer grpcServer.GracefulStop()
 
 	mux := runtime.NewServeMux()
 
+	if viper.GetBool("service.auto_migrate") {
+		err = withMigrator(ctx, func(ctx context.Context, m migrate.Migrator) error {
+			return m.Up(ctx) //nolint:wrapcheck
+		})
+		if err != nil {
+			return fmt.Errorf("auto migrate: %w", err)
+		}
+	}
+
 	repo, err := factory.NewRepo(ctx, viper.GetString("service.db"))
 	if err != nil {
 		return fmt.Errorf("create new repo: %w", err)
@@ -86,37 +100,136 @@ func RootCmdRunE(cmd *cobra.Command, _ []string) error {
 		}
 	}()
 
-	var (
-		translator    fuzzy.Translator
-		translatorStr string
-	)
+	var pseudoOpts []fuzzy.PseudoTranslateOption
 
-	switch translatorStr = viper.GetString("service.translator"); translatorStr {
-	case "":
-		translator = &fuzzy.NoopTranslate{}
-	case "AWSTranslate":
-		translator, err = fuzzy.NewAWSTranslate(ctx, fuzzy.WithDefaultAWSClient(ctx))
-	case "GoogleTranslate":
-		var closeTranslate func() error
+	if viper.IsSet("other.pseudo.expansion") {
+		pseudoOpts = append(pseudoOpts, fuzzy.WithPseudoExpansion(viper.GetFloat64("other.pseudo.expansion")))
+	}
 
-		translator, closeTranslate, err = fuzzy.NewGoogleTranslate(
-			ctx, fuzzy.WithDefaultGoogleClient(ctx))
+	pseudoTranslator, err := fuzzy.NewPseudoTranslate(pseudoOpts...)
+	if err != nil {
+		return fmt.Errorf("create new PseudoTranslate: %w", err)
+	}
+
+	// Translators available to service translator routing, the default translator is always available.
+	translators := map[string]fuzzy.Translator{"PseudoTranslate": pseudoTranslator}
+
+	translatorStr := viper.GetString("service.translator")
+
+	for _, name := range append(viper.GetStringSlice("service.translators"), translatorStr) {
+		if _, ok := translators[name]; ok || name == "" {
+			continue
+		}
+
+		translator, closeTranslator, newErr := newTranslator(ctx, name)
+		if newErr != nil {
+			return newErr
+		}
 
 		defer func() {
-			closeErr := closeTranslate()
+			closeErr := closeTranslator()
 			if closeErr != nil {
-				log.Printf("close GoogleTranslate client: %v\n", closeErr)
+				log.Printf("close %s client: %v\n", name, closeErr)
 			}
 		}()
+
+		translators[name] = translator
+	}
+
+	translator := translators[translatorStr]
+	if translator == nil {
+		translator = &fuzzy.NoopTranslate{}
+	}
+
+	serverOpts := []server.TranslateServiceServerOption{
+		server.WithTranslateConcurrency(viper.GetInt("service.translate_concurrency")),
+		server.WithPseudoTranslator(pseudoTranslator),
+		server.WithJobPollInterval(viper.GetDuration("service.job_poll_interval")),
+		server.WithTranslators(translators),
+	}
+
+	var detector fuzzy.LanguageDetector
+
+	switch detectorStr := viper.GetString("service.language_detector"); detectorStr {
+	case "":
+		// Uploads without a language are rejected.
+	case "Ngram":
+		detector = fuzzy.NewNgramDetect()
+	case "GoogleTranslate":
+		// Reuse the Google Translate client if it is one of the configured translators.
+		googleTranslate, ok := translators["GoogleTranslate"].(*fuzzy.GoogleTranslate)
+		if !ok {
+			var closeTranslate func() error
+
+			googleTranslate, closeTranslate, err = fuzzy.NewGoogleTranslate(ctx, fuzzy.WithDefaultGoogleClient(ctx))
+			if err != nil {
+				return fmt.Errorf("create new %s language detector: %w", detectorStr, err)
+			}
+
+			defer func() {
+				closeErr := closeTranslate()
+				if closeErr != nil {
+					log.Printf("close GoogleTranslate client: %v\n", closeErr)
+				}
+			}()
+		}
+
+		detector = googleTranslate
+	case "AWSComprehend":
+		detector, err = fuzzy.NewAWSComprehend(fuzzy.WithDefaultComprehendClient(ctx))
+		if err != nil {
+			return fmt.Errorf("create new %s language detector: %w", detectorStr, err)
+		}
 	default:
-		return fmt.Errorf("unsupported translator: %s", translatorStr)
+		return fmt.Errorf("unsupported language detector: %s", detectorStr)
 	}
 
-	if err != nil {
-		return fmt.Errorf("create new %s client: %w", translatorStr, err)
+	if detector != nil {
+		serverOpts = append(serverOpts, server.WithLanguageDetector(detector))
 	}
 
-	translatev1.RegisterTranslateServiceServer(grpcServer, server.NewTranslateServiceServer(repo, translator))
+	if viper.GetBool("service.back_translation") {
+		serverOpts = append(serverOpts, server.WithBackTranslation(viper.GetFloat64("service.quality_threshold")))
+	}
+
+	serverOpts = append(serverOpts,
+		server.WithMarkObsolete(viper.GetBool("service.mark_obsolete")),
+		server.WithRevisionRetention(viper.GetDuration("service.revision_retention")),
+		server.WithWebhookDeliveryRetention(viper.GetDuration("service.webhook_delivery_retention")),
+		server.WithJobRetention(viper.GetDuration("service.job_retention")))
+
+	translateServer := server.NewTranslateServiceServer(repo, translator, serverOpts...)
+
+	translatev1.RegisterTranslateServiceServer(grpcServer, translateServer)
+
+	// The admin RPCs are served over gRPC only, they are not registered on the REST gateway.
+	if viper.GetBool("service.admin_rpc") {
+		translatev1.RegisterAdminServiceServer(grpcServer, server.NewAdminServiceServer(repo))
+	}
+
+	// Fuzzy translate uploaded translations in the background.
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+
+	var jobsWg sync.WaitGroup
+
+	jobsWg.Go(func() {
+		jobsErr := translateServer.RunJobs(jobsCtx)
+		if jobsErr != nil {
+			log.Printf("run jobs: %v", jobsErr)
+		}
+	})
+
+	// Deliver webhooks of translation changes and finished jobs in the background.
+	jobsWg.Go(func() { translateServer.RunWebhooks(jobsCtx) })
+
+	// Delete message revisions, webhook deliveries and finished jobs older than their retention in the background.
+	jobsWg.Go(func() { translateServer.RunRevisionPruning(jobsCtx) })
+	jobsWg.Go(func() { translateServer.RunWebhookDeliveryPruning(jobsCtx) })
+	jobsWg.Go(func() { translateServer.RunJobPruning(jobsCtx) })
+
+	// Stop the job, webhook and revision workers before the repo is closed.
+	defer jobsWg.Wait()
+	defer stopJobs()
 
 	// gRPC Server Reflection provides information about publicly-accessible gRPC services on a server,
 	// and assists clients at runtime to construct RPC requests and responses without precompiled service information.
@@ -160,6 +273,36 @@ func RootCmdRunE(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// newTranslator creates the translator by name, the returned func closes the translator client.
+func newTranslator(ctx context.Context, name string) (fuzzy.Translator, func() error, error) { //nolint:ireturn
+	var (
+		translator      fuzzy.Translator
+		closeTranslator = func() error { return nil }
+		err             error
+	)
+
+	switch name {
+	case "AWSTranslate":
+		translator, err = fuzzy.NewAWSTranslate(ctx,
+			fuzzy.WithDefaultAWSClient(ctx),
+			fuzzy.WithAWSConcurrency(viper.GetInt("other.aws.concurrency")),
+			fuzzy.WithAWSHTML(viper.GetBool("other.aws.html")))
+	case "GoogleTranslate":
+		translator, closeTranslator, err = fuzzy.NewGoogleTranslate(ctx,
+			fuzzy.WithDefaultGoogleClient(ctx),
+			fuzzy.WithGoogleConcurrency(viper.GetInt("other.google.concurrency")),
+			fuzzy.WithGoogleHTML(viper.GetBool("other.google.html")))
+	default:
+		return nil, nil, fmt.Errorf("unsupported translator: %s", name)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("create new %s client: %w", name, err)
+	}
+
+	return translator, closeTranslator, nil
+}
+
 func Serve() {
 	// Execute adds all child commands to the root command and sets flags appropriately.
 	err := rootCmd.Execute()
@@ -174,7 +317,27 @@ func init() {
 	rootCmd.PersistentFlags().Uint("port", 8080, "port to run service on") //nolint:mnd
 	rootCmd.PersistentFlags().String("host", "0.0.0.0", "host to run service on")
 	rootCmd.PersistentFlags().String("db", "badgerdb", factory.Usage())
+	rootCmd.Flags().Bool("auto-migrate", false, "apply pending database migrations on start")
 	rootCmd.PersistentFlags().String("translator", "", fuzzy.Usage())
+	rootCmd.PersistentFlags().StringSlice("translators", nil,
+		"additional translators available to service translator routing. Supported options: "+
+			strings.Join(fuzzy.SupportedServices, ", "))
+	rootCmd.PersistentFlags().String("language-detector", "", fuzzy.DetectorUsage())
+	rootCmd.PersistentFlags().Uint("translate-concurrency", 4, "number of languages to fuzzy translate concurrently") //nolint:mnd
+	rootCmd.PersistentFlags().Duration("job-poll-interval", 10*time.Second, "interval to check for pending jobs")     //nolint:mnd
+	rootCmd.PersistentFlags().Bool("back-translation", false, "score fuzzy translations by back-translating them")
+	rootCmd.PersistentFlags().Float64("quality-threshold", fuzzy.DefaultQualityThreshold,
+		"back-translation quality score below which fuzzy translations are flagged for review")
+	rootCmd.PersistentFlags().Bool("mark-obsolete", false,
+		"keep messages removed from the original translation as obsolete instead of deleting them")
+	rootCmd.PersistentFlags().Duration("revision-retention", 0,
+		"how long message revisions are kept, e.g. 2160h. Revisions are kept forever if not set")
+	rootCmd.PersistentFlags().Duration("webhook-delivery-retention", 0,
+		"how long finished webhook deliveries are kept, e.g. 720h. Deliveries are kept forever if not set")
+	rootCmd.PersistentFlags().Duration("job-retention", 0,
+		"how long finished fuzzy translation jobs are kept, e.g. 720h. Jobs are kept forever if not set")
+	rootCmd.PersistentFlags().Bool("admin-rpc", false,
+		"enable the admin RPCs, e.g. Backup, over gRPC only. The backup archive includes webhook secrets")
 }
 
 var mutex = &sync.Mutex{}
@@ -213,10 +376,71 @@ func initConfig() {
 		log.Panicf("bind db flag: %v", err)
 	}
 
+	err = viper.BindPFlag("service.auto_migrate", rootCmd.Flags().Lookup("auto-migrate"))
+	if err != nil {
+		log.Panicf("bind auto-migrate flag: %v", err)
+	}
+
 	err = viper.BindPFlag("service.translator", rootCmd.PersistentFlags().Lookup("translator"))
 	if err != nil {
 		log.Panicf("bind translator flag: %v", err)
 	}
 
+	err = viper.BindPFlag("service.translators", rootCmd.PersistentFlags().Lookup("translators"))
+	if err != nil {
+		log.Panicf("bind translators flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.language_detector", rootCmd.PersistentFlags().Lookup("language-detector"))
+	if err != nil {
+		log.Panicf("bind language-detector flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.translate_concurrency", rootCmd.PersistentFlags().Lookup("translate-concurrency"))
+	if err != nil {
+		log.Panicf("bind translate-concurrency flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.job_poll_interval", rootCmd.PersistentFlags().Lookup("job-poll-interval"))
+	if err != nil {
+		log.Panicf("bind job-poll-interval flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.back_translation", rootCmd.PersistentFlags().Lookup("back-translation"))
+	if err != nil {
+		log.Panicf("bind back-translation flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.quality_threshold", rootCmd.PersistentFlags().Lookup("quality-threshold"))
+	if err != nil {
+		log.Panicf("bind quality-threshold flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.mark_obsolete", rootCmd.PersistentFlags().Lookup("mark-obsolete"))
+	if err != nil {
+		log.Panicf("bind mark-obsolete flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.revision_retention", rootCmd.PersistentFlags().Lookup("revision-retention"))
+	if err != nil {
+		log.Panicf("bind revision-retention flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.webhook_delivery_retention",
+		rootCmd.PersistentFlags().Lookup("webhook-delivery-retention"))
+	if err != nil {
+		log.Panicf("bind webhook-delivery-retention flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.job_retention", rootCmd.PersistentFlags().Lookup("job-retention"))
+	if err != nil {
+		log.Panicf("bind job-retention flag: %v", err)
+	}
+
+	err = viper.BindPFlag("service.admin_rpc", rootCmd.PersistentFlags().Lookup("admin-rpc"))
+	if err != nil {
+		log.Panicf("bind admin-rpc flag: %v", err)
+	}
+
 	mutex.Unlock()
 }
@@ -0,0 +1,38 @@
+// Package migrate embeds the database migration scripts and applies them.
+package migrate
+
+import (
+	"context"
+	"embed"
+)
+
+// MySQL contains the MySQL migrations.
+//
+//go:embed mysql/*.sql
+var MySQL embed.FS
+
+// SQLite contains the SQLite migrations, applied when an empty SQLite database is opened.
+//
+//go:embed sqlite/*.sql
+var SQLite embed.FS
+
+// Status is the schema version of a database.
+type Status struct {
+	Version int64 // applied schema version, 0 if no migration is applied
+	Latest  int64 // latest schema version known to the binary
+	Dirty   bool  // the last migration failed, the schema must be fixed and the version forced
+}
+
+// Migrator applies the schema migrations of a database.
+type Migrator interface {
+	// Up applies all pending migrations.
+	Up(ctx context.Context) error
+	// Down rolls back the given number of applied migrations.
+	Down(ctx context.Context, steps int) error
+	// Force sets the schema version without applying migrations and clears the dirty flag.
+	Force(ctx context.Context, version int64) error
+	// Status returns the schema version.
+	Status(ctx context.Context) (Status, error)
+	// Close closes the database.
+	Close() error
+}
@@ -0,0 +1,14 @@
+CREATE TABLE job (
+  id BINARY(16) PRIMARY KEY,
+  service_id BINARY(16) NOT NULL,
+  languages JSON,
+  status ENUM('PENDING', 'RUNNING', 'SUCCEEDED', 'FAILED') NOT NULL,
+  total INT NOT NULL DEFAULT 0,
+  done INT NOT NULL DEFAULT 0,
+  error TEXT,
+  created_at DATETIME(6) NOT NULL,
+  updated_at DATETIME(6) NOT NULL,
+
+  INDEX (status, created_at),
+  FOREIGN KEY (service_id) REFERENCES service (id) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE message DROP COLUMN status_reason;
@@ -0,0 +1 @@
+ALTER TABLE service ADD COLUMN translator_routing JSON;
@@ -0,0 +1 @@
+DROP TABLE translator_usage;
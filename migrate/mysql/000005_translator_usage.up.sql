@@ -0,0 +1,10 @@
+CREATE TABLE translator_usage (
+  service_id BINARY(16) NOT NULL,
+  date DATE NOT NULL,
+  translator VARCHAR(255) NOT NULL,
+  characters BIGINT NOT NULL DEFAULT 0,
+  requests BIGINT NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (service_id, date, translator),
+  FOREIGN KEY (service_id) REFERENCES service (id) ON DELETE CASCADE
+);
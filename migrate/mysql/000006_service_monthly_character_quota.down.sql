@@ -0,0 +1 @@
+ALTER TABLE service DROP COLUMN monthly_character_quota;
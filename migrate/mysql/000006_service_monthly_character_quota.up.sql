@@ -0,0 +1 @@
+ALTER TABLE service ADD COLUMN monthly_character_quota BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE message DROP COLUMN quality_score;
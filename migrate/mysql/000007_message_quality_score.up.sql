@@ -0,0 +1 @@
+ALTER TABLE message ADD COLUMN quality_score DOUBLE;
@@ -0,0 +1 @@
+ALTER TABLE message ADD COLUMN plural_id TEXT;
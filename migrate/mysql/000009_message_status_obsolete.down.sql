@@ -0,0 +1,3 @@
+DELETE FROM message WHERE status = 'OBSOLETE';
+
+ALTER TABLE message MODIFY status ENUM('UNTRANSLATED', 'FUZZY', 'TRANSLATED') NOT NULL;
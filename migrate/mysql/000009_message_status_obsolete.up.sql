@@ -0,0 +1 @@
+ALTER TABLE message MODIFY status ENUM('UNTRANSLATED', 'FUZZY', 'TRANSLATED', 'OBSOLETE') NOT NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE message_revision (
+  id BINARY(16) PRIMARY KEY,
+  translation_id BINARY(16) NOT NULL,
+  message_id TEXT NOT NULL,
+  old_message TEXT,
+  old_status ENUM('UNTRANSLATED', 'FUZZY', 'TRANSLATED', 'OBSOLETE'),
+  new_message TEXT NOT NULL,
+  new_status ENUM('UNTRANSLATED', 'FUZZY', 'TRANSLATED', 'OBSOLETE') NOT NULL,
+  actor VARCHAR(255) NOT NULL DEFAULT '',
+  created_at DATETIME(6) NOT NULL,
+
+  INDEX (translation_id, message_id(255), created_at),
+  FOREIGN KEY (translation_id) REFERENCES translation (id) ON DELETE CASCADE
+);
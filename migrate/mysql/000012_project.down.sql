@@ -0,0 +1,5 @@
+ALTER TABLE service
+DROP FOREIGN KEY service_project,
+DROP COLUMN project_id;
+
+DROP TABLE project;
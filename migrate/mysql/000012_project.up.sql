@@ -0,0 +1,15 @@
+CREATE TABLE project (
+  id BINARY(16) PRIMARY KEY,
+  name VARCHAR(255) NOT NULL
+);
+
+-- Existing services are moved to the default project.
+INSERT INTO project (id, name) VALUES (UUID_TO_BIN('00000000-0000-0000-0000-000000000001'), 'Default');
+
+ALTER TABLE service ADD COLUMN project_id BINARY(16);
+
+UPDATE service SET project_id = UUID_TO_BIN('00000000-0000-0000-0000-000000000001');
+
+ALTER TABLE service
+MODIFY project_id BINARY(16) NOT NULL,
+ADD CONSTRAINT service_project FOREIGN KEY (project_id) REFERENCES project (id);
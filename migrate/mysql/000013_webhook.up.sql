@@ -0,0 +1,30 @@
+CREATE TABLE webhook (
+  id BINARY(16) PRIMARY KEY,
+  service_id BINARY(16) NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  events JSON NOT NULL,
+  created_at DATETIME(6) NOT NULL,
+
+  INDEX (service_id, created_at),
+  FOREIGN KEY (service_id) REFERENCES service (id) ON DELETE CASCADE
+);
+
+CREATE TABLE webhook_delivery (
+  id BINARY(16) PRIMARY KEY,
+  webhook_id BINARY(16) NOT NULL,
+  service_id BINARY(16) NOT NULL,
+  event ENUM('TRANSLATION_COMPLETED', 'ORIGINAL_CHANGED', 'JOB_FINISHED') NOT NULL,
+  payload TEXT NOT NULL,
+  status ENUM('PENDING', 'SUCCEEDED', 'FAILED') NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  response_status INT NOT NULL DEFAULT 0,
+  error TEXT,
+  next_attempt_at DATETIME(6) NOT NULL,
+  created_at DATETIME(6) NOT NULL,
+  updated_at DATETIME(6) NOT NULL,
+
+  INDEX (webhook_id, created_at),
+  INDEX (status, created_at),
+  FOREIGN KEY (webhook_id) REFERENCES webhook (id) ON DELETE CASCADE
+);
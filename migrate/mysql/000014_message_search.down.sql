@@ -0,0 +1 @@
+ALTER TABLE message DROP INDEX message_search;
@@ -0,0 +1,2 @@
+-- Full-text search index of the message IDs and texts, see SearchMessages.
+ALTER TABLE message ADD FULLTEXT INDEX message_search (id, message);
@@ -0,0 +1,2 @@
+-- Order of the messages in the translation, messages are updated in place when the translation is saved.
+ALTER TABLE message ADD COLUMN ordinal INT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE message DROP INDEX message_binary_id, DROP COLUMN binary_id;
+
+ALTER TABLE translation MODIFY language VARCHAR(20) NOT NULL;
@@ -0,0 +1,6 @@
+-- Languages and message IDs are compared byte-wise, as in the other repos, without casts that prevent index use.
+-- The id column keeps its case-insensitive collation, shared by the columns of the message_search full-text index.
+ALTER TABLE translation MODIFY language VARCHAR(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL;
+
+ALTER TABLE message ADD COLUMN binary_id BLOB AS (CAST(id AS BINARY)) STORED NOT NULL,
+  ADD INDEX message_binary_id (translation_id, binary_id(255));
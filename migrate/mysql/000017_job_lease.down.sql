@@ -0,0 +1 @@
+ALTER TABLE job DROP COLUMN owner, DROP COLUMN lease_until;
@@ -0,0 +1,2 @@
+-- A running job is claimed by a server until the lease expires, see repo.JobsRepo.ClaimJob.
+ALTER TABLE job ADD COLUMN owner BINARY(16), ADD COLUMN lease_until DATETIME(6);
@@ -0,0 +1,280 @@
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SQLMigrator applies the migration scripts to a SQL database.
+//
+// The schema version is recorded in the schema_migrations table the same way as golang-migrate does,
+// so that the database can also be managed with the migrate CLI.
+type SQLMigrator struct {
+	db   *sql.DB
+	fsys fs.FS
+	dir  string // directory of the migration scripts in fsys, e.g. "mysql"
+}
+
+// migration is a pair of migration scripts, e.g. 000001_initial_schema.up.sql and 000001_initial_schema.down.sql.
+type migration struct {
+	up, down string // paths of the scripts
+	version  int64
+}
+
+// NewSQLMigrator creates a migrator of the database with the migration scripts in the fsys directory.
+func NewSQLMigrator(db *sql.DB, fsys fs.FS, dir string) *SQLMigrator {
+	return &SQLMigrator{db: db, fsys: fsys, dir: dir}
+}
+
+// Up applies all migrations newer than the schema version.
+func (m *SQLMigrator) Up(ctx context.Context) error {
+	current, err := m.cleanVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	migrations, err := m.migrations()
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range migrations {
+		if mig.version <= current {
+			continue
+		}
+
+		err = m.apply(ctx, mig.up, mig.version, mig.version)
+		if err != nil {
+			return fmt.Errorf("apply migration '%s': %w", mig.up, err)
+		}
+	}
+
+	return nil
+}
+
+// Down rolls back the given number of applied migrations, starting from the schema version.
+func (m *SQLMigrator) Down(ctx context.Context, steps int) error {
+	current, err := m.cleanVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	migrations, err := m.migrations()
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(migrations, func(mig migration) bool { return mig.version == current })
+	if i == -1 && current != 0 {
+		return fmt.Errorf("no migration for schema version %d", current)
+	}
+
+	for ; steps > 0 && i >= 0; steps, i = steps-1, i-1 {
+		var previous int64 // 0 if the first migration is rolled back
+
+		if i > 0 {
+			previous = migrations[i-1].version
+		}
+
+		err = m.apply(ctx, migrations[i].down, migrations[i].version, previous)
+		if err != nil {
+			return fmt.Errorf("apply migration '%s': %w", migrations[i].down, err)
+		}
+	}
+
+	return nil
+}
+
+// Force sets the schema version without applying migrations and clears the dirty flag.
+func (m *SQLMigrator) Force(ctx context.Context, version int64) error {
+	err := m.ensureVersionTable(ctx)
+	if err != nil {
+		return err
+	}
+
+	return setVersion(ctx, m.db, version, false)
+}
+
+// Status returns the schema version and the latest version of the migration scripts.
+func (m *SQLMigrator) Status(ctx context.Context) (Status, error) {
+	var status Status
+
+	err := m.ensureVersionTable(ctx)
+	if err != nil {
+		return status, err
+	}
+
+	status.Version, status.Dirty, err = m.version(ctx)
+	if err != nil {
+		return status, err
+	}
+
+	migrations, err := m.migrations()
+	if err != nil {
+		return status, err
+	}
+
+	if len(migrations) > 0 {
+		status.Latest = migrations[len(migrations)-1].version
+	}
+
+	return status, nil
+}
+
+// Close closes the database.
+func (m *SQLMigrator) Close() error {
+	err := m.db.Close()
+	if err != nil {
+		return fmt.Errorf("close db: %w", err)
+	}
+
+	return nil
+}
+
+// migrations returns the migrations sorted by version.
+func (m *SQLMigrator) migrations() ([]migration, error) {
+	files, err := fs.Glob(m.fsys, path.Join(m.dir, "*.up.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("list migrations: %w", err)
+	}
+
+	migrations := make([]migration, 0, len(files))
+
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(path.Base(file), "_")
+
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse version of migration '%s': %w", file, err)
+		}
+
+		migrations = append(migrations, migration{
+			version: version,
+			up:      file,
+			down:    strings.TrimSuffix(file, ".up.sql") + ".down.sql",
+		})
+	}
+
+	slices.SortFunc(migrations, func(a, b migration) int { return cmp.Compare(a.version, b.version) })
+
+	return migrations, nil
+}
+
+// apply executes the migration script, the version is marked dirty until the script succeeds.
+// The script and the version change are executed in a single transaction, but note that
+// MySQL commits DDL statements implicitly, so a failed migration may be partially applied.
+func (m *SQLMigrator) apply(ctx context.Context, file string, dirtyVersion, version int64) error {
+	script, err := fs.ReadFile(m.fsys, file)
+	if err != nil {
+		return fmt.Errorf("read script: %w", err)
+	}
+
+	err = setVersion(ctx, m.db, dirtyVersion, true)
+	if err != nil {
+		return err
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	_, err = tx.ExecContext(ctx, string(script))
+	if err != nil {
+		return fmt.Errorf("execute script: %w", err)
+	}
+
+	err = setVersion(ctx, tx, version, false)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// cleanVersion returns the schema version, or an error if the last migration failed.
+func (m *SQLMigrator) cleanVersion(ctx context.Context) (int64, error) {
+	err := m.ensureVersionTable(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	version, dirty, err := m.version(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if dirty {
+		return 0, fmt.Errorf("schema version %d is dirty, fix the schema and force the version", version)
+	}
+
+	return version, nil
+}
+
+func (m *SQLMigrator) ensureVersionTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return nil
+}
+
+// version returns the schema version, 0 if no migration is applied.
+func (m *SQLMigrator) version(ctx context.Context) (int64, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+
+	err := m.db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("select schema version: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+// execer is implemented by both sql.DB and sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// setVersion replaces the schema version, version 0 is recorded as no rows unless dirty.
+func setVersion(ctx context.Context, db execer, version int64, dirty bool) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("delete schema version: %w", err)
+	}
+
+	if version == 0 && !dirty {
+		return nil
+	}
+
+	_, err = db.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)`, version, dirty)
+	if err != nil {
+		return fmt.Errorf("insert schema version: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,5 @@
+DROP TABLE translator_usage;
+DROP TABLE job;
+DROP TABLE message;
+DROP TABLE translation;
+DROP TABLE service;
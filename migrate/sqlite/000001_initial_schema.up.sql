@@ -0,0 +1,58 @@
+CREATE TABLE service (
+  id TEXT PRIMARY KEY,
+  name TEXT,
+  translator_routing TEXT,
+  monthly_character_quota INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE translation (
+  id TEXT PRIMARY KEY,
+  service_id TEXT NOT NULL,
+  language TEXT NOT NULL,
+  original BOOLEAN NOT NULL DEFAULT false,
+
+  UNIQUE (service_id, language),
+  FOREIGN KEY (service_id) REFERENCES service (id) ON DELETE CASCADE
+);
+
+CREATE TABLE message (
+  translation_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  message TEXT NOT NULL,
+  description TEXT,
+  plural_id TEXT,
+  status TEXT NOT NULL CHECK (status IN ('UNTRANSLATED', 'FUZZY', 'TRANSLATED')),
+  positions TEXT,
+  status_reason TEXT,
+  quality_score REAL,
+
+  PRIMARY KEY (translation_id, id),
+  FOREIGN KEY (translation_id) REFERENCES translation (id) ON DELETE CASCADE
+);
+
+CREATE TABLE job (
+  id TEXT PRIMARY KEY,
+  service_id TEXT NOT NULL,
+  languages TEXT,
+  status TEXT NOT NULL CHECK (status IN ('PENDING', 'RUNNING', 'SUCCEEDED', 'FAILED')),
+  total INTEGER NOT NULL DEFAULT 0,
+  done INTEGER NOT NULL DEFAULT 0,
+  error TEXT,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+
+  FOREIGN KEY (service_id) REFERENCES service (id) ON DELETE CASCADE
+);
+
+CREATE INDEX job_status_created_at ON job (status, created_at);
+
+CREATE TABLE translator_usage (
+  service_id TEXT NOT NULL,
+  date DATE NOT NULL,
+  translator TEXT NOT NULL,
+  characters INTEGER NOT NULL DEFAULT 0,
+  requests INTEGER NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (service_id, date, translator),
+  FOREIGN KEY (service_id) REFERENCES service (id) ON DELETE CASCADE
+);
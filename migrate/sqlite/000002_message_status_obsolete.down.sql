@@ -0,0 +1,31 @@
+-- SQLite cannot alter a CHECK constraint, the message table is recreated.
+CREATE TABLE message_old (
+  translation_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  message TEXT NOT NULL,
+  description TEXT,
+  plural_id TEXT,
+  status TEXT NOT NULL CHECK (status IN ('UNTRANSLATED', 'FUZZY', 'TRANSLATED')),
+  positions TEXT,
+  status_reason TEXT,
+  quality_score REAL,
+
+  PRIMARY KEY (translation_id, id),
+  FOREIGN KEY (translation_id) REFERENCES translation (id) ON DELETE CASCADE
+);
+
+INSERT INTO message_old SELECT
+  translation_id,
+  id,
+  message,
+  description,
+  plural_id,
+  status,
+  positions,
+  status_reason,
+  quality_score
+FROM message WHERE status != 'OBSOLETE' ORDER BY rowid;
+
+DROP TABLE message;
+
+ALTER TABLE message_old RENAME TO message;
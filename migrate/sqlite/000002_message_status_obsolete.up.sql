@@ -0,0 +1,32 @@
+-- SQLite cannot alter a CHECK constraint, the message table is recreated.
+CREATE TABLE message_new (
+  translation_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  message TEXT NOT NULL,
+  description TEXT,
+  plural_id TEXT,
+  status TEXT NOT NULL CHECK (status IN ('UNTRANSLATED', 'FUZZY', 'TRANSLATED', 'OBSOLETE')),
+  positions TEXT,
+  status_reason TEXT,
+  quality_score REAL,
+
+  PRIMARY KEY (translation_id, id),
+  FOREIGN KEY (translation_id) REFERENCES translation (id) ON DELETE CASCADE
+);
+
+-- Messages are loaded in the rowid order, keep it.
+INSERT INTO message_new SELECT
+  translation_id,
+  id,
+  message,
+  description,
+  plural_id,
+  status,
+  positions,
+  status_reason,
+  quality_score
+FROM message ORDER BY rowid;
+
+DROP TABLE message;
+
+ALTER TABLE message_new RENAME TO message;
@@ -0,0 +1,15 @@
+CREATE TABLE message_revision (
+  id TEXT PRIMARY KEY,
+  translation_id TEXT NOT NULL,
+  message_id TEXT NOT NULL,
+  old_message TEXT,
+  old_status TEXT CHECK (old_status IN ('UNTRANSLATED', 'FUZZY', 'TRANSLATED', 'OBSOLETE')),
+  new_message TEXT NOT NULL,
+  new_status TEXT NOT NULL CHECK (new_status IN ('UNTRANSLATED', 'FUZZY', 'TRANSLATED', 'OBSOLETE')),
+  actor TEXT NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL,
+
+  FOREIGN KEY (translation_id) REFERENCES translation (id) ON DELETE CASCADE
+);
+
+CREATE INDEX message_revision_message ON message_revision (translation_id, message_id, created_at);
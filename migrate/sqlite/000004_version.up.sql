@@ -0,0 +1,2 @@
+ALTER TABLE service ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE translation ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,5 @@
+DROP INDEX service_project;
+
+ALTER TABLE service DROP COLUMN project_id;
+
+DROP TABLE project;
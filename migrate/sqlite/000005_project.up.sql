@@ -0,0 +1,14 @@
+CREATE TABLE project (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL
+);
+
+-- Existing services are moved to the default project.
+INSERT INTO project (id, name) VALUES ('00000000-0000-0000-0000-000000000001', 'Default');
+
+-- SQLite cannot add a NOT NULL column referencing another table, the repo always sets the project.
+ALTER TABLE service ADD COLUMN project_id TEXT REFERENCES project (id);
+
+UPDATE service SET project_id = '00000000-0000-0000-0000-000000000001';
+
+CREATE INDEX service_project ON service (project_id);
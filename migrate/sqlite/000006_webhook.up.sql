@@ -0,0 +1,32 @@
+CREATE TABLE webhook (
+  id TEXT PRIMARY KEY,
+  service_id TEXT NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  events TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  FOREIGN KEY (service_id) REFERENCES service (id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_service ON webhook (service_id, created_at);
+
+CREATE TABLE webhook_delivery (
+  id TEXT PRIMARY KEY,
+  webhook_id TEXT NOT NULL,
+  service_id TEXT NOT NULL,
+  event TEXT NOT NULL CHECK (event IN ('TRANSLATION_COMPLETED', 'ORIGINAL_CHANGED', 'JOB_FINISHED')),
+  payload TEXT NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  response_status INTEGER NOT NULL DEFAULT 0,
+  error TEXT,
+  next_attempt_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+
+  FOREIGN KEY (webhook_id) REFERENCES webhook (id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_delivery_webhook ON webhook_delivery (webhook_id, created_at);
+CREATE INDEX webhook_delivery_status ON webhook_delivery (status, created_at);
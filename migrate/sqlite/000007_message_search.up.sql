@@ -0,0 +1,25 @@
+-- Full-text search index of the message IDs and texts, kept in sync with the message table by triggers.
+-- Words are runs of letters, digits and underscores, the same as in MySQL full-text search.
+CREATE VIRTUAL TABLE message_search USING fts5 (
+  id,
+  message,
+  content = 'message',
+  content_rowid = 'rowid',
+  tokenize = "unicode61 remove_diacritics 0 tokenchars '_'"
+);
+
+CREATE TRIGGER message_search_insert AFTER INSERT ON message BEGIN
+  INSERT INTO message_search (rowid, id, message) VALUES (new.rowid, new.id, new.message);
+END;
+
+CREATE TRIGGER message_search_delete AFTER DELETE ON message BEGIN
+  INSERT INTO message_search (message_search, rowid, id, message) VALUES ('delete', old.rowid, old.id, old.message);
+END;
+
+CREATE TRIGGER message_search_update AFTER UPDATE OF id, message ON message BEGIN
+  INSERT INTO message_search (message_search, rowid, id, message) VALUES ('delete', old.rowid, old.id, old.message);
+  INSERT INTO message_search (rowid, id, message) VALUES (new.rowid, new.id, new.message);
+END;
+
+-- Index the existing messages.
+INSERT INTO message_search (message_search) VALUES ('rebuild');
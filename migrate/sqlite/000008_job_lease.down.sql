@@ -0,0 +1,3 @@
+ALTER TABLE job DROP COLUMN lease_until;
+
+ALTER TABLE job DROP COLUMN owner;
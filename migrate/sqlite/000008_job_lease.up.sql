@@ -0,0 +1,4 @@
+-- A running job is claimed by a server until the lease expires, see repo.JobsRepo.ClaimJob.
+ALTER TABLE job ADD COLUMN owner TEXT;
+
+ALTER TABLE job ADD COLUMN lease_until DATETIME;
@@ -0,0 +1,348 @@
+// Package backup writes and restores backend-neutral archives of the whole datastore.
+//
+// An archive is a gzip compressed stream of JSON values - a header with the format version and projects,
+// followed by a record per service with its translations, message revisions, usage, jobs, webhooks
+// and webhook deliveries. Webhook secrets are included, so archives must be kept as safe as the database.
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+// Version is the archive format version written by Write.
+// Version 2 adds projects, services of version 1 archives are restored to the default project.
+// Version 3 adds message revisions, webhooks and webhook deliveries.
+const Version = 3
+
+// ErrNotEmpty is returned by Restore if the target repo already contains services.
+var ErrNotEmpty = errors.New("repo is not empty")
+
+type header struct {
+	CreatedAt time.Time       `json:"createdAt"`
+	Projects  []model.Project `json:"projects"`
+	Version   int             `json:"version"`
+}
+
+type record struct {
+	Translations      model.Translations      `json:"translations"`
+	Revisions         []model.MessageRevision `json:"revisions"`
+	Usage             []model.Usage           `json:"usage"`
+	Jobs              []model.Job             `json:"jobs"`
+	Webhooks          []model.Webhook         `json:"webhooks"`
+	WebhookDeliveries []model.WebhookDelivery `json:"webhookDeliveries"`
+	Service           model.Service           `json:"service"`
+}
+
+// Stats counts the data in an archive.
+type Stats struct {
+	Services          int
+	Translations      int
+	Messages          int
+	Revisions         int // message revisions
+	Usage             int // usage records
+	Jobs              int
+	Webhooks          int
+	WebhookDeliveries int
+}
+
+func (s *Stats) add(rec *record) {
+	s.Services++
+	s.Translations += len(rec.Translations)
+	s.Usage += len(rec.Usage)
+	s.Revisions += len(rec.Revisions)
+	s.Jobs += len(rec.Jobs)
+	s.Webhooks += len(rec.Webhooks)
+	s.WebhookDeliveries += len(rec.WebhookDeliveries)
+
+	for _, translation := range rec.Translations {
+		s.Messages += len(translation.Messages)
+	}
+}
+
+// Write writes an archive of all projects and services with their translations, message revisions, usage, jobs,
+// webhooks and webhook deliveries to w.
+// The data is read in a single transaction of the repo, so the archive is a consistent snapshot.
+func Write(ctx context.Context, w io.Writer, r repo.Repo) (Stats, error) {
+	var stats Stats
+
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+
+	err := r.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		projects, inErr := r.LoadProjects(ctx)
+		if inErr != nil {
+			return fmt.Errorf("load projects: %w", inErr)
+		}
+
+		inErr = enc.Encode(header{Version: Version, CreatedAt: time.Now().UTC(), Projects: projects})
+		if inErr != nil {
+			return fmt.Errorf("write header: %w", inErr)
+		}
+
+		services, inErr := r.LoadServices(ctx, repo.LoadServicesOpts{})
+		if inErr != nil {
+			return fmt.Errorf("load services: %w", inErr)
+		}
+
+		for _, service := range services {
+			rec, inErr := loadRecord(ctx, r, service)
+			if inErr != nil {
+				return fmt.Errorf("service '%s': %w", service.ID, inErr)
+			}
+
+			inErr = enc.Encode(rec)
+			if inErr != nil {
+				return fmt.Errorf("write service '%s': %w", service.ID, inErr)
+			}
+
+			stats.add(rec)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Stats{}, fmt.Errorf("read repo: %w", err)
+	}
+
+	err = gz.Close()
+	if err != nil {
+		return Stats{}, fmt.Errorf("close gzip writer: %w", err)
+	}
+
+	return stats, nil
+}
+
+func loadRecord(ctx context.Context, r repo.Repo, service model.Service) (*record, error) {
+	translations, err := r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("load translations: %w", err)
+	}
+
+	rec := &record{Service: service, Translations: translations}
+
+	for _, translation := range translations {
+		revisions, err := r.LoadTranslationRevisions(ctx, service.ID, translation.Language)
+		if err != nil {
+			return nil, fmt.Errorf("load revisions of translation '%s': %w", translation.Language, err)
+		}
+
+		rec.Revisions = append(rec.Revisions, revisions...)
+	}
+
+	rec.Usage, err = r.LoadUsage(ctx, service.ID, repo.LoadUsageOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("load usage: %w", err)
+	}
+
+	rec.Jobs, err = r.LoadJobs(ctx, repo.LoadJobsOpts{FilterServiceID: service.ID})
+	if err != nil {
+		return nil, fmt.Errorf("load jobs: %w", err)
+	}
+
+	rec.Webhooks, err = r.LoadWebhooks(ctx, service.ID)
+	if err != nil {
+		return nil, fmt.Errorf("load webhooks: %w", err)
+	}
+
+	for _, webhook := range rec.Webhooks {
+		deliveries, err := r.LoadWebhookDeliveries(ctx, repo.LoadWebhookDeliveriesOpts{FilterWebhookID: webhook.ID})
+		if err != nil {
+			return nil, fmt.Errorf("load deliveries of webhook '%s': %w", webhook.ID, err)
+		}
+
+		rec.WebhookDeliveries = append(rec.WebhookDeliveries, deliveries...)
+	}
+
+	return rec, nil
+}
+
+// Restore restores the archive from rd to the repo, the repo must not contain any services.
+// Each service is restored in a single transaction of the repo. If the restore fails,
+// the restored services and projects are deleted, leaving the repo as it was.
+func Restore(ctx context.Context, rd io.Reader, r repo.Repo) (stats Stats, err error) {
+	services, err := r.LoadServices(ctx, repo.LoadServicesOpts{})
+	if err != nil {
+		return Stats{}, fmt.Errorf("load services: %w", err)
+	}
+
+	if len(services) > 0 {
+		return Stats{}, ErrNotEmpty
+	}
+
+	existing, err := r.LoadProjects(ctx)
+	if err != nil {
+		return Stats{}, fmt.Errorf("load projects: %w", err)
+	}
+
+	gz, err := gzip.NewReader(rd)
+	if err != nil {
+		return Stats{}, fmt.Errorf("read gzip header: %w", err)
+	}
+
+	defer gz.Close()
+
+	dec := json.NewDecoder(gz)
+
+	var h header
+
+	err = dec.Decode(&h)
+	if err != nil {
+		return Stats{}, fmt.Errorf("read header: %w", err)
+	}
+
+	if h.Version < 1 || h.Version > Version {
+		return Stats{}, fmt.Errorf("unsupported archive version %d, supported up to %d", h.Version, Version)
+	}
+
+	var restored restoredData
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, restored.delete(context.WithoutCancel(ctx), r))
+			stats = Stats{}
+		}
+	}()
+
+	err = r.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		for i := range h.Projects {
+			if !slices.ContainsFunc(existing, func(p model.Project) bool { return p.ID == h.Projects[i].ID }) {
+				restored.projectIDs = append(restored.projectIDs, h.Projects[i].ID)
+			}
+
+			inErr := r.SaveProject(ctx, &h.Projects[i])
+			if inErr != nil {
+				return fmt.Errorf("project '%s': %w", h.Projects[i].ID, inErr)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		restored.projectIDs = nil // nothing saved
+
+		return Stats{}, fmt.Errorf("restore projects: %w", err)
+	}
+
+	for {
+		var rec record
+
+		err = dec.Decode(&rec)
+
+		switch {
+		case errors.Is(err, io.EOF):
+			return stats, nil
+		case err != nil:
+			return Stats{}, fmt.Errorf("read service: %w", err)
+		}
+
+		err = r.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+			return restoreRecord(ctx, r, &rec, h.Version)
+		})
+		if err != nil {
+			return Stats{}, fmt.Errorf("restore service '%s': %w", rec.Service.ID, err)
+		}
+
+		restored.serviceIDs = append(restored.serviceIDs, rec.Service.ID)
+
+		stats.add(&rec)
+	}
+}
+
+// restoredData tracks the entities created by Restore, to delete them if the restore fails.
+type restoredData struct {
+	serviceIDs []uuid.UUID
+	projectIDs []uuid.UUID
+}
+
+// delete deletes the restored services with their data, then the restored projects.
+func (d *restoredData) delete(ctx context.Context, r repo.Repo) error {
+	var errs []error
+
+	for _, id := range d.serviceIDs {
+		err := r.DeleteService(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete restored service '%s': %w", id, err))
+		}
+	}
+
+	for _, id := range d.projectIDs {
+		err := r.DeleteProject(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete restored project '%s': %w", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func restoreRecord(ctx context.Context, r repo.Repo, rec *record, version int) error {
+	err := r.SaveService(ctx, &rec.Service)
+	if err != nil {
+		return fmt.Errorf("save service: %w", err)
+	}
+
+	for i := range rec.Translations {
+		translation := &rec.Translations[i]
+
+		err = r.SaveTranslation(ctx, rec.Service.ID, translation)
+		if err != nil {
+			return fmt.Errorf("save translation '%s': %w", translation.Language, err)
+		}
+
+		// Archives before version 3 have no revisions, the revisions recorded by SaveTranslation are kept.
+		if version < 3 { //nolint:mnd
+			continue
+		}
+
+		revisions := slices.DeleteFunc(slices.Clone(rec.Revisions), func(revision model.MessageRevision) bool {
+			return revision.Language != translation.Language
+		})
+
+		err = r.ReplaceTranslationRevisions(ctx, rec.Service.ID, translation.Language, revisions)
+		if err != nil {
+			return fmt.Errorf("save revisions of translation '%s': %w", translation.Language, err)
+		}
+	}
+
+	for i := range rec.Usage {
+		err = r.AddUsage(ctx, &rec.Usage[i])
+		if err != nil {
+			return fmt.Errorf("add usage: %w", err)
+		}
+	}
+
+	for i := range rec.Jobs {
+		err = r.SaveJob(ctx, &rec.Jobs[i])
+		if err != nil {
+			return fmt.Errorf("save job '%s': %w", rec.Jobs[i].ID, err)
+		}
+	}
+
+	for i := range rec.Webhooks {
+		err = r.SaveWebhook(ctx, &rec.Webhooks[i])
+		if err != nil {
+			return fmt.Errorf("save webhook '%s': %w", rec.Webhooks[i].ID, err)
+		}
+	}
+
+	for i := range rec.WebhookDeliveries {
+		err = r.SaveWebhookDelivery(ctx, &rec.WebhookDeliveries[i])
+		if err != nil {
+			return fmt.Errorf("save webhook delivery '%s': %w", rec.WebhookDeliveries[i].ID, err)
+		}
+	}
+
+	return nil
+}
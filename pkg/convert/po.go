@@ -69,6 +69,11 @@ func FromPo(b []byte, originalOverride *bool) (model.Translation, error) {
 	}
 
 	for _, node := range file.Messages {
+		// Obsolete entries are not part of the translation, see model.MessageStatusObsolete.
+		if node.Obsolete {
+			continue
+		}
+
 		mf2Msg, err := msgNodeToMF2(node, getMessages)
 		if err != nil {
 			return model.Translation{}, fmt.Errorf("convert message node to mf2 format: %w", err)
@@ -284,8 +289,11 @@ func ToPo(t model.Translation) ([]byte, error) { //nolint:gocognit
 			poMsg.ExtractedComments = strings.Split(message.Description, "\n")
 		}
 
-		if message.Status == model.MessageStatusFuzzy {
+		switch message.Status { //nolint:exhaustive
+		case model.MessageStatusFuzzy:
 			poMsg.Flags = append(poMsg.Flags, "fuzzy")
+		case model.MessageStatusObsolete:
+			poMsg.Obsolete = true
 		}
 
 		// Parse mf2 message.
@@ -482,3 +482,45 @@ func assertTranslation(t *testing.T, want, got model.Translation) {
 		t.Errorf("want equal translations\n%s", v)
 	}
 }
+
+// Test_PoObsolete tests that obsolete messages are written as obsolete entries and skipped when read.
+func Test_PoObsolete(t *testing.T) {
+	t.Parallel()
+
+	translation := model.Translation{
+		Language: language.Latvian,
+		Messages: []model.Message{
+			{ID: "Hello", Message: "Sveiki", Status: model.MessageStatusTranslated},
+			{ID: "Goodbye", Message: "Uz redzēšanos", Status: model.MessageStatusObsolete},
+		},
+	}
+
+	want := `msgid ""
+msgstr ""
+"Language: lv\n"
+
+msgid "Hello"
+msgstr "Sveiki"
+
+#~ msgid "Goodbye"
+#~ msgstr "Uz redzēšanos"
+`
+
+	got, err := ToPo(translation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	requireEqualPO(t, want, string(got))
+
+	gotTranslation, err := FromPo(got, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(gotTranslation.Messages) != 1 || gotTranslation.Messages[0].ID != "Hello" {
+		t.Errorf("want only message 'Hello', got %v", gotTranslation.Messages)
+	}
+}
@@ -84,13 +84,19 @@ func Test_FromXliff2(t *testing.T) {
 
 	originalTranslation := testutilrand.ModelTranslation(
 		3,
-		[]testutilrand.ModelMessageOption{testutilrand.WithStatus(model.MessageStatusTranslated)},
+		[]testutilrand.ModelMessageOption{
+			testutilrand.WithStatus(model.MessageStatusTranslated),
+			testutilrand.WithPluralID(""), // XLIFF does not support plural IDs
+		},
 		testutilrand.WithOriginal(true), testutilrand.WithSimpleMF2Messages(),
 	)
 
 	nonOriginalTranslation := testutilrand.ModelTranslation(
 		3,
-		[]testutilrand.ModelMessageOption{testutilrand.WithStatus(model.MessageStatusUntranslated)},
+		[]testutilrand.ModelMessageOption{
+			testutilrand.WithStatus(model.MessageStatusUntranslated),
+			testutilrand.WithPluralID(""), // XLIFF does not support plural IDs
+		},
 		testutilrand.WithOriginal(false), testutilrand.WithSimpleMF2Messages(),
 	)
 
@@ -223,6 +229,7 @@ func Test_TransformXLIFF2(t *testing.T) {
 	msgOpts := []testutilrand.ModelMessageOption{
 		// Enclose message in curly braces, as ToXliff2() removes them, and FromXliff2() adds them again
 		testutilrand.WithStatus(model.MessageStatusTranslated),
+		testutilrand.WithPluralID(""), // XLIFF does not support plural IDs
 	}
 
 	conf := &quick.Config{
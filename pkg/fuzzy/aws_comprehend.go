@@ -0,0 +1,116 @@
+package fuzzy
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/service/comprehend"
+	"go.expect.digital/translate/pkg/model"
+	"golang.org/x/text/language"
+)
+
+// comprehendMinTextLength is the minimum number of characters accepted by Amazon Comprehend language detection.
+const comprehendMinTextLength = 20
+
+// --------------------Definitions--------------------
+
+// Interface that defines some of the methods of the Amazon Comprehend client.
+// This interface helps to mock the Amazon Comprehend client in unit tests.
+// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/comprehend#Client
+type comprehendClient interface {
+	DetectDominantLanguage(
+		ctx context.Context,
+		params *comprehend.DetectDominantLanguageInput,
+		optFns ...func(*comprehend.Options),
+	) (*comprehend.DetectDominantLanguageOutput, error)
+}
+
+// AWSComprehend implements the LanguageDetector interface.
+type AWSComprehend struct {
+	client comprehendClient
+}
+
+type AWSComprehendOption func(*AWSComprehend) error
+
+// WithComprehendClient sets the Amazon Comprehend client.
+func WithComprehendClient(c comprehendClient) AWSComprehendOption {
+	return func(awsc *AWSComprehend) error {
+		awsc.client = c
+		return nil
+	}
+}
+
+// WithDefaultComprehendClient creates a new Amazon Comprehend client with credentials from the viper.
+func WithDefaultComprehendClient(ctx context.Context) AWSComprehendOption {
+	return func(awsc *AWSComprehend) error {
+		cfg, err := defaultAWSConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("with default client: %w", err)
+		}
+
+		awsc.client = comprehend.NewFromConfig(cfg)
+
+		return nil
+	}
+}
+
+// NewAWSComprehend creates a new Amazon Comprehend language detector.
+func NewAWSComprehend(opts ...AWSComprehendOption) (*AWSComprehend, error) {
+	awsc := &AWSComprehend{}
+
+	for _, opt := range opts {
+		err := opt(awsc)
+		if err != nil {
+			return nil, fmt.Errorf("apply opt: %w", err)
+		}
+	}
+
+	return awsc, nil
+}
+
+// --------------------Methods--------------------
+
+// DetectLanguage detects the dominant language of the translation messages using Amazon Comprehend.
+func (a *AWSComprehend) DetectLanguage(
+	ctx context.Context,
+	translation *model.Translation,
+) (LanguageDetection, error) {
+	und := LanguageDetection{Language: language.Und}
+
+	if translation == nil {
+		return und, nil
+	}
+
+	text, err := detectionText(translation)
+	if err != nil {
+		return LanguageDetection{}, fmt.Errorf("aws comprehend: %w", err)
+	}
+
+	// Amazon Comprehend rejects too short texts.
+	if utf8.RuneCountInString(text) < comprehendMinTextLength {
+		return und, nil
+	}
+
+	output, err := a.client.DetectDominantLanguage(ctx, &comprehend.DetectDominantLanguageInput{Text: &text})
+	if err != nil {
+		return LanguageDetection{}, fmt.Errorf("aws comprehend client: detect dominant language: %w", err)
+	}
+
+	detection := und
+
+	for _, dominant := range output.Languages {
+		if dominant.LanguageCode == nil || dominant.Score == nil || float64(*dominant.Score) <= detection.Confidence {
+			continue
+		}
+
+		lang, parseErr := language.Parse(*dominant.LanguageCode)
+		if parseErr != nil {
+			continue
+		}
+
+		detection = LanguageDetection{Language: lang, Confidence: float64(*dominant.Score)}
+	}
+
+	return detection, nil
+}
@@ -6,15 +6,21 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/translate"
+	"github.com/aws/aws-sdk-go-v2/service/translate/types"
 	"github.com/spf13/viper"
 	"go.expect.digital/translate/pkg/model"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/text/language"
 )
 
+// awsDefaultConcurrency is the default number of concurrent requests to the AWS Translate API.
+const awsDefaultConcurrency = 10
+
 // --------------------Definitions--------------------
 
 // Interface that defines some of the methods of the AWS Translate client.
@@ -26,11 +32,18 @@ type awsClient interface {
 		params *translate.TranslateTextInput,
 		optFns ...func(*translate.Options),
 	) (*translate.TranslateTextOutput, error)
+	TranslateDocument(
+		ctx context.Context,
+		params *translate.TranslateDocumentInput,
+		optFns ...func(*translate.Options),
+	) (*translate.TranslateDocumentOutput, error)
 }
 
 // AWSTranslate implements the Translator interface.
 type AWSTranslate struct {
 	client awsClient
+	sem    semaphore
+	html   bool // translate texts as HTML documents, see getHTMLTexts
 }
 
 type AWSTranslateOption func(*AWSTranslate) error
@@ -43,32 +56,34 @@ func WithAWSClient(c awsClient) AWSTranslateOption {
 	}
 }
 
-// WithDefaultAWSClient creates a new AWS Translate client with credentials from the viper.
-func WithDefaultAWSClient(ctx context.Context) AWSTranslateOption {
+// WithAWSConcurrency limits the number of concurrent requests to the AWS Translate API.
+// If n is not positive, the default limit is used.
+func WithAWSConcurrency(n int) AWSTranslateOption {
 	return func(awst *AWSTranslate) error {
-		accessKey := viper.GetString("other.aws.access_key_id")
-		if accessKey == "" {
-			return errors.New("with default client: AWS access key is not set")
+		if n > 0 {
+			awst.sem = newSemaphore(n)
 		}
 
-		secretKey := viper.GetString("other.aws.secret_access_key")
-		if secretKey == "" {
-			return errors.New("with default client: AWS secret key is not set")
-		}
+		return nil
+	}
+}
 
-		region := viper.GetString("other.aws.region")
-		if region == "" {
-			return errors.New("with default client: AWS region is not set")
-		}
+// WithAWSHTML enables HTML mode - placeholders and markup are sent to the AWS Translate API
+// as HTML elements of a text/html document instead of simplified placeholders '{$d}',
+// so that paired markup, e.g. '{#b}...{/b}', is translated as a unit with the text it encloses.
+func WithAWSHTML(enabled bool) AWSTranslateOption {
+	return func(awst *AWSTranslate) error {
+		awst.html = enabled
+		return nil
+	}
+}
 
-		// Create a new AWS SDK config
-		cfg, err := config.LoadDefaultConfig(ctx,
-			config.WithRegion(region),
-			config.WithHTTPClient(&http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}),
-			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
-		)
+// WithDefaultAWSClient creates a new AWS Translate client with credentials from the viper.
+func WithDefaultAWSClient(ctx context.Context) AWSTranslateOption {
+	return func(awst *AWSTranslate) error {
+		cfg, err := defaultAWSConfig(ctx)
 		if err != nil {
-			return fmt.Errorf("load default AWS SDK configuration: %w", err)
+			return fmt.Errorf("with default client: %w", err)
 		}
 
 		awst.client = translate.NewFromConfig(cfg)
@@ -79,7 +94,7 @@ func WithDefaultAWSClient(ctx context.Context) AWSTranslateOption {
 
 // NewAWSTranslate creates a new AWS Translate service.
 func NewAWSTranslate(ctx context.Context, opts ...AWSTranslateOption) (*AWSTranslate, error) {
-	awst := &AWSTranslate{}
+	awst := &AWSTranslate{sem: newSemaphore(awsDefaultConcurrency)}
 
 	for _, opt := range opts {
 		optErr := opt(awst)
@@ -115,32 +130,64 @@ func (a *AWSTranslate) Translate(ctx context.Context,
 		return &model.Translation{Language: targetLanguage, Original: translation.Original}, nil
 	}
 
+	// Adapt plural variants to the plural categories of the target language
+	translation, err := expandPlurals(translation, targetLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("aws translate: expand plurals: %w", err)
+	}
+
 	// Retrieve all translatable text from translation
-	texts, err := getTexts(translation)
+	extract, build := getTexts, buildTranslated
+	if a.html {
+		extract, build = getHTMLTexts, buildTranslatedHTML
+	}
+
+	texts, err := extract(translation)
 	if err != nil {
 		return nil, fmt.Errorf("aws translate: get texts: %w", err)
 	}
 
-	translatedTexts := make([]string, 0, len(texts))
+	translatedTexts := make([]string, len(texts))
+
+	// AWS Translate accepts one text per request, send them concurrently within the limit of a.sem.
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(a.sem.limit())
 
 	for i := range texts {
-		// TODO: Use TranslateDocument, to minimize the number of requests?
-		// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/translate#Client.TranslateDocument
-		translateOutput, translateErr := a.client.TranslateText(ctx,
-			&translate.TranslateTextInput{
-				TargetLanguageCode: awsLanguage(targetLanguage),
-				SourceLanguageCode: awsLanguage(translation.Language),
-				Text:               new(texts[i]), // Maximum text size limit accepted by the AWS Translate API - 10000 bytes.
-			})
-		if translateErr != nil {
-			return nil, fmt.Errorf("aws translate: translate text #%d: %w", i, translateErr)
-		}
+		g.Go(func() error {
+			semErr := a.sem.acquire(gctx)
+			if semErr != nil {
+				return fmt.Errorf("translate text #%d: %w", i, semErr)
+			}
+
+			defer a.sem.release()
+
+			translateErr := MeterRequest(gctx, texts[i])
+			if translateErr != nil {
+				return fmt.Errorf("translate text #%d: %w", i, translateErr)
+			}
+
+			if a.html {
+				translatedTexts[i], translateErr = a.translateHTML(gctx, texts[i], translation.Language, targetLanguage)
+			} else {
+				translatedTexts[i], translateErr = a.translateText(gctx, texts[i], translation.Language, targetLanguage)
+			}
 
-		translatedTexts = append(translatedTexts, *translateOutput.TranslatedText)
+			if translateErr != nil {
+				return fmt.Errorf("translate text #%d: %w", i, translateErr)
+			}
+
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if err != nil {
+		return nil, fmt.Errorf("aws translate: %w", err)
 	}
 
 	// build translation with new translated text
-	translated, err := buildTranslated(translation, translatedTexts, targetLanguage)
+	translated, err := build(translation, translatedTexts, targetLanguage)
 	if err != nil {
 		return nil, fmt.Errorf("aws translate: build translated: %w", err)
 	}
@@ -148,6 +195,39 @@ func (a *AWSTranslate) Translate(ctx context.Context,
 	return translated, nil
 }
 
+// translateText translates plain text.
+func (a *AWSTranslate) translateText(ctx context.Context, text string, source, target language.Tag) (string, error) {
+	// TODO: Use TranslateDocument, to minimize the number of requests?
+	// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/translate#Client.TranslateDocument
+	output, err := a.client.TranslateText(ctx, &translate.TranslateTextInput{
+		TargetLanguageCode: awsLanguage(target),
+		SourceLanguageCode: awsLanguage(source),
+		Text:               &text, // Maximum text size limit accepted by the AWS Translate API - 10000 bytes.
+	})
+	if err != nil {
+		return "", fmt.Errorf("translate text: %w", err)
+	}
+
+	return *output.TranslatedText, nil
+}
+
+// translateHTML translates HTML text as a text/html document.
+func (a *AWSTranslate) translateHTML(ctx context.Context, text string, source, target language.Tag) (string, error) {
+	output, err := a.client.TranslateDocument(ctx, &translate.TranslateDocumentInput{
+		TargetLanguageCode: awsLanguage(target),
+		SourceLanguageCode: awsLanguage(source),
+		Document: &types.Document{
+			Content:     []byte(text), // Maximum document size limit accepted by the AWS Translate API - 100 KB.
+			ContentType: new("text/html"),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("translate document: %w", err)
+	}
+
+	return string(output.TranslatedDocument.Content), nil
+}
+
 // helpers
 
 // awsLanguage normalizes language.Tag to be usable by AWS translate.
@@ -164,3 +244,33 @@ func awsLanguage(language language.Tag) *string {
 
 	return &lang
 }
+
+// defaultAWSConfig loads AWS SDK configuration with credentials and region from the viper.
+func defaultAWSConfig(ctx context.Context) (aws.Config, error) {
+	accessKey := viper.GetString("other.aws.access_key_id")
+	if accessKey == "" {
+		return aws.Config{}, errors.New("AWS access key is not set")
+	}
+
+	secretKey := viper.GetString("other.aws.secret_access_key")
+	if secretKey == "" {
+		return aws.Config{}, errors.New("AWS secret key is not set")
+	}
+
+	region := viper.GetString("other.aws.region")
+	if region == "" {
+		return aws.Config{}, errors.New("AWS region is not set")
+	}
+
+	// Create a new AWS SDK config
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(region),
+		config.WithHTTPClient(&http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
+	)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("load default AWS SDK configuration: %w", err)
+	}
+
+	return cfg, nil
+}
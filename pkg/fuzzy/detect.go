@@ -0,0 +1,64 @@
+package fuzzy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.expect.digital/translate/pkg/model"
+	"golang.org/x/text/language"
+)
+
+// detectTextLimit limits the number of bytes of translation text sent for language detection,
+// a few kilobytes are enough to detect the language and keep requests within the provider limits.
+const detectTextLimit = 5000
+
+var SupportedDetectors = []string{"Ngram", "GoogleTranslate", "AWSComprehend"}
+
+// DetectorUsage returns a string describing the supported language detectors for CLI.
+func DetectorUsage() string {
+	return "language detector for uploads without a language. Supported options: " +
+		strings.Join(SupportedDetectors, ", ")
+}
+
+// LanguageDetection is the result of language detection.
+type LanguageDetection struct {
+	// Language is the detected language, language.Und if the language could not be detected.
+	Language language.Tag
+	// Confidence is in range [0, 1], higher is more confident.
+	Confidence float64
+}
+
+// LanguageDetector detects the language of translation messages.
+type LanguageDetector interface {
+	DetectLanguage(ctx context.Context, translation *model.Translation) (LanguageDetection, error)
+}
+
+// detectionText returns the translatable text of the translation messages without placeholders,
+// one text per line, truncated to detectTextLimit bytes.
+func detectionText(translation *model.Translation) (string, error) {
+	texts, err := getTexts(translation)
+	if err != nil {
+		return "", fmt.Errorf("get texts: %w", err)
+	}
+
+	var sb strings.Builder
+
+	for _, text := range texts {
+		sb.WriteString(placeholderRegexp.ReplaceAllString(text, " "))
+		sb.WriteString("\n")
+
+		if sb.Len() >= detectTextLimit {
+			break
+		}
+	}
+
+	s := sb.String()
+
+	if len(s) > detectTextLimit {
+		// Drop a multi-byte character cut in half.
+		s = strings.ToValidUTF8(s[:detectTextLimit], "")
+	}
+
+	return strings.TrimSpace(s), nil
+}
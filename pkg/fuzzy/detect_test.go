@@ -0,0 +1,150 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	"golang.org/x/text/language"
+)
+
+func Test_NgramDetect(t *testing.T) {
+	t.Parallel()
+
+	detector := NewNgramDetect()
+
+	tests := []struct {
+		name     string
+		want     language.Tag
+		messages []string
+	}{
+		{
+			name:     "English",
+			messages: []string{"Your changes have been saved.", "Do you want to remove { $count } files from the folder?"},
+			want:     language.English,
+		},
+		{
+			name:     "German",
+			messages: []string{"Ihre Änderungen wurden gespeichert.", "Möchten Sie { $count } Dateien aus dem Ordner entfernen?"},
+			want:     language.German,
+		},
+		{
+			name:     "French",
+			messages: []string{"Vos modifications ont été enregistrées.", "Voulez-vous supprimer { $count } fichiers du dossier ?"},
+			want:     language.French,
+		},
+		{
+			name:     "Latvian",
+			messages: []string{"Jūsu izmaiņas ir saglabātas.", "Vai vēlaties izņemt { $count } failus no mapes?"},
+			want:     language.Latvian,
+		},
+		{
+			name:     "Russian",
+			messages: []string{"Ваши изменения сохранены.", "Вы хотите удалить { $count } файлов из папки?"},
+			want:     language.Russian,
+		},
+		{
+			name:     "Japanese by script",
+			messages: []string{"変更を保存しました。", "フォルダーからファイルを削除しますか？"},
+			want:     language.Japanese,
+		},
+		{
+			name:     "Greek by script",
+			messages: []string{"Οι αλλαγές σας αποθηκεύτηκαν."},
+			want:     language.Greek,
+		},
+		{
+			name: "Matcher variants",
+			messages: []string{`.input { $count :number }
+.match $count
+one {{Sie haben { $count } neue Nachricht}}
+* {{Sie haben { $count } neue Nachrichten}}`},
+			want: language.German,
+		},
+		{
+			name:     "Only placeholders",
+			messages: []string{"{ $name }", "{ $count } %"},
+			want:     language.Und,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			translation := &model.Translation{Language: language.Und}
+
+			for i, message := range test.messages {
+				translation.Messages = append(translation.Messages, model.Message{ID: strings.Repeat("x", i+1), Message: message})
+			}
+
+			got, err := detector.DetectLanguage(t.Context(), translation)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if test.want != got.Language {
+				t.Errorf("want language '%s', got '%s'", test.want, got.Language)
+			}
+
+			switch {
+			case got.Language == language.Und && got.Confidence != 0:
+				t.Errorf("want zero confidence for undetermined language, got %v", got.Confidence)
+			case got.Language != language.Und && (got.Confidence <= 0 || got.Confidence > 1):
+				t.Errorf("want confidence in range (0, 1], got %v", got.Confidence)
+			}
+		})
+	}
+}
+
+func Test_DetectLanguageMock(t *testing.T) {
+	t.Parallel()
+
+	translation := &model.Translation{
+		Language: language.Und,
+		Messages: []model.Message{{ID: "1", Message: "Hello, { $name }! You have new messages."}},
+	}
+
+	for name, detector := range mockDetectors {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := detector.DetectLanguage(t.Context(), translation)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			want := LanguageDetection{Language: language.English, Confidence: float64(float32(0.9))}
+			if want != got {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func Test_DetectionText(t *testing.T) {
+	t.Parallel()
+
+	translation := &model.Translation{
+		Messages: []model.Message{
+			{ID: "1", Message: "Hello, { $name }!"},
+			{ID: "2", Message: strings.Repeat("ā", detectTextLimit)},
+		},
+	}
+
+	got, err := detectionText(translation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !strings.HasPrefix(got, "Hello,  !\n") {
+		t.Errorf("want text without placeholders, got %q", got[:20])
+	}
+
+	if len(got) > detectTextLimit || !strings.HasSuffix(got, "ā") {
+		t.Errorf("want valid text truncated to %d bytes, got %d bytes", detectTextLimit, len(got))
+	}
+}
@@ -2,19 +2,36 @@ package fuzzy
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"go.expect.digital/translate/pkg/model"
 	"golang.org/x/text/language"
 )
 
-var SupportedServices = []string{"GoogleTranslate", "AWSTranslate"}
+var SupportedServices = []string{"GoogleTranslate", "AWSTranslate", "PseudoTranslate"}
 
 // Usage returns a string describing the supported translators for CLI.
 func Usage() string {
 	return "translator to use. Supported options: " + strings.Join(SupportedServices, ", ")
 }
 
+// Name returns the name of a supported translator, as listed in SupportedServices,
+// or an empty string for other translators, e.g. NoopTranslate.
+func Name(translator Translator) string {
+	switch translator.(type) {
+	default:
+		return ""
+	case *GoogleTranslate:
+		return "GoogleTranslate"
+	case *AWSTranslate:
+		return "AWSTranslate"
+	case *PseudoTranslate:
+		return "PseudoTranslate"
+	}
+}
+
 type Translator interface {
 	Translate(ctx context.Context, translation *model.Translation, targetLanguage language.Tag) (*model.Translation, error)
 	// XXX: Method to return supported languages? e.g. SupportedLanguages() map[language.Tag]bool
@@ -32,3 +49,78 @@ func (n *NoopTranslate) Translate(_ context.Context,
 
 	return translation, nil
 }
+
+// Meter records a request to a translation provider with the number of characters sent,
+// the request is not sent if the meter returns an error, e.g. when a quota is exceeded.
+type Meter func(ctx context.Context, characters int64) error
+
+type meterKey struct{}
+
+// WithMeter returns a copy of ctx with the meter, called by translators before each request to the provider.
+func WithMeter(ctx context.Context, meter Meter) context.Context {
+	return context.WithValue(ctx, meterKey{}, meter)
+}
+
+// MeterRequest calls the meter of ctx, if any, with the characters of the texts sent to the provider in one request.
+// Translators call it before each request to the provider, see WithMeter.
+func MeterRequest(ctx context.Context, texts ...string) error {
+	meter, ok := ctx.Value(meterKey{}).(Meter)
+	if !ok {
+		return nil
+	}
+
+	var characters int64
+
+	for _, text := range texts {
+		characters += int64(utf8.RuneCountInString(text))
+	}
+
+	err := meter(ctx, characters)
+	if err != nil {
+		return fmt.Errorf("meter request: %w", err)
+	}
+
+	return nil
+}
+
+// semaphore limits the number of concurrent requests to a translation provider.
+// It is shared between all Translate calls of a translator, so the limit holds
+// even when several languages are translated at once. A nil semaphore does not limit.
+type semaphore chan struct{}
+
+// newSemaphore creates a semaphore allowing up to n concurrent holders.
+func newSemaphore(n int) semaphore {
+	return make(semaphore, n)
+}
+
+// acquire blocks until a slot is available or ctx is done.
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("acquire semaphore: %w", ctx.Err())
+	}
+}
+
+// release frees a slot previously taken with acquire.
+func (s semaphore) release() {
+	if s == nil {
+		return
+	}
+
+	<-s
+}
+
+// limit returns the maximum number of concurrent holders, -1 when unlimited.
+func (s semaphore) limit() int {
+	if s == nil {
+		return -1
+	}
+
+	return cap(s)
+}
@@ -2,11 +2,19 @@ package fuzzy
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"cloud.google.com/go/translate/apiv3/translatepb"
+	"github.com/aws/aws-sdk-go-v2/service/comprehend"
+	comprehendtypes "github.com/aws/aws-sdk-go-v2/service/comprehend/types"
 	awst "github.com/aws/aws-sdk-go-v2/service/translate"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/translate/types"
 	"github.com/googleapis/gax-go/v2"
 	"go.expect.digital/translate/pkg/model"
 	"go.expect.digital/translate/pkg/testutil"
@@ -77,6 +85,118 @@ func Test_TranslateMock(t *testing.T) {
 	})
 }
 
+func Test_TranslateConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	const limit = 2
+
+	client := &mockConcurrentAWSTranslateClient{wantInFlight: limit}
+	translator := &AWSTranslate{client: client, sem: newSemaphore(limit)}
+	input := rand.ModelTranslation(20, nil, rand.WithLanguage(language.English), rand.WithSimpleMF2Messages())
+
+	// Translate to several languages at once, the limit is shared between calls.
+	var wg sync.WaitGroup
+
+	for _, lang := range []language.Tag{language.Latvian, language.German, language.French} {
+		wg.Go(func() {
+			output, err := translator.Translate(t.Context(), input, lang)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// Check that the order of the messages is preserved.
+			for i := range output.Messages {
+				if input.Messages[i].ID != output.Messages[i].ID {
+					t.Errorf("want message ID '%s', got '%s'", input.Messages[i].ID, output.Messages[i].ID)
+				}
+
+				testutil.EqualMF2Message(t, input.Messages[i].Message, output.Messages[i].Message)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	// Requests wait for each other, so fewer concurrent requests mean that the limit is not reached.
+	if got := client.maxInFlight.Load(); got != limit {
+		t.Errorf("want %d concurrent requests, got %d", limit, got)
+	}
+}
+
+func Test_TranslateMeter(t *testing.T) {
+	t.Parallel()
+
+	googleClient := &mockMeteredGoogleTranslateClient{}
+	awsClient := &mockMeteredAWSTranslateClient{}
+
+	tests := []struct {
+		translator Translator
+		client     *meteredClient
+		name       string
+	}{
+		{
+			name:       "GoogleTranslate",
+			translator: &GoogleTranslate{client: googleClient},
+			client:     &googleClient.meteredClient,
+		},
+		{
+			name:       "AWSTranslate",
+			translator: &AWSTranslate{client: awsClient},
+			client:     &awsClient.meteredClient,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests, characters atomic.Int64
+
+			ctx := WithMeter(t.Context(), func(_ context.Context, n int64) error {
+				requests.Add(1)
+				characters.Add(n)
+
+				return nil
+			})
+
+			input := rand.ModelTranslation(5, nil, rand.WithLanguage(language.English), rand.WithSimpleMF2Messages())
+
+			_, err := test.translator.Translate(ctx, input, language.Latvian)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if test.client.requests.Load() == 0 {
+				t.Error("want requests to the provider, got none")
+			}
+
+			if requests.Load() != test.client.requests.Load() {
+				t.Errorf("want %d metered requests, got %d", test.client.requests.Load(), requests.Load())
+			}
+
+			if characters.Load() != test.client.characters.Load() {
+				t.Errorf("want %d metered characters, got %d", test.client.characters.Load(), characters.Load())
+			}
+
+			// A failing meter stops the requests to the provider.
+			sent := test.client.requests.Load()
+
+			_, err = test.translator.Translate(WithMeter(t.Context(), func(context.Context, int64) error {
+				return errors.New("quota exceeded")
+			}), input, language.Latvian)
+			if err == nil {
+				t.Error("want error, got nil")
+			}
+
+			if test.client.requests.Load() != sent {
+				t.Errorf("want no requests with a failing meter, got %d", test.client.requests.Load()-sent)
+			}
+		})
+	}
+}
+
 // -------------------------Mocks------------------------------
 
 // mockGoogleTranslateClient is a mock implementation of the Google Translate client.
@@ -112,12 +232,146 @@ func (m *mockAWSTranslateClient) TranslateText(
 	}, nil
 }
 
+// TranslateDocument returns the input document as translated document.
+func (m *mockAWSTranslateClient) TranslateDocument(
+	_ context.Context,
+	params *awst.TranslateDocumentInput,
+	_ ...func(*awst.Options),
+) (*awst.TranslateDocumentOutput, error) {
+	return &awst.TranslateDocumentOutput{
+		SourceLanguageCode: params.SourceLanguageCode,
+		TargetLanguageCode: params.TargetLanguageCode,
+		TranslatedDocument: &awstypes.TranslatedDocument{Content: params.Document.Content},
+	}, nil
+}
+
+// DetectLanguage returns English as the detected language.
+func (m *mockGoogleTranslateClient) DetectLanguage(
+	context.Context,
+	*translatepb.DetectLanguageRequest,
+	...gax.CallOption,
+) (*translatepb.DetectLanguageResponse, error) {
+	return &translatepb.DetectLanguageResponse{
+		Languages: []*translatepb.DetectedLanguage{{LanguageCode: "en", Confidence: 0.9}},
+	}, nil
+}
+
 func (m *mockGoogleTranslateClient) Close() error { return nil }
 
+// mockComprehendClient is a mock implementation of the Amazon Comprehend client.
+type mockComprehendClient struct{}
+
+// DetectDominantLanguage returns English as the dominant language.
+func (m *mockComprehendClient) DetectDominantLanguage(
+	context.Context,
+	*comprehend.DetectDominantLanguageInput,
+	...func(*comprehend.Options),
+) (*comprehend.DetectDominantLanguageOutput, error) {
+	return &comprehend.DetectDominantLanguageOutput{
+		Languages: []comprehendtypes.DominantLanguage{
+			{LanguageCode: new("de"), Score: new(float32(0.05))},
+			{LanguageCode: new("en"), Score: new(float32(0.9))},
+		},
+	}, nil
+}
+
+// mockConcurrentAWSTranslateClient is a mock implementation of the AWS Translate client,
+// that records the maximum number of concurrent requests.
+// Requests wait up to 100ms for wantInFlight concurrent requests, so that a serial run is detected.
+type mockConcurrentAWSTranslateClient struct {
+	mockAWSTranslateClient
+
+	inFlight, maxInFlight atomic.Int64
+	wantInFlight          int64
+}
+
+// TranslateText returns the input text as translated text.
+func (m *mockConcurrentAWSTranslateClient) TranslateText(
+	ctx context.Context,
+	params *awst.TranslateTextInput,
+	optFns ...func(*awst.Options),
+) (*awst.TranslateTextOutput, error) {
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+
+	for {
+		maxN := m.maxInFlight.Load()
+		if n <= maxN || m.maxInFlight.CompareAndSwap(maxN, n) {
+			break
+		}
+	}
+
+	for deadline := time.Now().Add(100 * time.Millisecond); time.Now().Before(deadline); {
+		if m.maxInFlight.Load() >= m.wantInFlight {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	return m.mockAWSTranslateClient.TranslateText(ctx, params, optFns...)
+}
+
+// meteredClient records the requests and the characters of the texts sent to a translation provider.
+type meteredClient struct {
+	requests, characters atomic.Int64
+}
+
+func (m *meteredClient) record(texts ...string) {
+	m.requests.Add(1)
+
+	for _, text := range texts {
+		m.characters.Add(int64(utf8.RuneCountInString(text)))
+	}
+}
+
+// mockMeteredGoogleTranslateClient is a mock implementation of the Google Translate client,
+// that records the requests and the characters sent.
+type mockMeteredGoogleTranslateClient struct {
+	mockGoogleTranslateClient
+	meteredClient
+}
+
+// TranslateText returns the input text as translated text.
+func (m *mockMeteredGoogleTranslateClient) TranslateText(
+	ctx context.Context,
+	req *translatepb.TranslateTextRequest,
+	opts ...gax.CallOption,
+) (*translatepb.TranslateTextResponse, error) {
+	m.record(req.GetContents()...)
+
+	return m.mockGoogleTranslateClient.TranslateText(ctx, req, opts...)
+}
+
+// mockMeteredAWSTranslateClient is a mock implementation of the AWS Translate client,
+// that records the requests and the characters sent.
+type mockMeteredAWSTranslateClient struct {
+	mockAWSTranslateClient
+	meteredClient
+}
+
+// TranslateText returns the input text as translated text.
+func (m *mockMeteredAWSTranslateClient) TranslateText(
+	ctx context.Context,
+	params *awst.TranslateTextInput,
+	optFns ...func(*awst.Options),
+) (*awst.TranslateTextOutput, error) {
+	m.record(*params.Text)
+
+	return m.mockAWSTranslateClient.TranslateText(ctx, params, optFns...)
+}
+
 // -----------------------Helpers and init----------------------------
 
 var mockTranslators = map[string]Translator{
-	"AWSTranslate":    &AWSTranslate{client: &mockAWSTranslateClient{}},
+	"AWSTranslate":         &AWSTranslate{client: &mockAWSTranslateClient{}},
+	"AWSTranslate HTML":    &AWSTranslate{client: &mockAWSTranslateClient{}, html: true},
+	"GoogleTranslate":      &GoogleTranslate{client: &mockGoogleTranslateClient{}},
+	"GoogleTranslate HTML": &GoogleTranslate{client: &mockGoogleTranslateClient{}, html: true},
+}
+
+var mockDetectors = map[string]LanguageDetector{
+	"AWSComprehend":   &AWSComprehend{client: &mockComprehendClient{}},
 	"GoogleTranslate": &GoogleTranslate{client: &mockGoogleTranslateClient{}},
 }
 
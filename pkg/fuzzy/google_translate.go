@@ -1,10 +1,13 @@
 package fuzzy
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -16,6 +19,7 @@ import (
 	"go.expect.digital/mf2/parse"
 	"go.expect.digital/translate/pkg/model"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/text/language"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
@@ -29,8 +33,14 @@ const (
 
 	// googleTranslateCodePointsLimit limits the number of Unicode codepoints per single translation request.
 	googleTranslateCodePointsLimit = 30_000
+
+	// googleDefaultConcurrency is the default number of concurrent requests to the Google Translate API.
+	googleDefaultConcurrency = 5
 )
 
+// placeholderRegexp matches simplified placeholders '{$d}' in the text extracted by getTexts.
+var placeholderRegexp = regexp.MustCompile(`\{\$(0|[1-9]\d*)\}`)
+
 // --------------------Definitions--------------------
 
 // Interface that defines some of the methods of the Google Translate client.
@@ -42,12 +52,19 @@ type googleClient interface {
 		req *translatepb.TranslateTextRequest,
 		opts ...gax.CallOption,
 	) (*translatepb.TranslateTextResponse, error)
+	DetectLanguage(
+		ctx context.Context,
+		req *translatepb.DetectLanguageRequest,
+		opts ...gax.CallOption,
+	) (*translatepb.DetectLanguageResponse, error)
 	io.Closer
 }
 
-// GoogleTranslate implements the Translator interface.
+// GoogleTranslate implements the Translator and LanguageDetector interfaces.
 type GoogleTranslate struct {
 	client googleClient
+	sem    semaphore
+	html   bool // translate texts in HTML mode, see getHTMLTexts
 }
 
 type GoogleTranslateOption func(*GoogleTranslate) error
@@ -60,6 +77,28 @@ func WithGoogleClient(c googleClient) GoogleTranslateOption {
 	}
 }
 
+// WithGoogleConcurrency limits the number of concurrent requests to the Google Translate API.
+// If n is not positive, the default limit is used.
+func WithGoogleConcurrency(n int) GoogleTranslateOption {
+	return func(g *GoogleTranslate) error {
+		if n > 0 {
+			g.sem = newSemaphore(n)
+		}
+
+		return nil
+	}
+}
+
+// WithGoogleHTML enables HTML mode - placeholders and markup are sent to the Google Translate API
+// as HTML elements instead of simplified placeholders '{$d}', so that paired markup, e.g. '{#b}...{/b}',
+// is translated as a unit with the text it encloses.
+func WithGoogleHTML(enabled bool) GoogleTranslateOption {
+	return func(g *GoogleTranslate) error {
+		g.html = enabled
+		return nil
+	}
+}
+
 // WithDefaultGoogleClient creates a new Google Translate client with the API key from the viper.
 func WithDefaultGoogleClient(ctx context.Context) GoogleTranslateOption {
 	return func(g *GoogleTranslate) error {
@@ -83,7 +122,7 @@ func NewGoogleTranslate(
 	ctx context.Context,
 	opts ...GoogleTranslateOption,
 ) (gt *GoogleTranslate, closer func() error, err error) {
-	gt = &GoogleTranslate{}
+	gt = &GoogleTranslate{sem: newSemaphore(googleDefaultConcurrency)}
 
 	for _, opt := range opts {
 		optErr := opt(gt)
@@ -122,8 +161,19 @@ func (g *GoogleTranslate) Translate(
 		return &model.Translation{Language: targetLanguage, Original: translation.Original}, nil
 	}
 
+	// Adapt plural variants to the plural categories of the target language
+	translation, err := expandPlurals(translation, targetLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("google translate: expand plurals: %w", err)
+	}
+
 	// Retrieve all translatable text from translation
-	texts, err := getTexts(translation)
+	extract, build, mimeType := getTexts, buildTranslated, "text/plain"
+	if g.html {
+		extract, build, mimeType = getHTMLTexts, buildTranslatedHTML, "text/html"
+	}
+
+	texts, err := extract(translation)
 	if err != nil {
 		return nil, fmt.Errorf("google translate: get texts: %w", err)
 	}
@@ -131,30 +181,58 @@ func (g *GoogleTranslate) Translate(
 	// Split text from translation into batches to avoid exceeding
 	// googleTranslateRequestLimit or googleTranslateCodePointsLimit.
 	batches := textToBatches(texts)
-	translatedTexts := make([]string, 0, len(texts))
+	translatedBatches := make([][]string, len(batches))
+
+	// Translate text batches concurrently using Google Translate client, within the limit of g.sem.
+	eg, egctx := errgroup.WithContext(ctx)
+	eg.SetLimit(g.sem.limit())
 
-	// Translate text batches using Google Translate client.
 	for i := range batches {
-		res, err := g.client.TranslateText(ctx, &translatepb.TranslateTextRequest{ //nolint:govet
-			Parent:             parent(),
-			SourceLanguageCode: translation.Language.String(),
-			TargetLanguageCode: targetLanguage.String(),
-			Contents:           batches[i],
-			MimeType:           "text/plain",
-		})
-		if err != nil {
-			return nil, fmt.Errorf("google translate client: translate text #%d from batch: %w", i, err)
-		}
+		eg.Go(func() error {
+			semErr := g.sem.acquire(egctx)
+			if semErr != nil {
+				return fmt.Errorf("translate text #%d from batch: %w", i, semErr)
+			}
 
-		translations := res.GetTranslations()
+			defer g.sem.release()
 
-		for i := range translations {
-			translatedTexts = append(translatedTexts, translations[i].GetTranslatedText())
-		}
+			meterErr := MeterRequest(egctx, batches[i]...)
+			if meterErr != nil {
+				return fmt.Errorf("translate text #%d from batch: %w", i, meterErr)
+			}
+
+			res, translateErr := g.client.TranslateText(egctx, &translatepb.TranslateTextRequest{
+				Parent:             parent(),
+				SourceLanguageCode: translation.Language.String(),
+				TargetLanguageCode: targetLanguage.String(),
+				Contents:           batches[i],
+				MimeType:           mimeType,
+			})
+			if translateErr != nil {
+				return fmt.Errorf("translate text #%d from batch: %w", i, translateErr)
+			}
+
+			translations := res.GetTranslations()
+			translatedBatches[i] = make([]string, 0, len(translations))
+
+			for j := range translations {
+				translatedBatches[i] = append(translatedBatches[i], translations[j].GetTranslatedText())
+			}
+
+			return nil
+		})
+	}
+
+	err = eg.Wait()
+	if err != nil {
+		return nil, fmt.Errorf("google translate client: %w", err)
 	}
 
+	// Keep the order of the translated texts the same as of the batches.
+	translatedTexts := slices.Concat(translatedBatches...)
+
 	// build translation with new translated text
-	translated, err := buildTranslated(translation, translatedTexts, targetLanguage)
+	translated, err := build(translation, translatedTexts, targetLanguage)
 	if err != nil {
 		return nil, fmt.Errorf("google translate: build translated: %w", err)
 	}
@@ -162,6 +240,49 @@ func (g *GoogleTranslate) Translate(
 	return translated, nil
 }
 
+// DetectLanguage detects the language of the translation messages using the Google Translate API.
+func (g *GoogleTranslate) DetectLanguage(
+	ctx context.Context,
+	translation *model.Translation,
+) (LanguageDetection, error) {
+	und := LanguageDetection{Language: language.Und}
+
+	if translation == nil {
+		return und, nil
+	}
+
+	text, err := detectionText(translation)
+	if err != nil {
+		return LanguageDetection{}, fmt.Errorf("google translate: %w", err)
+	}
+
+	if text == "" {
+		return und, nil
+	}
+
+	res, err := g.client.DetectLanguage(ctx, &translatepb.DetectLanguageRequest{
+		Parent:   parent(),
+		Source:   &translatepb.DetectLanguageRequest_Content{Content: text},
+		MimeType: "text/plain",
+	})
+	if err != nil {
+		return LanguageDetection{}, fmt.Errorf("google translate client: detect language: %w", err)
+	}
+
+	// Languages are sorted by confidence, the most probable first.
+	languages := res.GetLanguages()
+	if len(languages) == 0 {
+		return und, nil
+	}
+
+	lang, err := language.Parse(languages[0].GetLanguageCode())
+	if err != nil {
+		return und, nil //nolint:nilerr // Unknown language code is not detected language.
+	}
+
+	return LanguageDetection{Language: lang, Confidence: float64(languages[0].GetConfidence())}, nil
+}
+
 // helpers
 
 // parent returns path to Google project and location.
@@ -221,6 +342,12 @@ func textToBatches(text []string) [][]string {
 // Output:
 // []string{"Hello, {$0} {$1}!"}, nil .
 func getTexts(translation *model.Translation) ([]string, error) {
+	return extractTexts(translation, patternToString)
+}
+
+// extractTexts extracts the text of every pattern of the translation messages,
+// encoded by patternToText, in the order of the messages and their variants.
+func extractTexts(translation *model.Translation, patternToText func([]parse.PatternPart) string) ([]string, error) {
 	texts := make([]string, 0, len(translation.Messages))
 
 	for i := range translation.Messages {
@@ -233,15 +360,15 @@ func getTexts(translation *model.Translation) ([]string, error) {
 		default:
 			return nil, fmt.Errorf("unsupported message type: %T", v)
 		case parse.SimpleMessage:
-			texts = append(texts, patternToString(v))
+			texts = append(texts, patternToText(v))
 		case parse.ComplexMessage:
 			switch v := v.ComplexBody.(type) {
 			case parse.Matcher:
 				for _, variant := range v.Variants {
-					texts = append(texts, patternToString(variant.QuotedPattern))
+					texts = append(texts, patternToText(variant.QuotedPattern))
 				}
 			case parse.QuotedPattern:
-				texts = append(texts, patternToString(v))
+				texts = append(texts, patternToText(v))
 			}
 		}
 	}
@@ -313,6 +440,17 @@ func patternToString(pattern []parse.PatternPart) string {
 //		},
 //	}, nil
 func buildTranslated(translation *model.Translation, translatedTexts []string, targetLanguage language.Tag,
+) (*model.Translation, error) {
+	return buildTranslatedWith(translation, translatedTexts, targetLanguage, buildTranslatedPattern)
+}
+
+// buildTranslatedWith constructs a translated version of the untranslated translation,
+// decoding every translated text into a pattern with buildPattern, see buildTranslated.
+func buildTranslatedWith(
+	translation *model.Translation,
+	translatedTexts []string,
+	targetLanguage language.Tag,
+	buildPattern func(translatedText string, previousPattern []parse.PatternPart) ([]parse.PatternPart, error),
 ) (*model.Translation, error) {
 	translated := &model.Translation{
 		Language: targetLanguage,
@@ -322,35 +460,49 @@ func buildTranslated(translation *model.Translation, translatedTexts []string, t
 
 	var textIndex int
 
+	// nextPattern builds the pattern from the next translated text, on failure records buildErr
+	// and returns the previous pattern.
+	nextPattern := func(previousPattern []parse.PatternPart, buildErr *error) []parse.PatternPart {
+		if textIndex >= len(translatedTexts) {
+			*buildErr = cmp.Or(*buildErr, errors.New("missing translated text"))
+			return previousPattern
+		}
+
+		text := translatedTexts[textIndex]
+		textIndex++
+
+		if *buildErr != nil {
+			return previousPattern
+		}
+
+		pattern, err := buildPattern(text, previousPattern)
+		if err != nil {
+			*buildErr = err
+			return previousPattern
+		}
+
+		return pattern
+	}
+
 	for i := range translation.Messages {
 		messageAST, err := parse.Parse(translation.Messages[i].Message)
 		if err != nil {
 			return nil, fmt.Errorf("parse mf2 message: %w", err)
 		}
 
+		// buildErr is the reason why the message cannot be translated, e.g. a placeholder is lost in translation.
+		var buildErr error
+
 		switch message := messageAST.Message.(type) {
 		case parse.SimpleMessage:
-			message, err = buildTranslatedPattern(translatedTexts[textIndex], message)
-			if err != nil {
-				return nil, fmt.Errorf("build translated pattern for simple message: %w", err)
-			}
-
-			textIndex++
-
 			// rewrite AST
-			messageAST.Message = message
+			messageAST.Message = parse.SimpleMessage(nextPattern(message, &buildErr))
 
 		case parse.ComplexMessage:
 			switch complexBody := message.ComplexBody.(type) {
 			case parse.Matcher:
 				for i := range complexBody.Variants {
-					complexBody.Variants[i].QuotedPattern, err = buildTranslatedPattern(
-						translatedTexts[textIndex], complexBody.Variants[i].QuotedPattern)
-					if err != nil {
-						return nil, fmt.Errorf("build translated pattern for matcher variant: %w", err)
-					}
-
-					textIndex++
+					complexBody.Variants[i].QuotedPattern = nextPattern(complexBody.Variants[i].QuotedPattern, &buildErr)
 				}
 
 				// rewrite AST
@@ -358,19 +510,21 @@ func buildTranslated(translation *model.Translation, translatedTexts []string, t
 				messageAST.Message = message
 
 			case parse.QuotedPattern:
-				complexBody, err = buildTranslatedPattern(translatedTexts[textIndex], complexBody)
-				if err != nil {
-					return nil, fmt.Errorf("build translated pattern for quoted pattern: %w", err)
-				}
-
-				textIndex++
-
 				// rewrite AST
-				message.ComplexBody = complexBody
+				message.ComplexBody = parse.QuotedPattern(nextPattern(complexBody, &buildErr))
 				messageAST.Message = message
 			}
 		}
 
+		// Keep the message untranslated, and record the reason, instead of corrupting it.
+		if buildErr != nil {
+			translated.Messages[i] = translation.Messages[i]
+			translated.Messages[i].Status = model.MessageStatusUntranslated
+			translated.Messages[i].StatusReason = fmt.Sprintf("invalid machine translation: %s", buildErr)
+
+			continue
+		}
+
 		translated.Messages[i] = model.Message{
 			ID:          translation.Messages[i].ID,
 			PluralID:    translation.Messages[i].PluralID,
@@ -381,33 +535,87 @@ func buildTranslated(translation *model.Translation, translatedTexts []string, t
 		}
 	}
 
+	if textIndex != len(translatedTexts) {
+		return nil, fmt.Errorf("want %d translated texts, got %d", textIndex, len(translatedTexts))
+	}
+
 	return translated, nil
 }
 
 // buildTranslatedPattern constructs a slice of parse.Pattern from a given text and placeholders
 // extracted from a translated text. Placeholders are replaced with corresponding
 // parse.Pattern retrieved from the message parse. The function returns a slice of parse.Pattern and error.
+// The translated pattern is validated against the previous pattern, see validatePattern.
 func buildTranslatedPattern(translatedText string, previousPattern []parse.PatternPart) ([]parse.PatternPart, error) {
-	re := regexp.MustCompile(`\{\$(0|[1-9]\d*)\}`)
-
 	translatedPattern := make([]parse.PatternPart, 0, len(previousPattern))
 
 	for _, v := range splitTextByPlaceholder(translatedText) {
-		if re.MatchString(v) { // simplified placeholder
+		if placeholderRegexp.MatchString(v) { // simplified placeholder
 			placeholderIndex, err := strconv.Atoi(v[2 : len(v)-1])
 			if err != nil {
 				return nil, fmt.Errorf("parse placeholder index: %w", err)
 			}
 
+			if placeholderIndex >= len(previousPattern) {
+				return nil, fmt.Errorf("placeholder '%s' is out of range", v)
+			}
+
+			// Only placeholders are replaced by indices, a text index is mangled by the translator.
+			if _, ok := previousPattern[placeholderIndex].(parse.Text); ok {
+				return nil, fmt.Errorf("placeholder '%s' is text", v)
+			}
+
 			translatedPattern = append(translatedPattern, previousPattern[placeholderIndex])
 		} else { // translated text
 			translatedPattern = append(translatedPattern, parse.Text(v))
 		}
 	}
 
+	err := validatePattern(previousPattern, translatedPattern)
+	if err != nil {
+		return nil, err
+	}
+
 	return translatedPattern, nil
 }
 
+// validatePattern checks that the translated pattern keeps placeholders of the previous pattern:
+//   - every expression (e.g. variable) and markup is present as many times as in the previous pattern;
+//   - markup keeps its order, so that it stays properly nested.
+func validatePattern(previousPattern, translatedPattern []parse.PatternPart) error {
+	placeholders := func(pattern []parse.PatternPart) (counts map[string]int, markup []string) {
+		counts = make(map[string]int)
+
+		for _, part := range pattern {
+			switch part.(type) {
+			case parse.Expression:
+				counts[fmt.Sprint(part)]++
+			case parse.Markup:
+				counts[fmt.Sprint(part)]++
+				markup = append(markup, fmt.Sprint(part))
+			}
+		}
+
+		return counts, markup
+	}
+
+	wantCounts, wantMarkup := placeholders(previousPattern)
+	gotCounts, gotMarkup := placeholders(translatedPattern)
+
+	for placeholder, want := range wantCounts {
+		if got := gotCounts[placeholder]; got != want {
+			return fmt.Errorf("want placeholder '%s' %d time(s), got %d", placeholder, want, got)
+		}
+	}
+
+	// Placeholders are taken from the previous pattern, so the only extras are duplicates counted above.
+	if !slices.Equal(wantMarkup, gotMarkup) {
+		return fmt.Errorf("want markup order %v, got %v", wantMarkup, gotMarkup)
+	}
+
+	return nil
+}
+
 // splitTextByPlaceholder splits a given string into substrings separated by '{$d}'
 // and returns a slice containing both the substrings and the separators.
 //
@@ -424,7 +632,7 @@ func splitTextByPlaceholder(s string) []string {
 	}
 
 	textParts := make([]string, 0, 1)
-	placeholderIndices := regexp.MustCompile(`\{\$(0|[1-9]\d*)\}`).FindAllStringIndex(s, -1)
+	placeholderIndices := placeholderRegexp.FindAllStringIndex(s, -1)
 
 	var startIndex int
 
@@ -3,6 +3,10 @@ package fuzzy
 import (
 	"slices"
 	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/testutil"
+	"golang.org/x/text/language"
 )
 
 func Test_SplitTextByPlaceholder(t *testing.T) {
@@ -73,3 +77,87 @@ func Test_SplitTextByPlaceholder(t *testing.T) {
 		})
 	}
 }
+
+func Test_BuildTranslatedValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		message        string
+		translatedText string
+		want           string
+		wantStatus     model.MessageStatus
+	}{
+		{
+			name:           "Placeholders reordered",
+			message:        "Hello { $name } { $lastName }!",
+			translatedText: "Sveiki {$3} {$1}!",
+			want:           "Sveiki { $lastName } { $name }!",
+			wantStatus:     model.MessageStatusFuzzy,
+		},
+		{
+			name:           "Placeholder dropped",
+			message:        "Hello { $name }!",
+			translatedText: "Sveiki!",
+			want:           "Hello { $name }!",
+			wantStatus:     model.MessageStatusUntranslated,
+		},
+		{
+			name:           "Placeholder duplicated",
+			message:        "Hello { $name }!",
+			translatedText: "Sveiki {$1} {$1}!",
+			want:           "Hello { $name }!",
+			wantStatus:     model.MessageStatusUntranslated,
+		},
+		{
+			name:           "Placeholder index out of range",
+			message:        "Hello { $name }!",
+			translatedText: "Sveiki {$1} {$7}!",
+			want:           "Hello { $name }!",
+			wantStatus:     model.MessageStatusUntranslated,
+		},
+		{
+			name:           "Placeholder index of text",
+			message:        "Hello { $name }!",
+			translatedText: "{$0}{$1}!",
+			want:           "Hello { $name }!",
+			wantStatus:     model.MessageStatusUntranslated,
+		},
+		{
+			name:           "Markup improperly nested",
+			message:        "{#b}Hello{/b}",
+			translatedText: "{$2}Sveiki{$0}",
+			want:           "{#b}Hello{/b}",
+			wantStatus:     model.MessageStatusUntranslated,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			translation := &model.Translation{
+				Language: language.English,
+				Messages: []model.Message{{ID: "1", Message: test.message, Status: model.MessageStatusUntranslated}},
+			}
+
+			got, err := buildTranslated(translation, []string{test.translatedText}, language.Latvian)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			msg := got.Messages[0]
+
+			if test.wantStatus != msg.Status {
+				t.Errorf("want message status '%d', got '%d'", test.wantStatus, msg.Status)
+			}
+
+			if wantReason := test.wantStatus == model.MessageStatusUntranslated; wantReason != (msg.StatusReason != "") {
+				t.Errorf("want status reason %t, got '%s'", wantReason, msg.StatusReason)
+			}
+
+			testutil.EqualMF2Message(t, test.want, msg.Message)
+		})
+	}
+}
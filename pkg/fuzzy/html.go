@@ -0,0 +1,213 @@
+package fuzzy
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"go.expect.digital/mf2/parse"
+	"go.expect.digital/translate/pkg/model"
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/text/language"
+)
+
+// htmlPlaceholderAttr is the attribute of the HTML elements encoding MF2 placeholders,
+// its value is the index of the parse.PatternPart in the pattern.
+const htmlPlaceholderAttr = "data-mf2"
+
+// getHTMLTexts extracts translatable text from the translation.Messages slice as HTML, see patternToHTML.
+// Unlike getTexts, paired markup is kept as HTML elements, so that translation providers
+// in HTML mode keep the markup around the text it applies to.
+//
+// Example:
+// Input:
+//
+//	&model.Translation{
+//		Language: language.English,
+//		Messages: []model.Message{{ID: "Hello", Message: `Hello, {#b}{ $name }{/b}!`}},
+//	}
+//
+// Output:
+// []string{`Hello, <span data-mf2="1"><span translate="no" data-mf2="2"></span></span>!`}, nil .
+func getHTMLTexts(translation *model.Translation) ([]string, error) {
+	return extractTexts(translation, patternToHTML)
+}
+
+// patternToHTML encodes the pattern as HTML:
+//   - text is HTML escaped;
+//   - opening markup with a matching closing markup is an element enclosing the text in between;
+//   - expressions and unpaired markup are empty elements, that are not translated.
+//
+// Every element refers to its parse.PatternPart by the index in htmlPlaceholderAttr.
+func patternToHTML(pattern []parse.PatternPart) string {
+	var (
+		text  strings.Builder
+		pairs = markupPairs(pattern)
+	)
+
+	closing := make(map[int]bool, len(pairs))
+	for _, closeIndex := range pairs {
+		closing[closeIndex] = true
+	}
+
+	for i := range pattern {
+		switch v := pattern[i].(type) {
+		case parse.Text:
+			text.WriteString(html.EscapeString(string(v)))
+		case parse.Expression, parse.Markup:
+			switch _, open := pairs[i]; {
+			case open:
+				fmt.Fprintf(&text, `<span %s="%d">`, htmlPlaceholderAttr, i)
+			case closing[i]:
+				text.WriteString("</span>")
+			default:
+				fmt.Fprintf(&text, `<span translate="no" %s="%d"></span>`, htmlPlaceholderAttr, i)
+			}
+		}
+	}
+
+	return text.String()
+}
+
+// markupPairs returns the index of the closing markup by the index of the matching opening markup,
+// e.g. '{#b}' and '{/b}'. Markup that is not properly nested is not paired.
+func markupPairs(pattern []parse.PatternPart) map[int]int {
+	pairs := make(map[int]int)
+
+	var opened []int // indexes of the unpaired opening markup
+
+	for i, part := range pattern {
+		markup, ok := part.(parse.Markup)
+		if !ok {
+			continue
+		}
+
+		switch markup.Typ {
+		case parse.Open:
+			opened = append(opened, i)
+		case parse.Close:
+			if len(opened) == 0 {
+				continue
+			}
+
+			last := opened[len(opened)-1]
+			if pattern[last].(parse.Markup).Identifier.String() == markup.Identifier.String() { //nolint:forcetypeassert
+				pairs[last] = i
+				opened = opened[:len(opened)-1]
+			}
+		}
+	}
+
+	return pairs
+}
+
+// buildTranslatedHTML constructs a translated version of the untranslated translation
+// from the texts translated in HTML mode, see getHTMLTexts and buildTranslated.
+func buildTranslatedHTML(translation *model.Translation, translatedTexts []string, targetLanguage language.Tag,
+) (*model.Translation, error) {
+	return buildTranslatedWith(translation, translatedTexts, targetLanguage, buildTranslatedHTMLPattern)
+}
+
+// buildTranslatedHTMLPattern decodes the text translated in HTML mode into a slice of parse.Pattern,
+// the HTML elements are replaced with the corresponding parse.Pattern of the previous pattern,
+// closing tags of paired markup with the closing markup.
+// The translated pattern is validated against the previous pattern, see validatePattern.
+func buildTranslatedHTMLPattern(translatedText string, previousPattern []parse.PatternPart,
+) ([]parse.PatternPart, error) {
+	var (
+		translatedPattern = make([]parse.PatternPart, 0, len(previousPattern))
+		pairs             = markupPairs(previousPattern)
+		opened            []int // indexes of the previous pattern parts of the open elements
+		tokenizer         = nethtml.NewTokenizer(strings.NewReader(translatedText))
+	)
+
+	appendText := func(text string) {
+		if last := len(translatedPattern) - 1; last >= 0 {
+			if previousText, ok := translatedPattern[last].(parse.Text); ok {
+				translatedPattern[last] = previousText + parse.Text(text)
+				return
+			}
+		}
+
+		translatedPattern = append(translatedPattern, parse.Text(text))
+	}
+
+	for {
+		switch tokenizer.Next() {
+		case nethtml.ErrorToken:
+			if err := tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("tokenize HTML: %w", err)
+			}
+
+			if len(opened) > 0 {
+				return nil, errors.New("unclosed HTML element")
+			}
+
+			err := validatePattern(previousPattern, translatedPattern)
+			if err != nil {
+				return nil, err
+			}
+
+			return translatedPattern, nil
+		case nethtml.TextToken:
+			appendText(string(tokenizer.Text()))
+		case nethtml.StartTagToken:
+			index, err := htmlPlaceholderIndex(tokenizer.Token(), previousPattern)
+			if err != nil {
+				return nil, err
+			}
+
+			translatedPattern = append(translatedPattern, previousPattern[index])
+			opened = append(opened, index)
+		case nethtml.SelfClosingTagToken:
+			index, err := htmlPlaceholderIndex(tokenizer.Token(), previousPattern)
+			if err != nil {
+				return nil, err
+			}
+
+			translatedPattern = append(translatedPattern, previousPattern[index])
+		case nethtml.EndTagToken:
+			if len(opened) == 0 {
+				return nil, errors.New("unexpected HTML closing tag")
+			}
+
+			index := opened[len(opened)-1]
+			opened = opened[:len(opened)-1]
+
+			if closeIndex, ok := pairs[index]; ok {
+				translatedPattern = append(translatedPattern, previousPattern[closeIndex])
+			}
+		case nethtml.CommentToken, nethtml.DoctypeToken:
+			// Not part of the encoded pattern, dropped.
+		}
+	}
+}
+
+// htmlPlaceholderIndex returns the index of the previous pattern part encoded by the HTML element.
+func htmlPlaceholderIndex(token nethtml.Token, previousPattern []parse.PatternPart) (int, error) {
+	for _, attr := range token.Attr {
+		if attr.Key != htmlPlaceholderAttr {
+			continue
+		}
+
+		index, err := strconv.Atoi(attr.Val)
+		if err != nil {
+			return 0, fmt.Errorf("parse placeholder index: %w", err)
+		}
+
+		if index < 0 || index >= len(previousPattern) {
+			return 0, fmt.Errorf("placeholder '%d' is out of range", index)
+		}
+
+		if _, ok := previousPattern[index].(parse.Text); ok {
+			return 0, fmt.Errorf("placeholder '%d' is text", index)
+		}
+
+		return index, nil
+	}
+
+	return 0, fmt.Errorf("unexpected HTML element '%s'", token.Data)
+}
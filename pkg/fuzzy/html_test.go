@@ -0,0 +1,136 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/testutil"
+	"golang.org/x/text/language"
+)
+
+func Test_GetHTMLTexts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "Text is escaped",
+			message: "Tom & Jerry <3",
+			want:    "Tom &amp; Jerry &lt;3",
+		},
+		{
+			name:    "Expression",
+			message: "Hello { $name }!",
+			want:    `Hello <span translate="no" data-mf2="1"></span>!`,
+		},
+		{
+			name:    "Paired markup",
+			message: "Hello, {#b}{ $name }{/b}!",
+			want:    `Hello, <span data-mf2="1"><span translate="no" data-mf2="2"></span></span>!`,
+		},
+		{
+			name:    "Unpaired markup",
+			message: "{#b}Hello{/i}",
+			want:    `<span translate="no" data-mf2="0"></span>Hello<span translate="no" data-mf2="2"></span>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			texts, err := getHTMLTexts(&model.Translation{Messages: []model.Message{{ID: "1", Message: test.message}}})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if len(texts) != 1 || texts[0] != test.want {
+				t.Errorf("want '%s', got %q", test.want, texts)
+			}
+		})
+	}
+}
+
+func Test_BuildTranslatedHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		message        string
+		translatedText string
+		want           string
+		wantStatus     model.MessageStatus
+	}{
+		{
+			name:           "Text is unescaped",
+			message:        "Tom & Jerry",
+			translatedText: "Toms &amp; Džerijs",
+			want:           "Toms & Džerijs",
+			wantStatus:     model.MessageStatusFuzzy,
+		},
+		{
+			name:           "Paired markup moved with the enclosed text",
+			message:        "Click {#b}here{/b} to continue",
+			translatedText: `Lai turpinātu, <span data-mf2="1">noklikšķiniet šeit</span>`,
+			want:           "Lai turpinātu, {#b}noklikšķiniet šeit{/b}",
+			wantStatus:     model.MessageStatusFuzzy,
+		},
+		{
+			name:           "Placeholder self-closed",
+			message:        "Hello { $name }!",
+			translatedText: `Sveiki <span translate="no" data-mf2="1"/>!`,
+			want:           "Sveiki { $name }!",
+			wantStatus:     model.MessageStatusFuzzy,
+		},
+		{
+			name:           "Placeholder dropped",
+			message:        "Hello { $name }!",
+			translatedText: "Sveiki!",
+			want:           "Hello { $name }!",
+			wantStatus:     model.MessageStatusUntranslated,
+		},
+		{
+			name:           "Closing tag dropped",
+			message:        "{#b}Hello{/b}",
+			translatedText: `<span data-mf2="0">Sveiki`,
+			want:           "{#b}Hello{/b}",
+			wantStatus:     model.MessageStatusUntranslated,
+		},
+		{
+			name:           "Unexpected element",
+			message:        "Hello",
+			translatedText: "<b>Sveiki</b>",
+			want:           "Hello",
+			wantStatus:     model.MessageStatusUntranslated,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			translation := &model.Translation{
+				Language: language.English,
+				Messages: []model.Message{{ID: "1", Message: test.message, Status: model.MessageStatusUntranslated}},
+			}
+
+			got, err := buildTranslatedHTML(translation, []string{test.translatedText}, language.Latvian)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			msg := got.Messages[0]
+
+			if test.wantStatus != msg.Status {
+				t.Errorf("want message status '%d', got '%d': %s", test.wantStatus, msg.Status, msg.StatusReason)
+			}
+
+			testutil.EqualMF2Message(t, test.want, msg.Message)
+		})
+	}
+}
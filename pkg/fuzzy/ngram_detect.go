@@ -0,0 +1,359 @@
+package fuzzy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"go.expect.digital/translate/pkg/model"
+	"golang.org/x/text/language"
+)
+
+// ngramMinLetters is the minimum number of letters in a text to attempt language detection.
+const ngramMinLetters = 3
+
+// scriptLanguages maps scripts, that are used by a single language, to the language.
+var scriptLanguages = []struct {
+	script *unicode.RangeTable
+	lang   language.Tag
+}{
+	{unicode.Hiragana, language.Japanese},
+	{unicode.Katakana, language.Japanese},
+	{unicode.Hangul, language.Korean},
+	{unicode.Han, language.Chinese},
+	{unicode.Greek, language.Greek},
+	{unicode.Arabic, language.Arabic},
+	{unicode.Hebrew, language.Hebrew},
+	{unicode.Thai, language.Thai},
+	{unicode.Georgian, language.Georgian},
+	{unicode.Armenian, language.Armenian},
+	{unicode.Devanagari, language.Hindi},
+}
+
+// ngramCorpus is the training text of the n-gram language profiles.
+var ngramCorpus = map[language.Tag]string{
+	language.English: `All human beings are born free and equal in dignity and rights.
+They are endowed with reason and conscience and should act towards one another in a spirit of brotherhood.
+Welcome back! Your file has been uploaded successfully. Please enter your password to continue.
+Are you sure you want to delete this item? Settings were saved. Search results for your query.
+There are no new messages. The quick brown fox jumps over the lazy dog while the children watch from the window.`,
+	language.German: `Alle Menschen sind frei und gleich an Würde und Rechten geboren.
+Sie sind mit Vernunft und Gewissen begabt und sollen einander im Geist der Brüderlichkeit begegnen.
+Willkommen zurück! Ihre Datei wurde erfolgreich hochgeladen. Bitte geben Sie Ihr Passwort ein, um fortzufahren.
+Sind Sie sicher, dass Sie diesen Eintrag löschen möchten? Die Einstellungen wurden gespeichert.
+Suchergebnisse für Ihre Anfrage. Es gibt keine neuen Nachrichten.
+Der schnelle braune Fuchs springt über den faulen Hund, während die Kinder aus dem Fenster schauen.`,
+	language.French: `Tous les êtres humains naissent libres et égaux en dignité et en droits.
+Ils sont doués de raison et de conscience et doivent agir les uns envers les autres dans un esprit de fraternité.
+Bon retour parmi nous ! Votre fichier a été téléchargé avec succès. Veuillez saisir votre mot de passe pour continuer.
+Êtes-vous sûr de vouloir supprimer cet élément ? Les paramètres ont été enregistrés.
+Résultats de recherche pour votre requête. Il n'y a aucun nouveau message.
+Le renard brun rapide saute par-dessus le chien paresseux pendant que les enfants regardent par la fenêtre.`,
+	language.Spanish: `Todos los seres humanos nacen libres e iguales en dignidad y derechos y,
+dotados como están de razón y conciencia, deben comportarse fraternalmente los unos con los otros.
+¡Bienvenido de nuevo! Su archivo se ha subido correctamente. Introduzca su contraseña para continuar.
+¿Está seguro de que desea eliminar este elemento? La configuración se ha guardado.
+Resultados de búsqueda para su consulta. No hay mensajes nuevos.
+El rápido zorro marrón salta sobre el perro perezoso mientras los niños miran por la ventana.`,
+	language.Italian: `Tutti gli esseri umani nascono liberi ed eguali in dignità e diritti.
+Essi sono dotati di ragione e di coscienza e devono agire gli uni verso gli altri in spirito di fratellanza.
+Bentornato! Il tuo file è stato caricato correttamente. Inserisci la password per continuare.
+Sei sicuro di voler eliminare questo elemento? Le impostazioni sono state salvate.
+Risultati della ricerca per la tua richiesta. Non ci sono nuovi messaggi.
+La veloce volpe marrone salta sopra il cane pigro mentre i bambini guardano dalla finestra.`,
+	language.Portuguese: `Todos os seres humanos nascem livres e iguais em dignidade e em direitos.
+Dotados de razão e de consciência, devem agir uns para com os outros em espírito de fraternidade.
+Bem-vindo de volta! Seu arquivo foi enviado com sucesso. Digite sua senha para continuar.
+Tem certeza de que deseja excluir este item? As configurações foram salvas.
+Resultados da pesquisa para a sua consulta. Não há mensagens novas.
+A rápida raposa marrom pula sobre o cão preguiçoso enquanto as crianças olham pela janela.`,
+	language.Dutch: `Alle mensen worden vrij en gelijk in waardigheid en rechten geboren.
+Zij zijn begiftigd met verstand en geweten, en behoren zich jegens elkander in een geest van broederschap te gedragen.
+Welkom terug! Je bestand is succesvol geüpload. Voer je wachtwoord in om verder te gaan.
+Weet je zeker dat je dit item wilt verwijderen? De instellingen zijn opgeslagen.
+Zoekresultaten voor je zoekopdracht. Er zijn geen nieuwe berichten.
+De snelle bruine vos springt over de luie hond terwijl de kinderen uit het raam kijken.`,
+	language.Swedish: `Alla människor är födda fria och lika i värde och rättigheter.
+De har utrustats med förnuft och samvete och bör handla gentemot varandra i en anda av broderskap.
+Välkommen tillbaka! Din fil har laddats upp. Ange ditt lösenord för att fortsätta.
+Är du säker på att du vill ta bort det här objektet? Inställningarna har sparats.
+Sökresultat för din fråga. Det finns inga nya meddelanden.
+Den snabba bruna räven hoppar över den lata hunden medan barnen tittar ut genom fönstret.`,
+	language.Polish: `Wszyscy ludzie rodzą się wolni i równi pod względem swej godności i swych praw.
+Są oni obdarzeni rozumem i sumieniem i powinni postępować wobec innych w duchu braterstwa.
+Witaj ponownie! Twój plik został pomyślnie przesłany. Wprowadź hasło, aby kontynuować.
+Czy na pewno chcesz usunąć ten element? Ustawienia zostały zapisane.
+Wyniki wyszukiwania dla twojego zapytania. Nie ma nowych wiadomości.
+Szybki brązowy lis przeskakuje nad leniwym psem, podczas gdy dzieci patrzą przez okno.`,
+	language.Latvian: `Visi cilvēki piedzimst brīvi un vienlīdzīgi savā pašcieņā un tiesībās.
+Viņi ir apveltīti ar saprātu un sirdsapziņu, un viņiem jāizturas citam pret citu brālības garā.
+Laipni lūdzam atpakaļ! Jūsu fails ir veiksmīgi augšupielādēts. Lūdzu, ievadiet savu paroli, lai turpinātu.
+Vai tiešām vēlaties dzēst šo vienumu? Iestatījumi ir saglabāti.
+Meklēšanas rezultāti jūsu vaicājumam. Jaunu ziņojumu nav.
+Ātrā brūnā lapsa lec pāri slinkajam sunim, kamēr bērni skatās pa logu.`,
+	language.Lithuanian: `Visi žmonės gimsta laisvi ir lygūs savo orumu ir teisėmis.
+Jiems suteiktas protas ir sąžinė ir jie turi elgtis vienas kito atžvilgiu kaip broliai.
+Sveiki sugrįžę! Jūsų failas sėkmingai įkeltas. Įveskite slaptažodį, kad galėtumėte tęsti.
+Ar tikrai norite ištrinti šį elementą? Nustatymai išsaugoti.
+Paieškos rezultatai pagal jūsų užklausą. Naujų pranešimų nėra.
+Greita ruda lapė peršoka per tingų šunį, kol vaikai žiūri pro langą.`,
+	language.Estonian: `Kõik inimesed sünnivad vabadena ja võrdsetena oma väärikuselt ja õigustelt.
+Neile on antud mõistus ja südametunnistus ja nende suhtumist üksteisesse peab kandma vendluse vaim.
+Tere tulemast tagasi! Teie fail on edukalt üles laaditud. Jätkamiseks sisestage oma parool.
+Kas olete kindel, et soovite selle üksuse kustutada? Seaded on salvestatud.
+Otsingutulemused teie päringule. Uusi sõnumeid pole.
+Kiire pruun rebane hüppab üle laisa koera, samal ajal kui lapsed vaatavad aknast välja.`,
+	language.Russian: `Все люди рождаются свободными и равными в своем достоинстве и правах.
+Они наделены разумом и совестью и должны поступать в отношении друг друга в духе братства.
+С возвращением! Ваш файл успешно загружен. Введите пароль, чтобы продолжить.
+Вы уверены, что хотите удалить этот элемент? Настройки сохранены.
+Результаты поиска по вашему запросу. Новых сообщений нет.
+Быстрая коричневая лиса прыгает через ленивую собаку, пока дети смотрят в окно.`,
+	language.Ukrainian: `Всі люди народжуються вільними і рівними у своїй гідності та правах.
+Вони наділені розумом і совістю і повинні діяти у відношенні один до одного в дусі братерства.
+З поверненням! Ваш файл успішно завантажено. Введіть пароль, щоб продовжити.
+Ви впевнені, що хочете видалити цей елемент? Налаштування збережено.
+Результати пошуку за вашим запитом. Нових повідомлень немає.
+Швидка руда лисиця стрибає через ледачого собаку, поки діти дивляться у вікно.`,
+}
+
+// --------------------Definitions--------------------
+
+// ngramProfile is the trigram language model of a single language.
+type ngramProfile struct {
+	lang     language.Tag
+	script   *unicode.RangeTable
+	logProbs map[string]float64
+	unseen   float64 // unseen is the log probability of a trigram not seen in the corpus.
+}
+
+// NgramDetect implements the LanguageDetector interface.
+// It detects the language offline, by the script of the text and character trigrams of the text,
+// compared to the trigram profiles of the languages in ngramCorpus.
+type NgramDetect struct {
+	profiles []ngramProfile
+}
+
+// NewNgramDetect creates a new offline n-gram language detector.
+func NewNgramDetect() *NgramDetect {
+	var (
+		counts     = make(map[language.Tag]map[string]int, len(ngramCorpus))
+		vocabulary = make(map[string]struct{})
+	)
+
+	for lang, corpus := range ngramCorpus {
+		counts[lang] = make(map[string]int)
+
+		for _, trigram := range trigrams(corpus) {
+			counts[lang][trigram]++
+			vocabulary[trigram] = struct{}{}
+		}
+	}
+
+	n := &NgramDetect{profiles: make([]ngramProfile, 0, len(ngramCorpus))}
+
+	for lang, corpus := range ngramCorpus {
+		var total int
+		for _, count := range counts[lang] {
+			total += count
+		}
+
+		// Add-one smoothing, so that unseen trigrams do not rule out the language.
+		denominator := float64(total + len(vocabulary))
+		profile := ngramProfile{
+			lang:     lang,
+			script:   dominantScript(corpus),
+			logProbs: make(map[string]float64, len(counts[lang])),
+			unseen:   math.Log(1 / denominator),
+		}
+
+		for trigram, count := range counts[lang] {
+			profile.logProbs[trigram] = math.Log(float64(count+1) / denominator)
+		}
+
+		n.profiles = append(n.profiles, profile)
+	}
+
+	return n
+}
+
+// --------------------Methods--------------------
+
+// DetectLanguage detects the language of the translation messages.
+func (n *NgramDetect) DetectLanguage(_ context.Context, translation *model.Translation) (LanguageDetection, error) {
+	if translation == nil {
+		return LanguageDetection{Language: language.Und}, nil
+	}
+
+	text, err := detectionText(translation)
+	if err != nil {
+		return LanguageDetection{}, fmt.Errorf("ngram detect: %w", err)
+	}
+
+	return n.detect(text), nil
+}
+
+// detect detects the language of the text.
+// Languages with a script of their own are detected by the script,
+// otherwise by the most probable trigram profile of the same script.
+func (n *NgramDetect) detect(text string) LanguageDetection {
+	und := LanguageDetection{Language: language.Und}
+
+	var letters, latinOrCyrillic int
+
+	scriptLetters := make(map[*unicode.RangeTable]int, len(scriptLanguages))
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		letters++
+
+		if unicode.In(r, unicode.Latin, unicode.Cyrillic) {
+			latinOrCyrillic++
+			continue
+		}
+
+		for _, sl := range scriptLanguages {
+			if unicode.Is(sl.script, r) {
+				scriptLetters[sl.script]++
+				break
+			}
+		}
+	}
+
+	if letters < ngramMinLetters {
+		return und
+	}
+
+	// Japanese is written in Han together with kana, prefer Japanese over Chinese if any kana is present.
+	if kana := scriptLetters[unicode.Hiragana] + scriptLetters[unicode.Katakana]; kana > 0 {
+		return LanguageDetection{
+			Language:   language.Japanese,
+			Confidence: float64(kana+scriptLetters[unicode.Han]) / float64(letters),
+		}
+	}
+
+	var (
+		best      language.Tag
+		bestCount = latinOrCyrillic
+	)
+
+	for _, sl := range scriptLanguages {
+		if count := scriptLetters[sl.script]; count > bestCount {
+			best, bestCount = sl.lang, count
+		}
+	}
+
+	if best != language.Und {
+		return LanguageDetection{Language: best, Confidence: float64(bestCount) / float64(letters)}
+	}
+
+	return n.detectByTrigrams(text)
+}
+
+// detectByTrigrams returns the most probable language of the text by trigram profiles,
+// confidence is the posterior probability of the language assuming equally probable languages.
+func (n *NgramDetect) detectByTrigrams(text string) LanguageDetection {
+	script := dominantScript(text)
+	textTrigrams := trigrams(text)
+
+	if len(textTrigrams) == 0 {
+		return LanguageDetection{Language: language.Und}
+	}
+
+	var (
+		langs  = make([]language.Tag, 0, len(n.profiles))
+		scores = make([]float64, 0, len(n.profiles))
+	)
+
+	for _, profile := range n.profiles {
+		if profile.script != script {
+			continue
+		}
+
+		var score float64
+
+		for _, trigram := range textTrigrams {
+			logProb, ok := profile.logProbs[trigram]
+			if !ok {
+				logProb = profile.unseen
+			}
+
+			score += logProb
+		}
+
+		langs = append(langs, profile.lang)
+		scores = append(scores, score)
+	}
+
+	if len(scores) == 0 {
+		return LanguageDetection{Language: language.Und}
+	}
+
+	// Softmax of the scores, shifted by the maximum score to avoid underflow.
+	best := 0
+
+	for i := range scores {
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+
+	var sum float64
+	for i := range scores {
+		sum += math.Exp(scores[i] - scores[best])
+	}
+
+	return LanguageDetection{Language: langs[best], Confidence: 1 / sum}
+}
+
+// helpers
+
+// trigrams returns character trigrams of the words in s, words are lowercased and padded with spaces.
+//
+// Example:
+//
+//	Input:
+//	  "Hi, all"
+//
+//	Output:
+//	  [" hi", "hi ", " al", "all", "ll "]
+func trigrams(s string) []string {
+	var result []string
+
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) })
+
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+
+		for i := 0; i+3 <= len(runes); i++ {
+			result = append(result, string(runes[i:i+3]))
+		}
+	}
+
+	return result
+}
+
+// dominantScript returns unicode.Cyrillic if most of the letters in s are Cyrillic, otherwise unicode.Latin.
+func dominantScript(s string) *unicode.RangeTable {
+	var latin, cyrillic int
+
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		}
+	}
+
+	if cyrillic > latin {
+		return unicode.Cyrillic
+	}
+
+	return unicode.Latin
+}
@@ -0,0 +1,314 @@
+package fuzzy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.expect.digital/mf2/parse"
+	"go.expect.digital/translate/pkg/model"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// pluralCategories lists CLDR plural categories in CLDR order.
+// Category "other" is not listed, it is always matched by the catch-all variant key '*'.
+var pluralCategories = []struct {
+	name string
+	form plural.Form
+}{
+	{"zero", plural.Zero},
+	{"one", plural.One},
+	{"two", plural.Two},
+	{"few", plural.Few},
+	{"many", plural.Many},
+}
+
+// pluralSample is a number in CLDR plural operands, see plural.Rules.MatchPlural.
+type pluralSample struct {
+	i, v, w, f, t int
+}
+
+// pluralSamples are the numbers checked to find out which plural categories a language uses,
+// and a representative number for each category.
+var pluralSamples = func() []pluralSample {
+	samples := make([]pluralSample, 0, 200+10) //nolint:mnd
+
+	for i := range 200 {
+		samples = append(samples, pluralSample{i: i})
+	}
+
+	// Decimals e.g. 0.5, 1.5, ... 9.5
+	for i := range 10 {
+		samples = append(samples, pluralSample{i: i, v: 1, w: 1, f: 5, t: 5})
+	}
+
+	return samples
+}()
+
+// pluralForms returns the plural categories used by the language, with a representative number for each.
+func pluralForms(rules *plural.Rules, lang language.Tag) map[string]pluralSample {
+	forms := make(map[string]pluralSample, len(pluralCategories))
+
+	for _, sample := range pluralSamples {
+		form := rules.MatchPlural(lang, sample.i, sample.v, sample.w, sample.f, sample.t)
+
+		for _, category := range pluralCategories {
+			if _, ok := forms[category.name]; !ok && category.form == form {
+				forms[category.name] = sample
+			}
+		}
+	}
+
+	return forms
+}
+
+// pluralCategory returns the plural category of the sample in the language, "other" if not any of pluralCategories.
+func pluralCategory(rules *plural.Rules, lang language.Tag, sample pluralSample) string {
+	form := rules.MatchPlural(lang, sample.i, sample.v, sample.w, sample.f, sample.t)
+
+	for _, category := range pluralCategories {
+		if category.form == form {
+			return category.name
+		}
+	}
+
+	return "other"
+}
+
+// isPluralCategory reports whether the variant key is a plural category name.
+func isPluralCategory(key string) bool {
+	if key == "other" {
+		return true
+	}
+
+	for _, category := range pluralCategories {
+		if category.name == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectorRules returns plural rules for the selector,
+// nil if the selector is not declared with a plural selector function.
+func selectorRules(declarations []parse.Declaration, selector parse.Variable) *plural.Rules {
+	for _, decl := range declarations {
+		var expr parse.Expression
+
+		switch decl := decl.(type) {
+		default:
+			continue
+		case parse.LocalDeclaration:
+			if decl.Variable != selector {
+				continue
+			}
+
+			expr = decl.Expression
+		case parse.InputDeclaration:
+			if decl.Expression.Operand != selector {
+				continue
+			}
+
+			expr = decl.Expression
+		}
+
+		function, ok := expr.Annotation.(parse.Function)
+		if !ok || function.Identifier.Name != "number" && function.Identifier.Name != "integer" {
+			return nil
+		}
+
+		for _, option := range function.Options {
+			if option.Identifier.Name != "select" {
+				continue
+			}
+
+			switch literalString(option.Value) {
+			case "exact":
+				return nil
+			case "ordinal":
+				return plural.Ordinal
+			}
+		}
+
+		return plural.Cardinal
+	}
+
+	return nil
+}
+
+// literalString returns the unquoted value of the literal, empty if the value is not a literal.
+func literalString(value parse.Value) string {
+	switch value := value.(type) {
+	default:
+		return ""
+	case parse.NameLiteral:
+		return string(value)
+	case parse.QuotedLiteral:
+		return string(value)
+	}
+}
+
+// keyString returns the variant key as written in the message, e.g. "*", "one" or "1".
+func keyString(key parse.VariantKey) string {
+	return strings.Trim(fmt.Sprint(key), "|")
+}
+
+// expandPlurals adapts plural variants of the messages to the plural categories of the target language,
+// so that the translator translates a variant for each category:
+//   - categories the source language doesn't use are synthesized from the variant
+//     the source language selects for a representative number of the category;
+//   - categories the target language does not use are dropped.
+//
+// The incoming translation is not modified.
+//
+// Example:
+//
+//	Input (en -> pl):
+//	  .input {$count :number}
+//	  .match $count
+//	  one {{{$count} file}}
+//	  *   {{{$count} files}}
+//
+//	Output:
+//	  .input {$count :number}
+//	  .match $count
+//	  one  {{{$count} file}}
+//	  few  {{{$count} files}}
+//	  many {{{$count} files}}
+//	  *    {{{$count} files}}
+func expandPlurals(translation *model.Translation, targetLanguage language.Tag) (*model.Translation, error) {
+	expanded := *translation
+	expanded.Messages = slices.Clone(translation.Messages)
+
+	for i := range expanded.Messages {
+		messageAST, err := parse.Parse(expanded.Messages[i].Message)
+		if err != nil {
+			return nil, fmt.Errorf("parse mf2 message with ID '%s': %w", expanded.Messages[i].ID, err)
+		}
+
+		message, ok := messageAST.Message.(parse.ComplexMessage)
+		if !ok {
+			continue
+		}
+
+		matcher, ok := message.ComplexBody.(parse.Matcher)
+		if !ok {
+			continue
+		}
+
+		var changed bool
+
+		for k, selector := range matcher.Selectors {
+			rules := selectorRules(message.Declarations, selector)
+			if rules == nil {
+				continue
+			}
+
+			var selectorChanged bool
+
+			matcher.Variants, selectorChanged = expandVariants(matcher.Variants, k, rules, translation.Language, targetLanguage)
+			changed = changed || selectorChanged
+		}
+
+		if !changed {
+			continue
+		}
+
+		// rewrite AST
+		message.ComplexBody = matcher
+		messageAST.Message = message
+		expanded.Messages[i].Message = messageAST.String()
+	}
+
+	return &expanded, nil
+}
+
+// expandVariants adapts variant keys of the k-th selector to the plural categories of the target language.
+// Variants are grouped by the keys of other selectors, missing categories are inserted before
+// the catch-all variant of each group. Reports whether variants have changed.
+func expandVariants(
+	variants []parse.Variant,
+	k int,
+	rules *plural.Rules,
+	sourceLanguage, targetLanguage language.Tag,
+) ([]parse.Variant, bool) {
+	sourceForms, targetForms := pluralForms(rules, sourceLanguage), pluralForms(rules, targetLanguage)
+
+	// groupKey identifies variants with the same keys except for the k-th selector.
+	groupKey := func(v parse.Variant) string {
+		keys := make([]string, 0, len(v.Keys))
+
+		for j, key := range v.Keys {
+			if j != k {
+				keys = append(keys, keyString(key))
+			}
+		}
+
+		return strings.Join(keys, " ")
+	}
+
+	expanded := make([]parse.Variant, 0, len(variants))
+
+	var changed bool
+
+	for _, variant := range variants {
+		if k >= len(variant.Keys) {
+			expanded = append(expanded, variant)
+			continue
+		}
+
+		key := keyString(variant.Keys[k])
+
+		// Drop categories the target language doesn't use.
+		if _, ok := targetForms[key]; !ok && key != "other" && isPluralCategory(key) {
+			changed = true
+			continue
+		}
+
+		if key == "*" {
+			group := groupKey(variant)
+
+			// Variants of the group, to pick from when synthesizing missing categories.
+			groupVariants := make(map[string]parse.Variant)
+
+			for _, v := range variants {
+				if k < len(v.Keys) && groupKey(v) == group {
+					groupVariants[keyString(v.Keys[k])] = v
+				}
+			}
+
+			for _, category := range pluralCategories {
+				// Synthesize only categories the source language can't express,
+				// a category missing in the source language on purpose stays missing.
+				sample, ok := targetForms[category.name]
+				_, exists := groupVariants[category.name]
+				_, inSource := sourceForms[category.name]
+
+				if !ok || exists || inSource {
+					continue
+				}
+
+				// Pick the variant the source language would use for the representative number.
+				source, ok := groupVariants[pluralCategory(rules, sourceLanguage, sample)]
+				if !ok {
+					source = variant
+				}
+
+				synthesized := parse.Variant{
+					Keys:          slices.Clone(source.Keys),
+					QuotedPattern: slices.Clone(source.QuotedPattern),
+				}
+				synthesized.Keys[k] = parse.NameLiteral(category.name)
+
+				expanded = append(expanded, synthesized)
+				changed = true
+			}
+		}
+
+		expanded = append(expanded, variant)
+	}
+
+	return expanded, changed
+}
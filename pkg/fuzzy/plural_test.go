@@ -0,0 +1,204 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/testutil"
+	"golang.org/x/text/language"
+)
+
+func Test_ExpandPlurals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		targetLanguage language.Tag
+		name           string
+		message        string
+		want           string
+	}{
+		{
+			name: "Synthesize few and many",
+			message: `.input { $count :number }
+.match $count
+one {{{ $count } file}}
+* {{{ $count } files}}`,
+			want: `.input { $count :number }
+.match $count
+one {{{ $count } file}}
+few {{{ $count } files}}
+many {{{ $count } files}}
+* {{{ $count } files}}`,
+			targetLanguage: language.Polish,
+		},
+		{
+			name: "Synthesize zero",
+			message: `.input { $count :integer }
+.match $count
+one {{{ $count } file}}
+* {{{ $count } files}}`,
+			want: `.input { $count :integer }
+.match $count
+one {{{ $count } file}}
+zero {{{ $count } files}}
+* {{{ $count } files}}`,
+			targetLanguage: language.Latvian,
+		},
+		{
+			name: "Drop unused categories",
+			message: `.input { $count :number }
+.match $count
+0 {{No files}}
+one {{{ $count } file}}
+* {{{ $count } files}}`,
+			want: `.input { $count :number }
+.match $count
+0 {{No files}}
+* {{{ $count } files}}`,
+			targetLanguage: language.Japanese,
+		},
+		{
+			name: "Ordinal",
+			message: `.input { $place :number select=ordinal }
+.match $place
+one {{{ $place }st}}
+two {{{ $place }nd}}
+few {{{ $place }rd}}
+* {{{ $place }th}}`,
+			want: `.input { $place :number select=ordinal }
+.match $place
+one {{{ $place }st}}
+* {{{ $place }th}}`,
+			targetLanguage: language.French,
+		},
+		{
+			name: "Multiple selectors",
+			message: `.input { $count :number }
+.input { $gender :string }
+.match $gender $count
+female one {{She has { $count } file}}
+female * {{She has { $count } files}}
+* one {{They have { $count } file}}
+* * {{They have { $count } files}}`,
+			want: `.input { $count :number }
+.input { $gender :string }
+.match $gender $count
+female one {{She has { $count } file}}
+female few {{She has { $count } files}}
+female many {{She has { $count } files}}
+female * {{She has { $count } files}}
+* one {{They have { $count } file}}
+* few {{They have { $count } files}}
+* many {{They have { $count } files}}
+* * {{They have { $count } files}}`,
+			targetLanguage: language.Polish,
+		},
+		{
+			name: "Exact selector is unchanged",
+			message: `.input { $count :number select=exact }
+.match $count
+one {{{ $count } file}}
+* {{{ $count } files}}`,
+			want: `.input { $count :number select=exact }
+.match $count
+one {{{ $count } file}}
+* {{{ $count } files}}`,
+			targetLanguage: language.Japanese,
+		},
+		{
+			name: "Selector named like an option",
+			message: `.input { $exactCount :number }
+.match $exactCount
+one {{{ $exactCount } file}}
+* {{{ $exactCount } files}}`,
+			want: `.input { $exactCount :number }
+.match $exactCount
+one {{{ $exactCount } file}}
+few {{{ $exactCount } files}}
+many {{{ $exactCount } files}}
+* {{{ $exactCount } files}}`,
+			targetLanguage: language.Polish,
+		},
+		{
+			name: "Non-plural selector is unchanged",
+			message: `.input { $type :string }
+.match $type
+one {{One}}
+* {{Other}}`,
+			want: `.input { $type :string }
+.match $type
+one {{One}}
+* {{Other}}`,
+			targetLanguage: language.Japanese,
+		},
+		{
+			name:           "Simple message is unchanged",
+			message:        "Hello, { $name }!",
+			want:           "Hello, { $name }!",
+			targetLanguage: language.Polish,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := &model.Translation{
+				Language: language.English,
+				Messages: []model.Message{{ID: "1", Message: test.message, Status: model.MessageStatusUntranslated}},
+			}
+
+			got, err := expandPlurals(input, test.targetLanguage)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			testutil.EqualMF2Message(t, test.want, got.Messages[0].Message)
+
+			if test.message != input.Messages[0].Message {
+				t.Errorf("want input message unchanged, got %s", input.Messages[0].Message)
+			}
+		})
+	}
+}
+
+func Test_PseudoTranslateExpandPlurals(t *testing.T) {
+	t.Parallel()
+
+	pseudo, err := NewPseudoTranslate(WithPseudoExpansion(0))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	input := &model.Translation{
+		Language: language.English,
+		Messages: []model.Message{{
+			ID: "1",
+			Message: `.input { $count :number }
+.match $count
+one {{File}}
+* {{Files}}`,
+			Status: model.MessageStatusUntranslated,
+		}},
+	}
+
+	got, err := pseudo.Translate(t.Context(), input, PseudoBidi)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Arabic uses all plural categories, missing ones are inserted before the catch-all variant.
+	want := `.input { $count :number }
+.match $count
+one {{[` + rlm + rlo + "File" + pdf + rlm + `]}}
+zero {{[` + rlm + rlo + "Files" + pdf + rlm + `]}}
+two {{[` + rlm + rlo + "Files" + pdf + rlm + `]}}
+few {{[` + rlm + rlo + "Files" + pdf + rlm + `]}}
+many {{[` + rlm + rlo + "Files" + pdf + rlm + `]}}
+* {{[` + rlm + rlo + "Files" + pdf + rlm + `]}}`
+
+	testutil.EqualMF2Message(t, want, got.Messages[0].Message)
+}
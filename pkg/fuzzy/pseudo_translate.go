@@ -0,0 +1,202 @@
+package fuzzy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.expect.digital/translate/pkg/model"
+	"golang.org/x/text/language"
+)
+
+const (
+	// pseudoDefaultExpansion is the default ratio by which pseudo translated text is lengthened.
+	pseudoDefaultExpansion = 0.3
+
+	// pseudoPadding is the character used to lengthen pseudo translated text.
+	pseudoPadding = "~"
+
+	// Unicode bidirectional control characters used by the RTL pseudo-locale.
+	rlm = "\u200f" // RIGHT-TO-LEFT MARK
+	rlo = "\u202e" // RIGHT-TO-LEFT OVERRIDE
+	pdf = "\u202c" // POP DIRECTIONAL FORMATTING
+)
+
+var (
+	// PseudoAccented is the pseudo-locale with accented, expanded and bracketed text, e.g. "[Ĥéļļö~~]".
+	PseudoAccented = language.MustParse("en-XA")
+	// PseudoBidi is the pseudo-locale with text forced to display right-to-left.
+	PseudoBidi = language.MustParse("ar-XB")
+)
+
+// accents maps ASCII letters to their accented look-alikes.
+var accents = map[rune]rune{
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ļ',
+	'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ', 'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ',
+	'Y': 'Ý', 'Z': 'Ž',
+	'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ', 'k': 'ķ', 'l': 'ļ',
+	'm': 'ṁ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ', 'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ',
+	'y': 'ý', 'z': 'ž',
+}
+
+// --------------------Definitions--------------------
+
+// PseudoTranslate implements the Translator interface.
+// It pseudo-localizes text parts of the messages, leaving placeholders and markup intact.
+// Use it to find truncated and hard-coded strings without a machine translation service.
+type PseudoTranslate struct {
+	expansion float64
+}
+
+type PseudoTranslateOption func(*PseudoTranslate) error
+
+// WithPseudoExpansion sets the ratio by which pseudo translated text is lengthened, e.g. 0.3 adds 30%.
+func WithPseudoExpansion(ratio float64) PseudoTranslateOption {
+	return func(p *PseudoTranslate) error {
+		if ratio < 0 || math.IsNaN(ratio) || math.IsInf(ratio, 0) {
+			return fmt.Errorf("invalid expansion ratio %v, must be a non-negative number", ratio)
+		}
+
+		p.expansion = ratio
+
+		return nil
+	}
+}
+
+// NewPseudoTranslate creates a new pseudo-localization translator.
+func NewPseudoTranslate(opts ...PseudoTranslateOption) (*PseudoTranslate, error) {
+	p := &PseudoTranslate{expansion: pseudoDefaultExpansion}
+
+	for _, opt := range opts {
+		err := opt(p)
+		if err != nil {
+			return nil, fmt.Errorf("apply opt: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// IsPseudoLocale reports whether the language is one of the pseudo-locales, e.g. en-XA or ar-XB.
+func IsPseudoLocale(lang language.Tag) bool {
+	region, _ := lang.Region()
+
+	return region.String() == "XA" || region.String() == "XB"
+}
+
+// --------------------Methods--------------------
+
+// Translate pseudo-localizes the translation messages. Text is displayed right-to-left
+// for the RTL pseudo-locale (ar-XB), otherwise text is accented.
+// Pseudo translated text is lengthened by the expansion ratio and enclosed in brackets.
+func (p *PseudoTranslate) Translate(
+	_ context.Context,
+	translation *model.Translation,
+	targetLanguage language.Tag,
+) (*model.Translation, error) {
+	if translation == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	if len(translation.Messages) == 0 {
+		return &model.Translation{Language: targetLanguage, Original: translation.Original}, nil
+	}
+
+	// Adapt plural variants to the plural categories of the target language
+	translation, err := expandPlurals(translation, targetLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("pseudo translate: expand plurals: %w", err)
+	}
+
+	texts, err := getTexts(translation)
+	if err != nil {
+		return nil, fmt.Errorf("pseudo translate: get texts: %w", err)
+	}
+
+	region, _ := targetLanguage.Region()
+	bidi := region.String() == "XB"
+
+	for i := range texts {
+		texts[i] = p.pseudoText(texts[i], bidi)
+	}
+
+	translated, err := buildTranslated(translation, texts, targetLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("pseudo translate: build translated: %w", err)
+	}
+
+	return translated, nil
+}
+
+// pseudoText pseudo-localizes text parts of s, placeholders '{$d}' are left intact.
+//
+// Example:
+//
+//	Input:
+//	  "Hello {$0}!"
+//
+//	Output:
+//	  "[Ĥéļļö {$0}!~~]"
+func (p *PseudoTranslate) pseudoText(s string, bidi bool) string {
+	if s == "" {
+		return ""
+	}
+
+	var (
+		sb    strings.Builder
+		runes int
+	)
+
+	sb.WriteString("[")
+
+	for _, part := range splitTextByPlaceholder(s) {
+		if placeholderRegexp.MatchString(part) {
+			sb.WriteString(part)
+			continue
+		}
+
+		runes += utf8.RuneCountInString(part)
+
+		if bidi {
+			sb.WriteString(bidiText(part))
+			continue
+		}
+
+		sb.WriteString(accentText(part))
+	}
+
+	sb.WriteString(strings.Repeat(pseudoPadding, int(math.Ceil(float64(runes)*p.expansion))))
+	sb.WriteString("]")
+
+	return sb.String()
+}
+
+// accentText replaces ASCII letters with their accented look-alikes.
+func accentText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if a, ok := accents[r]; ok {
+			return a
+		}
+
+		return r
+	}, s)
+}
+
+// bidiText forces words to display right-to-left, whitespace is left as is.
+func bidiText(s string) string {
+	var sb strings.Builder
+
+	for word := range strings.FieldsFuncSeq(s, unicode.IsSpace) {
+		sb.WriteString(s[:strings.Index(s, word)])
+		sb.WriteString(rlm + rlo + word + pdf + rlm)
+
+		s = s[strings.Index(s, word)+len(word):]
+	}
+
+	sb.WriteString(s) // trailing whitespace
+
+	return sb.String()
+}
@@ -0,0 +1,127 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/testutil"
+	"golang.org/x/text/language"
+)
+
+func Test_PseudoTranslate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		targetLanguage language.Tag
+		name           string
+		message        string
+		want           string
+		expansion      float64
+	}{
+		{
+			name:           "Accented text with placeholder",
+			message:        "Hello, { $name }!",
+			want:           "[Ĥéļļö, { $name }!~~~]",
+			targetLanguage: PseudoAccented,
+			expansion:      0.3,
+		},
+		{
+			name:           "Markup is left intact",
+			message:        "{#b}Hello{/b}",
+			want:           "[{#b}Ĥéļļö{/b}~~]",
+			targetLanguage: PseudoAccented,
+			expansion:      0.3,
+		},
+		{
+			name:           "No expansion",
+			message:        "Hello",
+			want:           "[Ĥéļļö]",
+			targetLanguage: PseudoAccented,
+			expansion:      0,
+		},
+		{
+			name:           "Non pseudo-locale target is accented",
+			message:        "Hello",
+			want:           "[Ĥéļļö~~~~~]",
+			targetLanguage: language.Latvian,
+			expansion:      1,
+		},
+		{
+			name:           "Right-to-left",
+			message:        "Hi there { $name }",
+			want:           "[" + rlm + rlo + "Hi" + pdf + rlm + " " + rlm + rlo + "there" + pdf + rlm + " { $name }]",
+			targetLanguage: PseudoBidi,
+			expansion:      0,
+		},
+		{
+			name: "Matcher variants",
+			message: `.input { $count :number }
+.match $count
+1 {{One}}
+* {{Many}}`,
+			want: `.input { $count :number }
+.match $count
+1 {{[Öñé~]}}
+* {{[Ṁåñý~~]}}`,
+			targetLanguage: PseudoAccented,
+			expansion:      0.3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pseudo, err := NewPseudoTranslate(WithPseudoExpansion(test.expansion))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			input := &model.Translation{
+				Language: language.English,
+				Messages: []model.Message{{ID: "1", Message: test.message, Status: model.MessageStatusUntranslated}},
+			}
+
+			got, err := pseudo.Translate(t.Context(), input, test.targetLanguage)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if test.targetLanguage != got.Language {
+				t.Errorf("want language '%s', got '%s'", test.targetLanguage, got.Language)
+			}
+
+			if model.MessageStatusFuzzy != got.Messages[0].Status {
+				t.Errorf("want message status '%d', got '%d'", model.MessageStatusFuzzy, got.Messages[0].Status)
+			}
+
+			testutil.EqualMF2Message(t, test.want, got.Messages[0].Message)
+		})
+	}
+}
+
+func Test_PseudoTranslateInvalidExpansion(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPseudoTranslate(WithPseudoExpansion(-1))
+	if err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func Test_IsPseudoLocale(t *testing.T) {
+	t.Parallel()
+
+	for lang, want := range map[language.Tag]bool{
+		PseudoAccented:          true,
+		PseudoBidi:              true,
+		language.English:        false,
+		language.BritishEnglish: false,
+	} {
+		if got := IsPseudoLocale(lang); want != got {
+			t.Errorf("want IsPseudoLocale(%s) %t, got %t", lang, want, got)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package fuzzy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.expect.digital/translate/pkg/model"
+)
+
+// DefaultQualityThreshold is the default back-translation quality score,
+// machine translated messages scoring below it are flagged for review.
+const DefaultQualityThreshold = 0.5
+
+// QualityScore returns the similarity of the source and back-translated MF2 messages in range [0, 1].
+// The translatable texts without placeholders are compared ignoring case and punctuation,
+// using the Sørensen–Dice coefficient of character bigrams.
+func QualityScore(source, backTranslated string) (float64, error) {
+	sourceText, err := qualityText(source)
+	if err != nil {
+		return 0, fmt.Errorf("source: %w", err)
+	}
+
+	backTranslatedText, err := qualityText(backTranslated)
+	if err != nil {
+		return 0, fmt.Errorf("back-translation: %w", err)
+	}
+
+	return diceCoefficient(sourceText, backTranslatedText), nil
+}
+
+// ScoreQuality sets the quality score of the fuzzy messages of the translated translation
+// by comparing the source messages with the back-translated messages, both in the original language.
+// Messages scoring below the threshold are flagged for review by appending to the status reason.
+// Messages without a valid back-translation are not scored.
+func ScoreQuality(source, translated, backTranslated *model.Translation, threshold float64) error {
+	sourceLookup := make(map[string]string, len(source.Messages))
+	for _, msg := range source.Messages {
+		sourceLookup[msg.ID] = msg.Message
+	}
+
+	backTranslatedLookup := make(map[string]string, len(backTranslated.Messages))
+
+	for _, msg := range backTranslated.Messages {
+		if msg.Status == model.MessageStatusFuzzy {
+			backTranslatedLookup[msg.ID] = msg.Message
+		}
+	}
+
+	for i := range translated.Messages {
+		msg := &translated.Messages[i]
+
+		sourceMsg, ok := sourceLookup[msg.ID]
+		if !ok || msg.Status != model.MessageStatusFuzzy {
+			continue
+		}
+
+		backTranslatedMsg, ok := backTranslatedLookup[msg.ID]
+		if !ok {
+			continue
+		}
+
+		score, err := QualityScore(sourceMsg, backTranslatedMsg)
+		if err != nil {
+			return fmt.Errorf("score message '%s': %w", msg.ID, err)
+		}
+
+		msg.QualityScore = &score
+
+		if score < threshold {
+			reason := fmt.Sprintf("low back-translation quality score %.2f: %s", score, backTranslatedMsg)
+
+			// Keep the existing reason, e.g. an invalid machine translation.
+			if msg.StatusReason != "" {
+				reason = msg.StatusReason + "; " + reason
+			}
+
+			msg.StatusReason = reason
+		}
+	}
+
+	return nil
+}
+
+// qualityText returns the translatable text of the MF2 message without placeholders,
+// lowercase words separated by a single space.
+func qualityText(message string) (string, error) {
+	texts, err := getTexts(&model.Translation{Messages: []model.Message{{Message: message}}})
+	if err != nil {
+		return "", fmt.Errorf("get texts: %w", err)
+	}
+
+	text := placeholderRegexp.ReplaceAllString(strings.Join(texts, " "), " ")
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	return strings.Join(words, " "), nil
+}
+
+// diceCoefficient returns the Sørensen–Dice coefficient of the character bigrams of a and b.
+func diceCoefficient(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	bigramsA, bigramsB := bigrams(a), bigrams(b)
+
+	total := len(bigramsA) + len(bigramsB)
+	if total == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(bigramsA))
+	for _, bigram := range bigramsA {
+		counts[bigram]++
+	}
+
+	var shared int
+
+	for _, bigram := range bigramsB {
+		if counts[bigram] > 0 {
+			counts[bigram]--
+			shared++
+		}
+	}
+
+	return 2 * float64(shared) / float64(total)
+}
+
+// bigrams returns the character bigrams of s.
+func bigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) < 2 { //nolint:mnd
+		return nil
+	}
+
+	result := make([]string, 0, len(runes)-1)
+	for i := range len(runes) - 1 {
+		result = append(result, string(runes[i:i+2]))
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	"golang.org/x/text/language"
+)
+
+func Test_QualityScore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		source         string
+		backTranslated string
+		wantMin        float64
+		wantMax        float64
+	}{
+		{
+			name:           "Identical",
+			source:         "{{Hello, world!}}",
+			backTranslated: "{{Hello, world!}}",
+			wantMin:        1,
+			wantMax:        1,
+		},
+		{
+			name:           "Case and punctuation are ignored",
+			source:         "{{Hello, world!}}",
+			backTranslated: "{{hello world}}",
+			wantMin:        1,
+			wantMax:        1,
+		},
+		{
+			name:           "Placeholders are ignored",
+			source:         "{{Hello, { $name }!}}",
+			backTranslated: "{{Hello { $user }}}",
+			wantMin:        1,
+			wantMax:        1,
+		},
+		{
+			name:           "Similar",
+			source:         "{{Save the file}}",
+			backTranslated: "{{Save this file}}",
+			wantMin:        DefaultQualityThreshold,
+			wantMax:        0.99,
+		},
+		{
+			name:           "Unrelated",
+			source:         "{{Save the file}}",
+			backTranslated: "{{Cancel my order}}",
+			wantMin:        0,
+			wantMax:        0.2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			score, err := QualityScore(test.source, test.backTranslated)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if score < test.wantMin || score > test.wantMax {
+				t.Errorf("want score in [%.2f, %.2f], got %.2f", test.wantMin, test.wantMax, score)
+			}
+		})
+	}
+}
+
+func Test_ScoreQuality(t *testing.T) {
+	t.Parallel()
+
+	source := &model.Translation{
+		Language: language.English,
+		Messages: []model.Message{
+			{ID: "good", Message: "{{Save the file}}", Status: model.MessageStatusUntranslated},
+			{ID: "bad", Message: "{{Save the file}}", Status: model.MessageStatusUntranslated},
+			{ID: "translated", Message: "{{Save the file}}", Status: model.MessageStatusUntranslated},
+		},
+	}
+
+	translated := &model.Translation{
+		Language: language.Latvian,
+		Messages: []model.Message{
+			{ID: "good", Message: "{{Saglabāt failu}}", Status: model.MessageStatusFuzzy},
+			{ID: "bad", Message: "{{Atcelt pasūtījumu}}", Status: model.MessageStatusFuzzy, StatusReason: "invalid"},
+			{ID: "translated", Message: "{{Saglabāt failu}}", Status: model.MessageStatusTranslated},
+		},
+	}
+
+	backTranslated := &model.Translation{
+		Language: language.English,
+		Messages: []model.Message{
+			{ID: "good", Message: "{{Save the file}}", Status: model.MessageStatusFuzzy},
+			{ID: "bad", Message: "{{Cancel the order}}", Status: model.MessageStatusFuzzy},
+			{ID: "translated", Message: "{{Save the file}}", Status: model.MessageStatusFuzzy},
+		},
+	}
+
+	err := ScoreQuality(source, translated, backTranslated, DefaultQualityThreshold)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	good, bad, reviewed := translated.Messages[0], translated.Messages[1], translated.Messages[2]
+
+	if good.QualityScore == nil || *good.QualityScore != 1 || good.StatusReason != "" {
+		t.Errorf("want good message scored 1 and not flagged, got %+v", good)
+	}
+
+	if bad.QualityScore == nil || *bad.QualityScore >= DefaultQualityThreshold || bad.Status != model.MessageStatusFuzzy {
+		t.Errorf("want bad message scored below threshold and fuzzy, got %+v", bad)
+	}
+
+	// The existing reason is kept.
+	if !strings.HasPrefix(bad.StatusReason, "invalid; low back-translation quality score") {
+		t.Errorf("want bad message reason appended to the existing reason, got '%s'", bad.StatusReason)
+	}
+
+	if reviewed.QualityScore != nil {
+		t.Errorf("want translated message not scored, got %+v", reviewed)
+	}
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+// Job is an asynchronous machine translation job, it fuzzy translates
+// untranslated messages of a service for the given languages.
+type Job struct {
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// LeaseUntil is when the claim of the running job expires, the job can be claimed again after it.
+	LeaseUntil time.Time      `json:"leaseUntil"`
+	Error      string         `json:"error"`
+	Languages  []language.Tag `json:"languages"`
+	Total      int            `json:"total"` // Total is the number of languages to translate.
+	Done       int            `json:"done"`  // Done is the number of languages already translated.
+	ID         uuid.UUID      `json:"id"`
+	ServiceID  uuid.UUID      `json:"serviceId"`
+	// Owner is the ID of the server that claimed the job, see repo.JobsRepo.ClaimJob.
+	Owner  uuid.UUID `json:"owner"`
+	Status JobStatus `json:"status"`
+}
+
+// Finished reports whether the job has either succeeded or failed.
+func (j *Job) Finished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed
+}
+
+type JobStatus int32
+
+const (
+	JobStatusPending JobStatus = iota
+	JobStatusRunning
+	JobStatusSucceeded
+	JobStatusFailed
+)
+
+const (
+	jobStatusTextPending   = "PENDING"
+	jobStatusTextRunning   = "RUNNING"
+	jobStatusTextSucceeded = "SUCCEEDED"
+	jobStatusTextFailed    = "FAILED"
+)
+
+func (s *JobStatus) String() string {
+	switch *s {
+	default:
+		return ""
+	case JobStatusPending:
+		return jobStatusTextPending
+	case JobStatusRunning:
+		return jobStatusTextRunning
+	case JobStatusSucceeded:
+		return jobStatusTextSucceeded
+	case JobStatusFailed:
+		return jobStatusTextFailed
+	}
+}
+
+// Value implements driver.Valuer interface.
+func (s *JobStatus) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+// Scan implements sql.Scanner interface.
+func (s *JobStatus) Scan(value any) error {
+	switch v := value.(type) {
+	default:
+		return fmt.Errorf("unknown type %+v, want string", v)
+	case string:
+		return s.Scan([]byte(v))
+	case []byte:
+		switch string(v) {
+		default:
+			return fmt.Errorf("unknown job status: %+v", v)
+		case jobStatusTextPending:
+			*s = JobStatusPending
+		case jobStatusTextRunning:
+			*s = JobStatusRunning
+		case jobStatusTextSucceeded:
+			*s = JobStatusSucceeded
+		case jobStatusTextFailed:
+			*s = JobStatusFailed
+		}
+	}
+
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+
+	"github.com/google/uuid"
 )
 
 // Mask is a list of paths that can be used to specify which fields
@@ -172,6 +174,12 @@ func UpdateService(src, dst *Service, mask Mask) error {
 	// When mask is nil dstService is updated with all fields from srcService
 	// So we need to make sure that the ID is not updated
 	src.ID = dst.ID
+
+	// A service without a project keeps its project.
+	if src.ProjectID == uuid.Nil {
+		src.ProjectID = dst.ProjectID
+	}
+
 	Update(src, dst, mask)
 
 	return nil
@@ -13,13 +13,17 @@ import (
 type Translation struct {
 	Language language.Tag `json:"language"`
 	Messages []Message    `json:"messages"`
-	Original bool         `json:"original"`
+	// Version is incremented on every save that changes the translation, for optimistic concurrency control,
+	// 0 if never saved.
+	Version  int  `json:"version"`
+	Original bool `json:"original"`
 }
 
 /*
 FindChangedMessageIDs returns a list of message IDs that have been altered in the new Translation e.g.
  1. The message.message has been changed
- 2. The message with new ID has been added.
+ 2. The message with new ID has been added
+ 3. The obsolete message has been added again.
 */
 func (t *Translation) FindChangedMessageIDs(current *Translation) []string {
 	lookup := make(map[string]int, len(t.Messages))
@@ -30,7 +34,8 @@ func (t *Translation) FindChangedMessageIDs(current *Translation) []string {
 	var ids []string
 
 	for _, msg := range current.Messages {
-		if idx, ok := lookup[msg.ID]; !ok || t.Messages[idx].Message != msg.Message {
+		idx, ok := lookup[msg.ID]
+		if !ok || t.Messages[idx].Message != msg.Message || t.Messages[idx].Status == MessageStatusObsolete {
 			ids = append(ids, msg.ID)
 		}
 	}
@@ -38,6 +43,29 @@ func (t *Translation) FindChangedMessageIDs(current *Translation) []string {
 	return ids
 }
 
+// FindRemovedMessages returns messages of the Translation missing in the new Translation.
+func (t *Translation) FindRemovedMessages(current *Translation) []Message {
+	lookup := make(map[string]struct{}, len(current.Messages))
+	for i := range current.Messages {
+		lookup[current.Messages[i].ID] = struct{}{}
+	}
+
+	var messages []Message
+
+	for _, msg := range t.Messages {
+		if _, ok := lookup[msg.ID]; !ok {
+			messages = append(messages, msg)
+		}
+	}
+
+	return messages
+}
+
+// EqualMessages reports whether the translations have equal messages in the same order.
+func (t *Translation) EqualMessages(other *Translation) bool {
+	return slices.EqualFunc(t.Messages, other.Messages, func(a, b Message) bool { return a.Equal(&b) })
+}
+
 type Translations []Translation
 
 // HasLanguage checks if Translations contains Translation with the given language.
@@ -124,8 +152,57 @@ func (t *Translations) MarkUntranslated(ids []string) {
 	}
 }
 
+// DeleteMessages deletes messages with IDs in the ids slice from all languages.
+func (t *Translations) DeleteMessages(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	slices.Sort(ids)
+
+	for i := range *t {
+		(*t)[i].Messages = slices.DeleteFunc((*t)[i].Messages, func(m Message) bool {
+			_, found := slices.BinarySearch(ids, m.ID)
+			return found
+		})
+	}
+}
+
+// MarkObsolete changes status of the messages removed from the original translation to OBSOLETE in all languages.
+// The removed messages are added back to the original translation, so that obsolete messages are kept together.
+func (t *Translations) MarkObsolete(removed []Message) {
+	origIdx := t.OriginalIndex()
+	if len(removed) == 0 || origIdx == -1 {
+		return
+	}
+
+	ids := make([]string, 0, len(removed))
+
+	for _, msg := range removed {
+		ids = append(ids, msg.ID)
+
+		msg.Status = MessageStatusObsolete
+		(*t)[origIdx].Messages = append((*t)[origIdx].Messages, msg)
+	}
+
+	slices.Sort(ids)
+
+	for _, translation := range *t {
+		if translation.Original {
+			continue
+		}
+
+		for i := range translation.Messages {
+			if _, found := slices.BinarySearch(ids, translation.Messages[i].ID); found {
+				translation.Messages[i].Status = MessageStatusObsolete
+			}
+		}
+	}
+}
+
 /*
-PopulateTranslations adds missing messages from the original language to other languages.
+PopulateTranslations adds missing messages from the original language to other languages,
+obsolete messages are not added.
 
 Example:
 
@@ -180,6 +257,10 @@ func (t *Translations) PopulateTranslations() {
 			}
 
 			for j := range (*t)[origIdx].Messages {
+				if (*t)[origIdx].Messages[j].Status == MessageStatusObsolete {
+					continue
+				}
+
 				if _, ok := lookup[(*t)[origIdx].Messages[j].ID]; !ok {
 					newMsg := (*t)[origIdx].Messages[j]
 					newMsg.Status = MessageStatusUntranslated
@@ -196,12 +277,27 @@ func (t *Translations) PopulateTranslations() {
 }
 
 type Message struct {
-	ID          string        `json:"id"`
-	PluralID    string        `json:"pluralId"`
-	Message     string        `json:"message"` // Message contains MessageFormat V2 formatted value
-	Description string        `json:"description"`
-	Positions   Positions     `json:"positions"`
-	Status      MessageStatus `json:"status"`
+	QualityScore *float64      `json:"qualityScore"` // QualityScore is the back-translation score, nil if not scored
+	ID           string        `json:"id"`
+	PluralID     string        `json:"pluralId"`
+	Message      string        `json:"message"` // Message contains MessageFormat V2 formatted value
+	Description  string        `json:"description"`
+	StatusReason string        `json:"statusReason"` // StatusReason explains the status, e.g. why machine translation failed
+	Positions    Positions     `json:"positions"`
+	Status       MessageStatus `json:"status"`
+}
+
+// Equal reports whether the messages are equal, empty and nil positions are equal.
+func (m *Message) Equal(other *Message) bool {
+	return m.ID == other.ID &&
+		m.PluralID == other.PluralID &&
+		m.Message == other.Message &&
+		m.Description == other.Description &&
+		m.Status == other.Status &&
+		m.StatusReason == other.StatusReason &&
+		slices.Equal(m.Positions, other.Positions) &&
+		(m.QualityScore == nil) == (other.QualityScore == nil) &&
+		(m.QualityScore == nil || *m.QualityScore == *other.QualityScore)
 }
 
 type MessageStatus int32
@@ -210,12 +306,15 @@ const (
 	MessageStatusTranslated MessageStatus = iota
 	MessageStatusFuzzy
 	MessageStatusUntranslated
+	// MessageStatusObsolete is the status of a message removed from the original translation.
+	MessageStatusObsolete
 )
 
 const (
 	messageStatusTextTranslated   = "TRANSLATED"
 	messageStatusTextFuzzy        = "FUZZY"
 	messageStatusTextUntranslated = "UNTRANSLATED"
+	messageStatusTextObsolete     = "OBSOLETE"
 )
 
 func (s *MessageStatus) String() string {
@@ -228,6 +327,8 @@ func (s *MessageStatus) String() string {
 		return messageStatusTextFuzzy
 	case MessageStatusUntranslated:
 		return messageStatusTextUntranslated
+	case MessageStatusObsolete:
+		return messageStatusTextObsolete
 	}
 }
 
@@ -241,6 +342,8 @@ func (s *MessageStatus) Scan(value any) error {
 	switch v := value.(type) {
 	default:
 		return fmt.Errorf("unknown type %+v, want string", v)
+	case string:
+		return s.Scan([]byte(v))
 	case []byte:
 		switch string(v) {
 		default:
@@ -251,6 +354,8 @@ func (s *MessageStatus) Scan(value any) error {
 			*s = MessageStatusFuzzy
 		case messageStatusTextUntranslated:
 			*s = MessageStatusUntranslated
+		case messageStatusTextObsolete:
+			*s = MessageStatusObsolete
 		}
 	}
 
@@ -281,6 +386,8 @@ func (p *Positions) Scan(value any) error {
 	case nil:
 		*p = nil
 		return nil
+	case string:
+		return p.Scan([]byte(v))
 	case []byte:
 		err := json.Unmarshal(v, &p)
 		if err != nil {
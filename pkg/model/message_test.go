@@ -1,6 +1,7 @@
 package model
 
 import (
+	"reflect"
 	"slices"
 	"testing"
 )
@@ -207,6 +208,7 @@ func Test_FindChangedMessageIDs(t *testing.T) {
 		Messages: []Message{
 			{ID: "1", Message: "Hello"},
 			{ID: "2", Message: "World"},
+			{ID: "4", Message: "Removed", Status: MessageStatusObsolete},
 		},
 	}
 	current := Translation{
@@ -214,6 +216,7 @@ func Test_FindChangedMessageIDs(t *testing.T) {
 			{ID: "1", Message: "Hello"},
 			{ID: "2", Message: "Go"},
 			{ID: "3", Message: "Testing"},
+			{ID: "4", Message: "Removed"},
 		},
 	}
 
@@ -222,7 +225,123 @@ func Test_FindChangedMessageIDs(t *testing.T) {
 	// ID:1 -> Are the same (Should not be included)
 	// ID:2 -> Messages has been changed (Should be included)
 	// ID:3 -> Is new (Should be included)
-	if !slices.Equal([]string{"2", "3"}, changedIDs) {
-		t.Errorf("want %v, got %v", []string{"2", "3"}, changedIDs)
+	// ID:4 -> Was obsolete (Should be included)
+	if !slices.Equal([]string{"2", "3", "4"}, changedIDs) {
+		t.Errorf("want %v, got %v", []string{"2", "3", "4"}, changedIDs)
+	}
+}
+
+func Test_FindRemovedMessages(t *testing.T) {
+	t.Parallel()
+
+	old := Translation{
+		Messages: []Message{
+			{ID: "1", Message: "Hello"},
+			{ID: "2", Message: "World"},
+		},
+	}
+	current := Translation{
+		Messages: []Message{
+			{ID: "1", Message: "Hello"},
+			{ID: "3", Message: "Testing"},
+		},
+	}
+
+	removed := old.FindRemovedMessages(&current)
+
+	// ID:2 -> Is removed (Should be included)
+	if len(removed) != 1 || removed[0].ID != "2" {
+		t.Errorf("want message '2', got %v", removed)
+	}
+}
+
+func Test_MessageEqual(t *testing.T) {
+	t.Parallel()
+
+	score := 0.5
+
+	tests := []struct {
+		name string
+		a, b Message
+		want bool
+	}{
+		{
+			name: "equal",
+			a:    Message{ID: "1", Message: "Hello", Positions: Positions{"main.go:1"}, QualityScore: &score},
+			b:    Message{ID: "1", Message: "Hello", Positions: Positions{"main.go:1"}, QualityScore: new(0.5)},
+			want: true,
+		},
+		{
+			name: "nil and empty positions",
+			a:    Message{ID: "1", Positions: Positions{}},
+			b:    Message{ID: "1"},
+			want: true,
+		},
+		{
+			name: "different status",
+			a:    Message{ID: "1", Status: MessageStatusFuzzy},
+			b:    Message{ID: "1", Status: MessageStatusTranslated},
+		},
+		{
+			name: "unscored",
+			a:    Message{ID: "1", QualityScore: &score},
+			b:    Message{ID: "1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := test.a.Equal(&test.b); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_DeleteMessages(t *testing.T) {
+	t.Parallel()
+
+	translations := Translations{
+		{Original: true, Messages: []Message{{ID: "1"}, {ID: "2"}}},
+		{Messages: []Message{{ID: "1"}, {ID: "2"}, {ID: "3"}}},
+	}
+
+	translations.DeleteMessages([]string{"2"})
+
+	for _, translation := range translations {
+		if slices.ContainsFunc(translation.Messages, func(m Message) bool { return m.ID == "2" }) {
+			t.Errorf("want message '2' deleted, got %v", translation.Messages)
+		}
+	}
+
+	if len(translations[1].Messages) != 2 {
+		t.Errorf("want 2 messages kept, got %v", translations[1].Messages)
+	}
+}
+
+func Test_MarkObsolete(t *testing.T) {
+	t.Parallel()
+
+	translations := Translations{
+		{Original: true, Messages: []Message{{ID: "1", Status: MessageStatusTranslated}}},
+		{Messages: []Message{{ID: "1", Status: MessageStatusTranslated}, {ID: "2", Status: MessageStatusFuzzy}}},
+	}
+
+	translations.MarkObsolete([]Message{{ID: "2", Message: "World", Status: MessageStatusTranslated}})
+
+	// The removed message is added back to the original translation.
+	want := []Message{
+		{ID: "1", Status: MessageStatusTranslated},
+		{ID: "2", Message: "World", Status: MessageStatusObsolete},
+	}
+	if !reflect.DeepEqual(want, translations[0].Messages) {
+		t.Errorf("want original messages %v, got %v", want, translations[0].Messages)
+	}
+
+	want = []Message{{ID: "1", Status: MessageStatusTranslated}, {ID: "2", Status: MessageStatusObsolete}}
+	if !reflect.DeepEqual(want, translations[1].Messages) {
+		t.Errorf("want messages %v, got %v", want, translations[1].Messages)
 	}
 }
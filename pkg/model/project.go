@@ -0,0 +1,16 @@
+package model
+
+import "github.com/google/uuid"
+
+// DefaultProjectID is the ID of the default project, created with the database.
+// Services created without a project, and services created before projects were introduced, belong to it.
+var DefaultProjectID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// DefaultProjectName is the name of the default project.
+const DefaultProjectName = "Default"
+
+// Project groups the services of a team.
+type Project struct {
+	Name string    `json:"name"`
+	ID   uuid.UUID `json:"id"`
+}
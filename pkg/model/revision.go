@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+// MessageState is the text and status of a message at a revision.
+type MessageState struct {
+	Message string        `json:"message"`
+	Status  MessageStatus `json:"status"`
+}
+
+// MessageRevision records a change of the text or status of a message of a translation.
+type MessageRevision struct {
+	CreatedAt time.Time    `json:"createdAt"`
+	Language  language.Tag `json:"language"`
+	// Old is the message before the change, nil if the change added the message.
+	Old       *MessageState `json:"old"`
+	Actor     string        `json:"actor"` // Actor made the change, empty if unknown.
+	MessageID string        `json:"messageId"`
+	New       MessageState  `json:"new"`
+	ID        uuid.UUID     `json:"id"`
+}
+
+// NewMessageRevisions returns the revisions of the current messages of the translation language
+// added or changed in text or status since the previous messages.
+// Of the current messages with the same ID, the last one is compared, as the last one is stored.
+func NewMessageRevisions(
+	lang language.Tag,
+	previous, current []Message,
+	actor string,
+	createdAt time.Time,
+) []MessageRevision {
+	lookup := make(map[string]MessageState, len(previous))
+	for _, msg := range previous {
+		lookup[msg.ID] = MessageState{Message: msg.Message, Status: msg.Status}
+	}
+
+	last := make(map[string]int, len(current))
+	for i, msg := range current {
+		last[msg.ID] = i
+	}
+
+	var revisions []MessageRevision
+
+	for i, msg := range current {
+		if last[msg.ID] != i {
+			continue
+		}
+
+		state := MessageState{Message: msg.Message, Status: msg.Status}
+
+		old, ok := lookup[msg.ID]
+		if ok && old == state {
+			continue
+		}
+
+		revision := MessageRevision{
+			ID:        uuid.New(),
+			Language:  lang,
+			MessageID: msg.ID,
+			New:       state,
+			Actor:     actor,
+			CreatedAt: createdAt,
+		}
+
+		if ok {
+			revision.Old = &old
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions
+}
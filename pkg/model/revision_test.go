@@ -0,0 +1,61 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+func Test_NewMessageRevisions(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Now()
+
+	previous := []Message{
+		{ID: "1", Message: "Hello", Status: MessageStatusTranslated},
+		{ID: "2", Message: "World", Status: MessageStatusTranslated},
+		{ID: "3", Message: "Removed", Status: MessageStatusTranslated},
+	}
+
+	current := []Message{
+		{ID: "1", Message: "Hello", Status: MessageStatusTranslated, Description: "not revised"},
+		{ID: "2", Message: "World", Status: MessageStatusFuzzy},
+		{ID: "4", Message: "First", Status: MessageStatusUntranslated},
+		{ID: "4", Message: "Added", Status: MessageStatusUntranslated}, // the last one is stored
+	}
+
+	revisions := NewMessageRevisions(language.Latvian, previous, current, "alice", createdAt)
+
+	for i := range revisions {
+		if revisions[i].ID == uuid.Nil {
+			t.Errorf("want revision ID, got nil UUID")
+		}
+
+		revisions[i].ID = uuid.Nil
+	}
+
+	want := []MessageRevision{
+		{
+			Language:  language.Latvian,
+			MessageID: "2",
+			Old:       &MessageState{Message: "World", Status: MessageStatusTranslated},
+			New:       MessageState{Message: "World", Status: MessageStatusFuzzy},
+			Actor:     "alice",
+			CreatedAt: createdAt,
+		},
+		{
+			Language:  language.Latvian,
+			MessageID: "4",
+			New:       MessageState{Message: "Added", Status: MessageStatusUntranslated},
+			Actor:     "alice",
+			CreatedAt: createdAt,
+		},
+	}
+
+	if !reflect.DeepEqual(want, revisions) {
+		t.Errorf("\nwant %+v\ngot  %+v", want, revisions)
+	}
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+// SearchResult is a message found by a message search.
+type SearchResult struct {
+	Language  language.Tag
+	Message   Message
+	ServiceID uuid.UUID
+}
+
+// TextSpan is the byte range [Start, End) of a text.
+type TextSpan struct {
+	Start int
+	End   int
+}
+
+// isWordRune reports whether r is a part of a search word,
+// words are runs of letters, digits, marks and underscores, the same as in MySQL full-text search.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_'
+}
+
+// SearchWords returns the spans of the words of the text.
+func SearchWords(text string) []TextSpan {
+	var (
+		spans []TextSpan
+		start = -1
+	)
+
+	for i, r := range text {
+		switch {
+		case isWordRune(r) && start < 0:
+			start = i
+		case !isWordRune(r) && start >= 0:
+			spans = append(spans, TextSpan{Start: start, End: i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		spans = append(spans, TextSpan{Start: start, End: len(text)})
+	}
+
+	return spans
+}
+
+// SearchTerms returns the distinct lowercase words of the text, in order of appearance.
+func SearchTerms(text string) []string {
+	var terms []string
+
+	for _, span := range SearchWords(text) {
+		term := strings.ToLower(text[span.Start:span.End])
+		if !slices.Contains(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// SearchHighlights returns the spans of the words of the text starting with any of the lowercase terms.
+func SearchHighlights(text string, terms []string) []TextSpan {
+	var spans []TextSpan
+
+	for _, span := range SearchWords(text) {
+		word := strings.ToLower(text[span.Start:span.End])
+
+		if slices.ContainsFunc(terms, func(term string) bool { return strings.HasPrefix(word, term) }) {
+			spans = append(spans, span)
+		}
+	}
+
+	return spans
+}
+
+// MatchSearch reports whether each of the lowercase terms starts a word of the message text or ID.
+func (m *Message) MatchSearch(terms []string) bool {
+	words := append(SearchTerms(m.Message), SearchTerms(m.ID)...)
+
+	for _, term := range terms {
+		if !slices.ContainsFunc(words, func(word string) bool { return strings.HasPrefix(word, term) }) {
+			return false
+		}
+	}
+
+	return len(terms) > 0
+}
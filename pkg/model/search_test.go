@@ -0,0 +1,57 @@
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_SearchTerms(t *testing.T) {
+	t.Parallel()
+
+	got := SearchTerms("Proceed to Checkout, or keep_shopping! Ātri proceed")
+	want := []string{"proceed", "to", "checkout", "or", "keep_shopping", "ātri"}
+
+	if !slices.Equal(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func Test_SearchHighlights(t *testing.T) {
+	t.Parallel()
+
+	got := SearchHighlights("Čeks: checkout and Check-in", []string{"check", "če"})
+	want := []TextSpan{{Start: 0, End: 5}, {Start: 7, End: 15}, {Start: 20, End: 25}}
+
+	if !slices.Equal(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func Test_MatchSearch(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{ID: "cart.checkout_button", Message: "Proceed to payment"}
+
+	tests := []struct {
+		name  string
+		terms []string
+		want  bool
+	}{
+		{name: "Text word prefixes", terms: []string{"proc", "pay"}, want: true},
+		{name: "ID and text words", terms: []string{"cart", "payment"}, want: true},
+		{name: "Word with underscore", terms: []string{"checkout_b"}, want: true},
+		{name: "Not a word prefix", terms: []string{"ceed"}, want: false},
+		{name: "One term missing", terms: []string{"proceed", "shipping"}, want: false},
+		{name: "No terms", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := msg.MatchSearch(test.terms); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -1,8 +1,63 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+// TranslatorNone is the translator name that disables machine translation.
+const TranslatorNone = "None"
 
 type Service struct {
-	Name string    `json:"name"`
-	ID   uuid.UUID `json:"id"`
+	Name              string            `json:"name"`
+	TranslatorRouting TranslatorRouting `json:"translatorRouting"`
+	// MonthlyCharacterQuota limits the characters machine translated per calendar month (UTC), 0 for no quota.
+	MonthlyCharacterQuota int64 `json:"monthlyCharacterQuota"`
+	// Version is incremented on every save, for optimistic concurrency control, 0 if never saved.
+	Version int       `json:"version"`
+	ID      uuid.UUID `json:"id"`
+	// ProjectID is the project of the service, a service without a project is saved to DefaultProjectID.
+	ProjectID uuid.UUID `json:"projectId"`
+}
+
+// TranslatorRouting selects the translator used to machine translate translations of a service.
+type TranslatorRouting struct {
+	// Routes are matched in order, the first route matching the source and target language wins.
+	Routes []TranslatorRoute `json:"routes"`
+}
+
+// TranslatorRoute selects the translator for translations from the source to the target language.
+type TranslatorRoute struct {
+	Source language.Tag `json:"source"` // language.Und matches any source language
+	Target language.Tag `json:"target"` // language.Und matches any target language
+	// Translator is the translator name, e.g. "GoogleTranslate", or TranslatorNone to disable machine translation.
+	Translator string `json:"translator"`
+}
+
+// Translator returns the translator name of the first route matching the source and target language.
+// Returns false if no route matches, the default translator should be used.
+func (t TranslatorRouting) Translator(source, target language.Tag) (string, bool) {
+	for _, route := range t.Routes {
+		if matchLanguage(route.Source, source) && matchLanguage(route.Target, target) {
+			return route.Translator, true
+		}
+	}
+
+	return "", false
+}
+
+// matchLanguage reports whether the route language matches the language,
+// i.e. route language is language.Und, the language itself or one of its parents, e.g. "ja" matches "ja-JP".
+func matchLanguage(route, lang language.Tag) bool {
+	if route == language.Und {
+		return true
+	}
+
+	for ; lang != language.Und; lang = lang.Parent() {
+		if lang == route {
+			return true
+		}
+	}
+
+	return false
 }
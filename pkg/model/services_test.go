@@ -0,0 +1,85 @@
+package model
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func Test_TranslatorRoutingTranslator(t *testing.T) {
+	t.Parallel()
+
+	routing := TranslatorRouting{
+		Routes: []TranslatorRoute{
+			{Source: language.English, Target: language.Japanese, Translator: "GoogleTranslate"},
+			{Source: language.Und, Target: language.Latvian, Translator: TranslatorNone},
+			{Source: language.Und, Target: language.Und, Translator: "AWSTranslate"},
+		},
+	}
+
+	tests := []struct {
+		source, target language.Tag
+		name           string
+		want           string
+		routing        TranslatorRouting
+		wantOK         bool
+	}{
+		{
+			name:    "Exact language pair",
+			routing: routing,
+			source:  language.English,
+			target:  language.Japanese,
+			want:    "GoogleTranslate",
+			wantOK:  true,
+		},
+		{
+			name:    "Parent language matches",
+			routing: routing,
+			source:  language.AmericanEnglish,
+			target:  language.MustParse("ja-JP"),
+			want:    "GoogleTranslate",
+			wantOK:  true,
+		},
+		{
+			name:    "Any source language",
+			routing: routing,
+			source:  language.German,
+			target:  language.Latvian,
+			want:    TranslatorNone,
+			wantOK:  true,
+		},
+		{
+			name:    "Catch-all route",
+			routing: routing,
+			source:  language.German,
+			target:  language.Japanese,
+			want:    "AWSTranslate",
+			wantOK:  true,
+		},
+		{
+			name:    "No routes",
+			routing: TranslatorRouting{},
+			source:  language.English,
+			target:  language.Japanese,
+		},
+		{
+			name: "Child language does not match parent",
+			routing: TranslatorRouting{
+				Routes: []TranslatorRoute{{Source: language.Und, Target: language.MustParse("ja-JP"), Translator: "GoogleTranslate"}},
+			},
+			source: language.English,
+			target: language.Japanese,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := test.routing.Translator(test.source, test.target)
+			if test.want != got || test.wantOK != ok {
+				t.Errorf("want translator '%s' (%t), got '%s' (%t)", test.want, test.wantOK, got, ok)
+			}
+		})
+	}
+}
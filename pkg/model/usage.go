@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Usage is the machine translation usage of a service by a translator on a day.
+type Usage struct {
+	Date       time.Time `json:"date"` // Date is the day in UTC, truncated to midnight.
+	Translator string    `json:"translator"`
+	Characters int64     `json:"characters"` // Characters is the number of characters sent to the translator.
+	Requests   int64     `json:"requests"`   // Requests is the number of requests sent to the translator.
+	ServiceID  uuid.UUID `json:"serviceId"`
+}
+
+// UsageDate returns the UTC day of t, used as the Usage date.
+func UsageDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// MonthStart returns the start of the UTC calendar month of t, monthly quotas are reset at the start of the month.
+func MonthStart(t time.Time) time.Time {
+	year, month, _ := t.UTC().Date()
+
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
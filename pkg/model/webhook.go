@@ -0,0 +1,173 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is an HTTP endpoint notified of the service events.
+type Webhook struct {
+	CreatedAt time.Time `json:"createdAt"`
+	URL       string    `json:"url"`
+	// Secret signs the request body of the deliveries with HMAC-SHA256.
+	Secret    string         `json:"secret"`
+	Events    []WebhookEvent `json:"events"`
+	ID        uuid.UUID      `json:"id"`
+	ServiceID uuid.UUID      `json:"serviceId"`
+}
+
+// Subscribed reports whether the event is delivered to the webhook.
+func (w *Webhook) Subscribed(event WebhookEvent) bool {
+	return slices.Contains(w.Events, event)
+}
+
+// WebhookDelivery is a request sent to a webhook, retried until it succeeds or runs out of attempts.
+type WebhookDelivery struct {
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// NextAttemptAt is the time of the next attempt of a pending delivery.
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	Payload       string    `json:"payload"` // Payload is the JSON body of the request.
+	Error         string    `json:"error"`   // Error is the error of the last attempt, empty if delivered.
+	Attempts      int       `json:"attempts"`
+	// ResponseStatus is the HTTP status code of the last response, 0 if there was no response.
+	ResponseStatus int                   `json:"responseStatus"`
+	ID             uuid.UUID             `json:"id"`
+	WebhookID      uuid.UUID             `json:"webhookId"`
+	ServiceID      uuid.UUID             `json:"serviceId"`
+	Event          WebhookEvent          `json:"event"`
+	Status         WebhookDeliveryStatus `json:"status"`
+}
+
+type WebhookEvent int32
+
+const (
+	// WebhookEventTranslationCompleted is sent when a translation has all messages translated.
+	WebhookEventTranslationCompleted WebhookEvent = iota
+	// WebhookEventOriginalChanged is sent when the original translation changed
+	// and marked messages of other translations untranslated.
+	WebhookEventOriginalChanged
+	// WebhookEventJobFinished is sent when a machine translation job finished, successfully or not.
+	WebhookEventJobFinished
+)
+
+const (
+	webhookEventTextTranslationCompleted = "TRANSLATION_COMPLETED"
+	webhookEventTextOriginalChanged      = "ORIGINAL_CHANGED"
+	webhookEventTextJobFinished          = "JOB_FINISHED"
+)
+
+func (e WebhookEvent) String() string {
+	switch e {
+	default:
+		return ""
+	case WebhookEventTranslationCompleted:
+		return webhookEventTextTranslationCompleted
+	case WebhookEventOriginalChanged:
+		return webhookEventTextOriginalChanged
+	case WebhookEventJobFinished:
+		return webhookEventTextJobFinished
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (e WebhookEvent) MarshalText() ([]byte, error) {
+	s := e.String()
+	if s == "" {
+		return nil, fmt.Errorf("unknown webhook event: %d", e)
+	}
+
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (e *WebhookEvent) UnmarshalText(text []byte) error {
+	switch string(text) {
+	default:
+		return fmt.Errorf("unknown webhook event: %s", text)
+	case webhookEventTextTranslationCompleted:
+		*e = WebhookEventTranslationCompleted
+	case webhookEventTextOriginalChanged:
+		*e = WebhookEventOriginalChanged
+	case webhookEventTextJobFinished:
+		*e = WebhookEventJobFinished
+	}
+
+	return nil
+}
+
+// Value implements driver.Valuer interface.
+func (e *WebhookEvent) Value() (driver.Value, error) {
+	return e.String(), nil
+}
+
+// Scan implements sql.Scanner interface.
+func (e *WebhookEvent) Scan(value any) error {
+	switch v := value.(type) {
+	default:
+		return fmt.Errorf("unknown type %+v, want string", v)
+	case string:
+		return e.UnmarshalText([]byte(v))
+	case []byte:
+		return e.UnmarshalText(v)
+	}
+}
+
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = iota
+	WebhookDeliveryStatusSucceeded
+	WebhookDeliveryStatusFailed
+)
+
+const (
+	webhookDeliveryStatusTextPending   = "PENDING"
+	webhookDeliveryStatusTextSucceeded = "SUCCEEDED"
+	webhookDeliveryStatusTextFailed    = "FAILED"
+)
+
+func (s *WebhookDeliveryStatus) String() string {
+	switch *s {
+	default:
+		return ""
+	case WebhookDeliveryStatusPending:
+		return webhookDeliveryStatusTextPending
+	case WebhookDeliveryStatusSucceeded:
+		return webhookDeliveryStatusTextSucceeded
+	case WebhookDeliveryStatusFailed:
+		return webhookDeliveryStatusTextFailed
+	}
+}
+
+// Value implements driver.Valuer interface.
+func (s *WebhookDeliveryStatus) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+// Scan implements sql.Scanner interface.
+func (s *WebhookDeliveryStatus) Scan(value any) error {
+	switch v := value.(type) {
+	default:
+		return fmt.Errorf("unknown type %+v, want string", v)
+	case string:
+		return s.Scan([]byte(v))
+	case []byte:
+		switch string(v) {
+		default:
+			return fmt.Errorf("unknown webhook delivery status: %+v", v)
+		case webhookDeliveryStatusTextPending:
+			*s = WebhookDeliveryStatusPending
+		case webhookDeliveryStatusTextSucceeded:
+			*s = WebhookDeliveryStatusSucceeded
+		case webhookDeliveryStatusTextFailed:
+			*s = WebhookDeliveryStatusFailed
+		}
+	}
+
+	return nil
+}
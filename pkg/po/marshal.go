@@ -20,15 +20,23 @@ func (p *PO) Marshal() []byte {
 }
 
 func (m *Message) marshal(b *bytes.Buffer) {
-	// writeQuoted function splits a string into multiple lines and wraps each line in double quotes.
-	writeQuoted := func(s string) {
+	// prefix is written before keyword and string lines, obsolete entries are commented out.
+	// previousPrefix is written before the lines of the previous untranslated strings.
+	prefix, previousPrefix := "", "#| "
+	if m.Obsolete {
+		prefix, previousPrefix = "#~ ", "#~| "
+	}
+
+	// writeQuoted function splits a string into multiple lines and wraps each line in double quotes,
+	// the prefix is written before each continuation line.
+	writeQuoted := func(s, prefix string) {
 		b.WriteRune('"')
 
 		for i, r := range s {
 			switch r {
 			case '\n': // end of line
 				if i < len(s)-1 { // not the last character
-					b.WriteString("\"\n\"")
+					b.WriteString("\"\n" + prefix + "\"")
 				}
 
 				continue
@@ -62,26 +70,36 @@ func (m *Message) marshal(b *bytes.Buffer) {
 		fmt.Fprintf(b, "#, %s\n", flag)
 	}
 
+	if m.PreviousMsgID != "" {
+		b.WriteString(previousPrefix + "msgid ")
+		writeQuoted(m.PreviousMsgID, previousPrefix)
+	}
+
+	if m.PreviousMsgIDPlural != "" {
+		b.WriteString(previousPrefix + "msgid_plural ")
+		writeQuoted(m.PreviousMsgIDPlural, previousPrefix)
+	}
+
 	if m.MsgID != "" {
-		b.WriteString("msgid ")
-		writeQuoted(m.MsgID)
+		b.WriteString(prefix + "msgid ")
+		writeQuoted(m.MsgID, prefix)
 	}
 
 	if m.MsgIDPlural != "" {
-		b.WriteString("msgid_plural ")
-		writeQuoted(m.MsgIDPlural)
+		b.WriteString(prefix + "msgid_plural ")
+		writeQuoted(m.MsgIDPlural, prefix)
 	}
 
 	switch len(m.MsgStr) {
 	case 0: // empty
-		b.WriteString("msgstr \"\"\n")
+		b.WriteString(prefix + "msgstr \"\"\n")
 	case 1: // singular
-		b.WriteString("msgstr ")
-		writeQuoted(m.MsgStr[0])
+		b.WriteString(prefix + "msgstr ")
+		writeQuoted(m.MsgStr[0], prefix)
 	default: // plural
 		for i, ms := range m.MsgStr {
-			b.WriteString("msgstr[" + strconv.Itoa(i) + "] ")
-			writeQuoted(ms)
+			b.WriteString(prefix + "msgstr[" + strconv.Itoa(i) + "] ")
+			writeQuoted(ms, prefix)
 		}
 	}
 }
@@ -111,6 +111,68 @@ msgstr[0] ""
 "Ir 1 apelsīns"
 msgstr[1] ""
 "Ir vairāki apelsīni"
+`,
+		},
+		{
+			name: "obsolete",
+			input: PO{
+				Messages: []Message{
+					{
+						MsgID:  "Hello",
+						MsgStr: []string{"Sveiki"},
+					},
+					{
+						MsgID:             "\nThere is 1 orange",
+						MsgIDPlural:       "There is multiple oranges",
+						MsgStr:            []string{"Ir 1 apelsīns", "Ir vairāki apelsīni"},
+						ExtractedComments: []string{"Removed from the source"},
+						Obsolete:          true,
+					},
+				},
+			},
+			want: `msgid "Hello"
+msgstr "Sveiki"
+
+#. Removed from the source
+#~ msgid ""
+#~ "There is 1 orange"
+#~ msgid_plural "There is multiple oranges"
+#~ msgstr[0] "Ir 1 apelsīns"
+#~ msgstr[1] "Ir vairāki apelsīni"
+`,
+		},
+		{
+			name: "previous strings",
+			input: PO{
+				Messages: []Message{
+					{
+						MsgID:         "Hello",
+						MsgStr:        []string{"Sveiki"},
+						Flags:         []string{"fuzzy"},
+						PreviousMsgID: "Hello!",
+					},
+					{
+						MsgID:               "There is 1 orange",
+						MsgIDPlural:         "There is multiple oranges",
+						MsgStr:              []string{"Ir 1 apelsīns", "Ir vairāki apelsīni"},
+						PreviousMsgID:       "\nThere is 1 apple",
+						PreviousMsgIDPlural: "There is multiple apples",
+						Obsolete:            true,
+					},
+				},
+			},
+			want: `#, fuzzy
+#| msgid "Hello!"
+msgid "Hello"
+msgstr "Sveiki"
+
+#~| msgid ""
+#~| "There is 1 apple"
+#~| msgid_plural "There is multiple apples"
+#~ msgid "There is 1 orange"
+#~ msgid_plural "There is multiple oranges"
+#~ msgstr[0] "Ir 1 apelsīns"
+#~ msgstr[1] "Ir vairāki apelsīni"
 `,
 		},
 	}
@@ -104,6 +104,8 @@ const (
 	msgID state = iota
 	msgIDPlural
 	msgStr
+	previousMsgID
+	previousMsgIDPlural
 )
 
 func (p *parser) parseMessage() (Message, error) {
@@ -117,7 +119,33 @@ func (p *parser) parseMessage() (Message, error) {
 	}
 
 	for line := p.next(); line != "" && line != eof; line = p.next() {
+		// Keyword and string lines of obsolete entries are commented out.
+		if after, ok := strings.CutPrefix(line, "#~ "); ok {
+			msg.Obsolete = true
+			line = after
+		}
+
+		// Lines of the previous untranslated strings are commented out, also in obsolete entries.
+		previous := false
+
+		if after, ok := strings.CutPrefix(line, "#~| "); ok {
+			msg.Obsolete = true
+			previous = true
+			line = after
+		} else if after, ok := strings.CutPrefix(line, "#| "); ok {
+			previous = true
+			line = after
+		}
+
 		switch {
+		case previous && strings.HasPrefix(line, `msgid "`):
+			lastState = previousMsgID
+			msg.PreviousMsgID = replaceEscapedQuote(line[7 : len(line)-1])
+		case previous && strings.HasPrefix(line, `msgid_plural "`):
+			lastState = previousMsgIDPlural
+			msg.PreviousMsgIDPlural = replaceEscapedQuote(line[14 : len(line)-1])
+		case previous && !strings.HasPrefix(line, `"`):
+			return Message{}, fmt.Errorf("unexpected previous line: %s", line)
 		case strings.HasPrefix(line, "# "):
 			msg.TranslatorComments = append(msg.TranslatorComments, line[2:])
 		case strings.HasPrefix(line, "#. "):
@@ -155,6 +183,10 @@ func (p *parser) parseMessage() (Message, error) {
 				msg.MsgIDPlural += lineVal
 			case msgStr:
 				msg.MsgStr[len(msg.MsgStr)-1] += lineVal
+			case previousMsgID:
+				msg.PreviousMsgID += lineVal
+			case previousMsgIDPlural:
+				msg.PreviousMsgIDPlural += lineVal
 			}
 		default:
 			return Message{}, fmt.Errorf("unexpected line: %s", line)
@@ -104,7 +104,7 @@ func Test_ParseSuperset(t *testing.T) {
 		if !slices.ContainsFunc(got.Messages, func(message Message) bool {
 			return reflect.DeepEqual(message, want)
 		}) {
-			t.Errorf("want %v to contain %s", got.Messages, want)
+			t.Errorf("want %v to contain %v", got.Messages, want)
 		}
 	}
 }
@@ -57,6 +57,63 @@ msgstr[1] "str2-1"`,
 				},
 			},
 		},
+		{
+			name: "obsolete messages",
+			input: `msgid "id1"
+msgstr "str1"
+
+#. Extracted comment
+#~ msgid "id2"
+#~ msgstr ""
+#~ "str2"`,
+			want: PO{
+				Messages: []Message{
+					{
+						MsgID:  "id1",
+						MsgStr: []string{"str1"},
+					},
+					{
+						MsgID:             "id2",
+						MsgStr:            []string{"str2"},
+						ExtractedComments: []string{"Extracted comment"},
+						Obsolete:          true,
+					},
+				},
+			},
+		},
+		{
+			name: "previous strings",
+			input: `#, fuzzy
+#| msgid "old id1"
+msgid "id1"
+msgstr "str1"
+
+#~| msgid ""
+#~| "old id2"
+#~| msgid_plural "old id2 plural"
+#~ msgid "id2"
+#~ msgid_plural "id2 plural"
+#~ msgstr[0] "str2"
+#~ msgstr[1] "str2-1"`,
+			want: PO{
+				Messages: []Message{
+					{
+						MsgID:         "id1",
+						MsgStr:        []string{"str1"},
+						Flags:         []string{"fuzzy"},
+						PreviousMsgID: "old id1",
+					},
+					{
+						MsgID:               "id2",
+						MsgIDPlural:         "id2 plural",
+						MsgStr:              []string{"str2", "str2-1"},
+						PreviousMsgID:       "old id2",
+						PreviousMsgIDPlural: "old id2 plural",
+						Obsolete:            true,
+					},
+				},
+			},
+		},
 		{
 			name: "full example",
 			input: `# Top-level comment1
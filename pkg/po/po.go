@@ -26,10 +26,19 @@ type Header struct {
 type Message struct {
 	MsgID       string
 	MsgIDPlural string
-	MsgStr      []string
+
+	// PreviousMsgID and PreviousMsgIDPlural are the untranslated strings of the entry before it was changed,
+	// their lines are commented out with "#|", or with "#~|" for obsolete entries.
+	PreviousMsgID       string
+	PreviousMsgIDPlural string
+
+	MsgStr []string
 
 	TranslatorComments []string
 	ExtractedComments  []string
 	References         []string
 	Flags              []string
+
+	// Obsolete is set for obsolete entries, their keyword lines are commented out with "#~".
+	Obsolete bool
 }
@@ -0,0 +1,16 @@
+package repo
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx with the actor recorded in the message revisions, e.g. a user name.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor of ctx set by WithActor, empty if unknown.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/dgraph-io/badger/v4"
 	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
 )
 
 // newDB opens a new Badger database with the given options.
@@ -32,8 +33,12 @@ func newDB(options badger.Options) (*badger.DB, error) {
 	return db, nil
 }
 
-// getValue unmarshals the value of a BadgerDB item into v (either *model.Service or *model.Translation).
-func getValue[T *model.Service | *model.Translation](item *badger.Item, v T) error {
+// getValue unmarshals the value of a BadgerDB item into v (either *model.Project, *model.Service,
+// *model.Translation, *model.Job, *model.Usage, *model.MessageRevision, *model.Webhook or *model.WebhookDelivery).
+func getValue[T *model.Project | *model.Service | *model.Translation | *model.Job | *model.Usage |
+	*model.MessageRevision | *model.Webhook | *model.WebhookDelivery](
+	item *badger.Item, v T,
+) error {
 	return item.Value(func(val []byte) error { //nolint:wrapcheck
 		err := json.Unmarshal(val, &v)
 		if err != nil {
@@ -43,3 +48,84 @@ func getValue[T *model.Service | *model.Translation](item *badger.Item, v T) err
 		return nil
 	})
 }
+
+// deletePrefix deletes all keys with the prefix in the transaction.
+func deletePrefix(txn *badger.Txn, prefix []byte) error {
+	for _, key := range prefixKeys(txn, prefix) {
+		err := txn.Delete(key)
+		if err != nil {
+			return fmt.Errorf("delete key '%s': %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// prefixKeys returns all keys with the prefix in the transaction.
+func prefixKeys(txn *badger.Txn, prefix []byte) [][]byte {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = prefix
+	opts.PrefetchValues = false
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	var keys [][]byte
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		keys = append(keys, it.Item().KeyCopy(nil))
+	}
+
+	return keys
+}
+
+// writeBatches runs the writes in as few transactions as possible. When a transaction grows too big,
+// see badger.ErrTxnTooBig, it is committed and the write is retried in a new transaction,
+// so the writes must be idempotent.
+func writeBatches(db *badger.DB, writes []func(txn *badger.Txn) error) error {
+	txn := db.NewTransaction(true)
+
+	defer func() { txn.Discard() }()
+
+	for _, write := range writes {
+		err := write(txn)
+		if errors.Is(err, badger.ErrTxnTooBig) {
+			err = commit(txn)
+			if err != nil {
+				return err
+			}
+
+			txn = db.NewTransaction(true)
+			err = write(txn)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return commit(txn)
+}
+
+// commit commits the transaction, a conflict is returned as repo.ErrConflict.
+func commit(txn *badger.Txn) error {
+	err := txn.Commit()
+	if errors.Is(err, badger.ErrConflict) {
+		return fmt.Errorf("repo: commit tx: %w: %w", repo.ErrConflict, err)
+	} else if err != nil {
+		return fmt.Errorf("repo: commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// nextVersion returns the version of an entity saved with the version over the stored version,
+// 0 if the entity is new or was stored before versioning.
+// An entity with a non-zero version must have the stored version, see repo.ErrConflict.
+func nextVersion(version, stored int) (int, error) {
+	if version != 0 && stored != 0 && version != stored {
+		return 0, repo.ErrConflict
+	}
+
+	return stored + 1, nil
+}
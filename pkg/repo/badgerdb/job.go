@@ -0,0 +1,286 @@
+package badgerdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+const jobPrefix = "job:"
+
+// jobKey converts a job ID to a BadgerDB key with prefix.
+func jobKey(id uuid.UUID) []byte {
+	return fmt.Appendf(nil, "%s%s", jobPrefix, id)
+}
+
+// SaveJob handles both Create and Update.
+func (r *Repo) SaveJob(ctx context.Context, job *model.Job) error {
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond) // MySQL DATETIME(6) precision
+
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = now
+	}
+
+	job.UpdatedAt = now
+
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		b, err := json.Marshal(job)
+		if err != nil {
+			return fmt.Errorf("marshal job: %w", err)
+		}
+
+		err = r.tx.Set(jobKey(job.ID), b)
+		if err != nil {
+			return fmt.Errorf("repo: set job: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *Repo) LoadJob(_ context.Context, jobID uuid.UUID) (*model.Job, error) {
+	var job model.Job
+
+	err := r.view(func(txn *badger.Txn) error {
+		item, err := txn.Get(jobKey(jobID))
+
+		switch {
+		default:
+			return getValue(item, &job)
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get job: %w", err)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (r *Repo) LoadJobs(_ context.Context, opts repo.LoadJobsOpts) ([]model.Job, error) {
+	var jobs []model.Job
+
+	err := r.view(func(txn *badger.Txn) error {
+		itOpts := badger.DefaultIteratorOptions
+		itOpts.Prefix = []byte(jobPrefix)
+
+		it := txn.NewIterator(itOpts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var job model.Job
+
+			err := getValue(it.Item(), &job)
+			if err != nil {
+				return err
+			}
+
+			if opts.FilterServiceID != uuid.Nil && job.ServiceID != opts.FilterServiceID {
+				continue
+			}
+
+			if len(opts.FilterStatuses) > 0 && !slices.Contains(opts.FilterStatuses, job.Status) {
+				continue
+			}
+
+			jobs = append(jobs, job)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	slices.SortFunc(jobs, func(a, b model.Job) int {
+		return compareJobKeys(repo.JobKey{CreatedAt: a.CreatedAt, ID: a.ID}, repo.JobKey{CreatedAt: b.CreatedAt, ID: b.ID})
+	})
+
+	if opts.After != (repo.JobKey{}) {
+		jobs = slices.DeleteFunc(jobs, func(job model.Job) bool {
+			return compareJobKeys(repo.JobKey{CreatedAt: job.CreatedAt, ID: job.ID}, opts.After) <= 0
+		})
+	}
+
+	if opts.Limit > 0 && len(jobs) > opts.Limit {
+		jobs = jobs[:opts.Limit]
+	}
+
+	return jobs, nil
+}
+
+// compareJobKeys orders jobs by creation time and ID, compared byte-wise.
+func compareJobKeys(a, b repo.JobKey) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+
+	return bytes.Compare(a.ID[:], b.ID[:])
+}
+
+// DeleteJobs deletes the succeeded and failed jobs last updated before the time.
+// Outside a transaction the jobs are deleted in batches, as a job retention might cover many jobs.
+func (r *Repo) DeleteJobs(ctx context.Context, before time.Time) error {
+	if r.tx == nil {
+		var keys [][]byte
+
+		err := r.db.View(func(txn *badger.Txn) error {
+			var txErr error
+
+			keys, txErr = finishedJobKeys(txn, before)
+
+			return txErr
+		})
+		if err != nil {
+			return fmt.Errorf("repo: db view: %w", err)
+		}
+
+		err = writeBatches(r.db, deleteKeysWrites(keys))
+		if err != nil {
+			return fmt.Errorf("repo: delete jobs: %w", err)
+		}
+
+		return nil
+	}
+
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		keys, err := finishedJobKeys(r.tx, before)
+		if err != nil {
+			return err
+		}
+
+		for _, write := range deleteKeysWrites(keys) {
+			err = write(r.tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// finishedJobKeys returns the keys of the finished jobs last updated before the time.
+func finishedJobKeys(txn *badger.Txn, before time.Time) ([][]byte, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = []byte(jobPrefix)
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	var keys [][]byte
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		var job model.Job
+
+		err := getValue(it.Item(), &job)
+		if err != nil {
+			return nil, err
+		}
+
+		if job.Finished() && job.UpdatedAt.Before(before) {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+	}
+
+	return keys, nil
+}
+
+// ClaimJob sets the job running by the owner, the claim is checked and saved in one transaction.
+func (r *Repo) ClaimJob(ctx context.Context, job *model.Job, owner uuid.UUID, leaseUntil time.Time) error {
+	now := time.Now().UTC().Truncate(time.Microsecond) // MySQL DATETIME(6) precision
+	leaseUntil = leaseUntil.UTC().Truncate(time.Microsecond)
+
+	var stored model.Job
+
+	err := r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		item, err := r.tx.Get(jobKey(job.ID))
+
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get job: %w", err)
+		}
+
+		err = getValue(item, &stored)
+		if err != nil {
+			return err
+		}
+
+		claimable := stored.Status == model.JobStatusPending ||
+			stored.Status == model.JobStatusRunning && (stored.Owner == owner || stored.LeaseUntil.Before(now))
+		if !claimable {
+			return repo.ErrConflict
+		}
+
+		stored.Status = model.JobStatusRunning
+		stored.Owner = owner
+		stored.LeaseUntil = leaseUntil
+		stored.UpdatedAt = now
+
+		b, err := json.Marshal(stored)
+		if err != nil {
+			return fmt.Errorf("marshal job: %w", err)
+		}
+
+		err = r.tx.Set(jobKey(job.ID), b)
+		if err != nil {
+			return fmt.Errorf("repo: set job: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	job.Status = stored.Status
+	job.Owner = stored.Owner
+	job.LeaseUntil = stored.LeaseUntil
+	job.UpdatedAt = stored.UpdatedAt
+
+	return nil
+}
+
+// serviceJobKeys returns the keys of the jobs of the service in the transaction.
+func serviceJobKeys(txn *badger.Txn, serviceID uuid.UUID) ([][]byte, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = []byte(jobPrefix)
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	var keys [][]byte
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		var job model.Job
+
+		err := getValue(it.Item(), &job)
+		if err != nil {
+			return nil, err
+		}
+
+		if job.ServiceID == serviceID {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+	}
+
+	return keys, nil
+}
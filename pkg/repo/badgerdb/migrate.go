@@ -0,0 +1,527 @@
+package badgerdb
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/migrate"
+	"go.expect.digital/translate/pkg/model"
+)
+
+// versionKey is the key of the key format version of the database.
+var versionKey = []byte("schema:version")
+
+// upgrades upgrade the key format in place, upgrades[i] upgrades the version i+1 to i+2.
+// Version 1 is the key format of databases created before the version was recorded.
+var upgrades = []func(db *badger.DB) error{
+	upgradeMessageKeys,    // 1 -> 2
+	upgradeProjects,       // 2 -> 3
+	upgradeSearchIndex,    // 3 -> 4
+	upgradeMessageOrder,   // 4 -> 5
+	upgradeWebhookPending, // 5 -> 6
+}
+
+// latestVersion returns the key format version of the repo.
+func latestVersion() int64 {
+	return int64(len(upgrades)) + 1
+}
+
+// Migrator upgrades the key format of the database.
+type Migrator struct {
+	db *badger.DB
+}
+
+// NewMigrator opens the database with the path from global config and returns its migrator.
+func NewMigrator() (*Migrator, error) {
+	db, err := defaultDB()
+	if err != nil {
+		return nil, fmt.Errorf("new badger db: %w", err)
+	}
+
+	return &Migrator{db: db}, nil
+}
+
+// Up upgrades the key format to the latest version, an empty database is initialized, see initDB.
+func (m *Migrator) Up(context.Context) error {
+	version, err := dbVersion(m.db)
+	if err != nil {
+		return err
+	}
+
+	if version == 0 {
+		return initDB(m.db)
+	}
+
+	for ; version < latestVersion(); version++ {
+		err = upgrades[version-1](m.db)
+		if err != nil {
+			return fmt.Errorf("upgrade key format version %d: %w", version, err)
+		}
+
+		err = setVersion(m.db, version+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Down is not supported, the key format can only be upgraded.
+func (m *Migrator) Down(context.Context, int) error {
+	return errors.New("badger db key format cannot be downgraded")
+}
+
+// Force sets the key format version without upgrading the database.
+func (m *Migrator) Force(_ context.Context, version int64) error {
+	return setVersion(m.db, version)
+}
+
+// Status returns the key format version of the database.
+func (m *Migrator) Status(context.Context) (migrate.Status, error) {
+	version, err := dbVersion(m.db)
+	if err != nil {
+		return migrate.Status{}, err
+	}
+
+	return migrate.Status{Version: version, Latest: latestVersion()}, nil
+}
+
+// Close closes the database.
+func (m *Migrator) Close() error {
+	err := m.db.Close()
+	if err != nil {
+		return fmt.Errorf("close badger db: %w", err)
+	}
+
+	return nil
+}
+
+// upgradeMessageKeys moves the messages stored in the translation values to a key per message.
+// Each translation is moved in its own transaction, an interrupted upgrade can be run again.
+func upgradeMessageKeys(db *badger.DB) error {
+	var keys [][]byte
+
+	err := db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(translationPrefix)
+		opts.PrefetchValues = false
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("badger db: list translations: %w", err)
+	}
+
+	for _, key := range keys {
+		err = db.Update(func(txn *badger.Txn) error {
+			return upgradeTranslationMessageKeys(txn, key)
+		})
+		if err != nil {
+			return fmt.Errorf("badger db: upgrade translation '%s': %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// upgradeTranslationMessageKeys moves the messages of the translation key to a key per message.
+func upgradeTranslationMessageKeys(txn *badger.Txn, key []byte) error {
+	// The key is translation:<service ID>:<language>.
+	s, _, _ := strings.Cut(strings.TrimPrefix(string(key), translationPrefix), ":")
+
+	serviceID, err := uuid.Parse(s)
+	if err != nil {
+		return fmt.Errorf("parse service ID: %w", err)
+	}
+
+	item, err := txn.Get(key)
+	if err != nil {
+		return fmt.Errorf("get translation: %w", err)
+	}
+
+	var translation model.Translation
+
+	err = getValue(item, &translation)
+	if err != nil {
+		return fmt.Errorf("get translation value: %w", err)
+	}
+
+	// The translation is already upgraded or has no messages.
+	if len(translation.Messages) == 0 {
+		return nil
+	}
+
+	_, _, err = saveMessages(txn, serviceID, &translation)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(model.Translation{Language: translation.Language, Original: translation.Original})
+	if err != nil {
+		return fmt.Errorf("marshal translation: %w", err)
+	}
+
+	err = txn.Set(key, b)
+	if err != nil {
+		return fmt.Errorf("set translation: %w", err)
+	}
+
+	return nil
+}
+
+// upgradeProjects creates the default project and moves the services without a project to it.
+func upgradeProjects(db *badger.DB) error {
+	err := db.Update(func(txn *badger.Txn) error {
+		inErr := setDefaultProject(txn)
+		if inErr != nil {
+			return inErr
+		}
+
+		// Services are collected first, the iterator must be closed before the services are set.
+		services, inErr := servicesWithoutProject(txn)
+		if inErr != nil {
+			return inErr
+		}
+
+		for _, service := range services {
+			service.ProjectID = model.DefaultProjectID
+
+			var b []byte
+
+			b, inErr = json.Marshal(service)
+			if inErr != nil {
+				return fmt.Errorf("marshal service: %w", inErr)
+			}
+
+			inErr = txn.Set(getServiceKey(service.ID), b)
+			if inErr != nil {
+				return fmt.Errorf("set service: %w", inErr)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("badger db: move services to the default project: %w", err)
+	}
+
+	return nil
+}
+
+// upgradeSearchIndex adds the messages to the search index.
+// Each translation is indexed in its own transaction, an interrupted upgrade can be run again.
+func upgradeSearchIndex(db *badger.DB) error {
+	translations, err := listTranslations(db)
+	if err != nil {
+		return err
+	}
+
+	// The messages of translation:<service ID>:<language> are message:<service ID>:<language>:<ID>.
+	for _, translation := range translations {
+		prefix := []byte(messagePrefix + translation + ":")
+
+		err = db.Update(func(txn *badger.Txn) error {
+			return indexMessages(txn, prefix)
+		})
+		if err != nil {
+			return fmt.Errorf("badger db: index messages '%s': %w", prefix, err)
+		}
+	}
+
+	return nil
+}
+
+// upgradeMessageOrder replaces the positions of the messages with ranks and order keys, see assignRanks.
+// Each translation is upgraded in its own transaction, an interrupted upgrade can be run again.
+func upgradeMessageOrder(db *badger.DB) error {
+	translations, err := listTranslations(db)
+	if err != nil {
+		return err
+	}
+
+	for _, translation := range translations {
+		err = db.Update(func(txn *badger.Txn) error {
+			return upgradeTranslationMessageOrder(txn, translation)
+		})
+		if err != nil {
+			return fmt.Errorf("badger db: upgrade message order of translation '%s': %w", translation, err)
+		}
+	}
+
+	return nil
+}
+
+// upgradeTranslationMessageOrder ranks the messages of the translation <service ID>:<language> by position.
+func upgradeTranslationMessageOrder(txn *badger.Txn, translation string) error {
+	// positionedMessage is a message stored with its position in the translation by key format version 4.
+	type positionedMessage struct {
+		storedMessage
+		Position int `json:"position"`
+	}
+
+	var (
+		keys     [][]byte
+		messages []positionedMessage
+	)
+
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = []byte(messagePrefix + translation + ":")
+
+	it := txn.NewIterator(opts)
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		var msg positionedMessage
+
+		err := it.Item().Value(func(val []byte) error { return json.Unmarshal(val, &msg) }) //nolint:wrapcheck
+		if err != nil {
+			it.Close()
+			return fmt.Errorf("get message: %w", err)
+		}
+
+		keys = append(keys, it.Item().KeyCopy(nil))
+		messages = append(messages, msg)
+	}
+
+	it.Close()
+
+	// The messages are already ranked.
+	if slices.ContainsFunc(messages, func(msg positionedMessage) bool { return msg.Rank != 0 }) {
+		return nil
+	}
+
+	idx := make([]int, len(messages))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	slices.SortStableFunc(idx, func(a, b int) int { return cmp.Compare(messages[a].Position, messages[b].Position) })
+
+	order := []byte(orderPrefix + translation + ":")
+
+	for i, rank := range evenRanks(len(idx)) {
+		msg := messages[idx[i]].storedMessage
+		msg.Rank = rank
+
+		b, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("marshal message: %w", err)
+		}
+
+		err = txn.Set(keys[idx[i]], b)
+		if err != nil {
+			return fmt.Errorf("set message '%s': %w", msg.ID, err)
+		}
+
+		err = txn.Set(rankKey(order, rank), []byte(msg.ID))
+		if err != nil {
+			return fmt.Errorf("set order of message '%s': %w", msg.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// upgradeWebhookPending indexes the pending webhook deliveries, see webhookPendingKey.
+// The index keys are set in batches, an interrupted upgrade can be run again.
+func upgradeWebhookPending(db *badger.DB) error {
+	var writes []func(txn *badger.Txn) error
+
+	err := db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(webhookDeliveryPrefix)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var delivery model.WebhookDelivery
+
+			err := getValue(it.Item(), &delivery)
+			if err != nil {
+				return fmt.Errorf("get webhook delivery: %w", err)
+			}
+
+			if delivery.Status != model.WebhookDeliveryStatusPending {
+				continue
+			}
+
+			writes = append(writes, func(txn *badger.Txn) error {
+				return txn.Set(webhookPendingKey(&delivery), nil) //nolint:wrapcheck
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("badger db: list pending webhook deliveries: %w", err)
+	}
+
+	err = writeBatches(db, writes)
+	if err != nil {
+		return fmt.Errorf("badger db: index pending webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// listTranslations returns the translations as <service ID>:<language>, the keys without the translation prefix.
+func listTranslations(db *badger.DB) ([]string, error) {
+	var translations []string
+
+	err := db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(translationPrefix)
+		opts.PrefetchValues = false
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			translations = append(translations, strings.TrimPrefix(string(it.Item().Key()), translationPrefix))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("badger db: list translations: %w", err)
+	}
+
+	return translations, nil
+}
+
+// servicesWithoutProject returns the services saved before projects were introduced.
+func servicesWithoutProject(txn *badger.Txn) ([]model.Service, error) {
+	var services []model.Service
+
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = []byte(servicePrefix)
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		var service model.Service
+
+		err := getValue(it.Item(), &service)
+		if err != nil {
+			return nil, fmt.Errorf("get service value: %w", err)
+		}
+
+		if service.ProjectID == uuid.Nil {
+			services = append(services, service)
+		}
+	}
+
+	return services, nil
+}
+
+// setDefaultProject sets the default project in the transaction.
+func setDefaultProject(txn *badger.Txn) error {
+	return setProject(txn, &model.Project{ID: model.DefaultProjectID, Name: model.DefaultProjectName})
+}
+
+// initDB initializes an empty database with the latest key format version and the default project.
+func initDB(db *badger.DB) error {
+	err := db.Update(func(txn *badger.Txn) error {
+		inErr := setDefaultProject(txn)
+		if inErr != nil {
+			return inErr
+		}
+
+		return txn.Set(versionKey, []byte(strconv.FormatInt(latestVersion(), 10))) //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("badger db: init: %w", err)
+	}
+
+	return nil
+}
+
+// checkVersion checks that the database has the latest key format version,
+// an empty database is initialized, see initDB.
+func checkVersion(db *badger.DB) error {
+	version, err := dbVersion(db)
+	if err != nil {
+		return err
+	}
+
+	switch latest := latestVersion(); {
+	case version == 0:
+		return initDB(db)
+	case version < latest:
+		return fmt.Errorf("key format version %d is outdated, upgrade it to %d with 'translate migrate up'", version, latest)
+	case version > latest:
+		return fmt.Errorf("key format version %d is newer than the supported version %d", version, latest)
+	}
+
+	return nil
+}
+
+// dbVersion returns the key format version of the database,
+// 0 if the database is empty and 1 if the version was not recorded yet.
+func dbVersion(db *badger.DB) (int64, error) {
+	var version int64
+
+	err := db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(versionKey)
+
+		switch {
+		default:
+			return item.Value(func(val []byte) error { //nolint:wrapcheck
+				version, err = strconv.ParseInt(string(val), 10, 64)
+				if err != nil {
+					return fmt.Errorf("parse key format version: %w", err)
+				}
+
+				return nil
+			})
+		case errors.Is(err, badger.ErrKeyNotFound):
+			// The version is not recorded, check if the database is empty.
+			opts := badger.DefaultIteratorOptions
+			opts.PrefetchValues = false
+
+			it := txn.NewIterator(opts)
+			defer it.Close()
+
+			if it.Rewind(); it.Valid() {
+				version = 1
+			}
+
+			return nil
+		case err != nil:
+			return fmt.Errorf("get key format version: %w", err)
+		}
+	})
+	if err != nil {
+		return 0, fmt.Errorf("badger db: %w", err)
+	}
+
+	return version, nil
+}
+
+func setVersion(db *badger.DB, version int64) error {
+	err := db.Update(func(txn *badger.Txn) error {
+		return txn.Set(versionKey, []byte(strconv.FormatInt(version, 10))) //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("badger db: set key format version: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,381 @@
+package badgerdb
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+)
+
+func Test_upgradeMessageKeys(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	db, err := newDB(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer db.Close()
+
+	service := rand.ModelService()
+	want := rand.ModelTranslation(5, nil, rand.WithLanguage(language.Latvian), rand.WithOriginal(true))
+
+	// Key format version 1 stores the translation with its messages in a single value.
+	err = db.Update(func(txn *badger.Txn) error {
+		for key, v := range map[string]any{
+			string(getServiceKey(service.ID)):                 service,
+			string(translationKey(service.ID, want.Language)): want,
+		} {
+			b, inErr := json.Marshal(v)
+			if inErr != nil {
+				return inErr //nolint:wrapcheck
+			}
+
+			inErr = txn.Set([]byte(key), b)
+			if inErr != nil {
+				return inErr //nolint:wrapcheck
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	m := &Migrator{db: db}
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if status.Version != 1 {
+		t.Errorf("want version 1, got %d", status.Version)
+	}
+
+	err = m.Up(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// The upgrade can be run again, e.g. after it was interrupted.
+	err = upgradeMessageKeys(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = checkVersion(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	r := &Repo{db: db}
+
+	// The service is moved to the default project.
+	gotService, err := r.LoadService(ctx, service.ID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if gotService.ProjectID != model.DefaultProjectID {
+		t.Errorf("want project %s, got %s", model.DefaultProjectID, gotService.ProjectID)
+	}
+
+	_, err = r.LoadProject(ctx, model.DefaultProjectID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(model.Translations{*want}, got) {
+		t.Errorf("\nwant %v\ngot  %v", *want, got)
+	}
+
+	// Messages are stored under their own keys.
+	got, err = r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{
+		FilterMessageIDs: []string{want.Messages[2].ID},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(got) != 1 || !reflect.DeepEqual(want.Messages[2:3], got[0].Messages) {
+		t.Errorf("want message %v, got %v", want.Messages[2], got)
+	}
+}
+
+func Test_upgradeSearchIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	db, err := newDB(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer db.Close()
+
+	r := &Repo{db: db}
+	service := rand.ModelService()
+	translation := &model.Translation{
+		Language: language.Latvian,
+		Messages: []model.Message{{ID: "checkout", Message: "Apmaksāt pirkumu"}},
+	}
+
+	err = initDB(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = r.SaveTranslation(ctx, service.ID, translation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Key format version 3 has no search index.
+	err = db.Update(func(txn *badger.Txn) error {
+		inErr := deletePrefix(txn, []byte(searchPrefix))
+		if inErr != nil {
+			return inErr
+		}
+
+		return txn.Set(versionKey, []byte("3")) //nolint:wrapcheck
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = (&Migrator{db: db}).Up(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	results, err := r.SearchMessages(ctx, repo.SearchMessagesOpts{Query: "pirk"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(results) != 1 || results[0].Message.ID != "checkout" || results[0].ServiceID != service.ID {
+		t.Errorf("want message 'checkout', got %v", results)
+	}
+}
+
+func Test_upgradeMessageOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	db, err := newDB(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer db.Close()
+
+	r := &Repo{db: db}
+	service := rand.ModelService()
+	want := rand.ModelTranslation(5, nil, rand.WithLanguage(language.Latvian))
+
+	err = initDB(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Key format version 4 stores the position of the message in the translation, without order keys.
+	err = db.Update(func(txn *badger.Txn) error {
+		b, inErr := json.Marshal(model.Translation{Language: want.Language, Version: 1})
+		if inErr != nil {
+			return inErr //nolint:wrapcheck
+		}
+
+		inErr = txn.Set(translationKey(service.ID, want.Language), b)
+		if inErr != nil {
+			return inErr //nolint:wrapcheck
+		}
+
+		for i, msg := range want.Messages {
+			b, inErr = json.Marshal(struct {
+				model.Message
+				Position int `json:"position"`
+			}{Message: msg, Position: i})
+			if inErr != nil {
+				return inErr //nolint:wrapcheck
+			}
+
+			inErr = txn.Set(messageKey(service.ID, want.Language, msg.ID), b)
+			if inErr != nil {
+				return inErr //nolint:wrapcheck
+			}
+		}
+
+		return txn.Set(versionKey, []byte("4")) //nolint:wrapcheck
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = (&Migrator{db: db}).Up(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// The upgrade can be run again, e.g. after it was interrupted.
+	err = upgradeMessageOrder(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want.Version = 1
+
+	if !reflect.DeepEqual(model.Translations{*want}, got) {
+		t.Errorf("\nwant %v\ngot  %v", *want, got)
+	}
+
+	err = db.View(func(txn *badger.Txn) error {
+		order, inErr := loadOrder(txn, service.ID, want.Language)
+		if inErr != nil {
+			return inErr
+		}
+
+		if len(order) != len(want.Messages) {
+			t.Errorf("want %d order keys, got %d", len(want.Messages), len(order))
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_upgradeWebhookPending(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	db, err := newDB(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer db.Close()
+
+	r := &Repo{db: db}
+
+	err = initDB(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	pending := model.WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: uuid.New(),
+		ServiceID: uuid.New(),
+		Status:    model.WebhookDeliveryStatusPending,
+	}
+
+	succeeded := pending
+	succeeded.ID = uuid.New()
+	succeeded.Status = model.WebhookDeliveryStatusSucceeded
+
+	// Key format version 5 stores the deliveries without the pending index.
+	err = db.Update(func(txn *badger.Txn) error {
+		for _, delivery := range []model.WebhookDelivery{pending, succeeded} {
+			b, inErr := json.Marshal(delivery)
+			if inErr != nil {
+				return inErr //nolint:wrapcheck
+			}
+
+			inErr = txn.Set(webhookDeliveryKey(&delivery), b)
+			if inErr != nil {
+				return inErr //nolint:wrapcheck
+			}
+		}
+
+		return txn.Set(versionKey, []byte("5")) //nolint:wrapcheck
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = (&Migrator{db: db}).Up(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// The upgrade can be run again, e.g. after it was interrupted.
+	err = upgradeWebhookPending(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := r.LoadWebhookDeliveries(ctx, repo.LoadWebhookDeliveriesOpts{
+		FilterStatuses: []model.WebhookDeliveryStatus{model.WebhookDeliveryStatusPending},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual([]model.WebhookDelivery{pending}, got) {
+		t.Errorf("\nwant %v\ngot  %v", []model.WebhookDelivery{pending}, got)
+	}
+}
@@ -0,0 +1,202 @@
+package badgerdb
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+const orderPrefix = "order:"
+
+// rankGap is the gap between the ranks of consecutive messages assigned together,
+// messages inserted between them get ranks in the gap without changing the ranks of the other messages.
+const rankGap = 1 << 32
+
+// orderKeyPrefix returns the key prefix of the message order of a service translation.
+func orderKeyPrefix(serviceID uuid.UUID, language language.Tag) []byte {
+	return fmt.Appendf(nil, "%s%s:%s:", orderPrefix, serviceID, language)
+}
+
+// rankKey returns the order key of the rank with the order key prefix, keys are ordered by rank.
+// The value is the ID of the message with the rank.
+func rankKey(prefix []byte, rank uint64) []byte {
+	return fmt.Appendf(prefix, "%016x", rank)
+}
+
+// parseRankKey returns the rank of the order key with the order key prefix.
+func parseRankKey(prefix, key []byte) (uint64, error) {
+	rank, err := strconv.ParseUint(string(key[len(prefix):]), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse order key '%s': %w", key, err)
+	}
+
+	return rank, nil
+}
+
+// rankedMessage is the ID and the rank of a stored message.
+type rankedMessage struct {
+	id   string
+	rank uint64
+}
+
+// loadOrder returns the IDs and ranks of the messages of the service translation ordered by rank,
+// only the order keys are read.
+func loadOrder(txn *badger.Txn, serviceID uuid.UUID, language language.Tag) ([]rankedMessage, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = orderKeyPrefix(serviceID, language)
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	var order []rankedMessage
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		rank, err := parseRankKey(opts.Prefix, it.Item().Key())
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := it.Item().ValueCopy(nil)
+		if err != nil {
+			return nil, fmt.Errorf("get order key '%s': %w", it.Item().Key(), err)
+		}
+
+		order = append(order, rankedMessage{id: string(id), rank: rank})
+	}
+
+	return order, nil
+}
+
+// lastRank returns the highest rank of the messages of the service translation, 0 if there are no messages.
+func lastRank(txn *badger.Txn, serviceID uuid.UUID, language language.Tag) (uint64, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = orderKeyPrefix(serviceID, language)
+	opts.Reverse = true
+	opts.PrefetchValues = false
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	// Reverse iteration starts at the last key up to the seek key.
+	if it.Seek(append(orderKeyPrefix(serviceID, language), 0xff)); !it.Valid() {
+		return 0, nil
+	}
+
+	return parseRankKey(opts.Prefix, it.Item().Key())
+}
+
+// assignRanks returns the ranks of the messages with the IDs in the order of the IDs, the IDs are unique.
+// The stored messages of the longest subsequence ordered as stored keep their ranks, so that saving a translation
+// with added, moved or removed messages does not change the ranks of the other messages.
+// The other messages get evenly spaced ranks between the kept ranks, all messages are ranked again
+// only if there is no gap for them.
+func assignRanks(stored []rankedMessage, ids []string) []uint64 {
+	storedRanks := make(map[string]uint64, len(stored))
+	for _, msg := range stored {
+		storedRanks[msg.id] = msg.rank
+	}
+
+	ranks := make([]uint64, len(ids))
+	for i, id := range ids {
+		ranks[i] = storedRanks[id] // 0 for a new message
+	}
+
+	keep := longestIncreasing(ranks)
+
+	for i := 0; i < len(ids); {
+		if keep[i] {
+			i++
+			continue
+		}
+
+		// Messages i to j-1 get ranks between the kept ranks before and after them.
+		j := i + 1
+		for j < len(ids) && !keep[j] {
+			j++
+		}
+
+		var low uint64
+		if i > 0 {
+			low = ranks[i-1]
+		}
+
+		n := uint64(j - i) //nolint:gosec
+
+		var step uint64
+
+		switch {
+		case j < len(ids):
+			step = (ranks[j] - low) / (n + 1)
+		case n <= (math.MaxUint64-low)/rankGap:
+			step = rankGap
+		}
+
+		if step == 0 {
+			return evenRanks(len(ids))
+		}
+
+		for k := range n {
+			ranks[i+int(k)] = low + step*(k+1) //nolint:gosec
+		}
+
+		i = j
+	}
+
+	return ranks
+}
+
+// evenRanks returns n ranks rankGap apart.
+func evenRanks(n int) []uint64 {
+	ranks := make([]uint64, n)
+	for i := range ranks {
+		ranks[i] = rankGap * uint64(i+1) //nolint:gosec
+	}
+
+	return ranks
+}
+
+// longestIncreasing reports for each value if it is in the longest strictly increasing subsequence of the values,
+// zero values are skipped.
+func longestIncreasing(values []uint64) []bool {
+	var (
+		// tails[k] is the index of the smallest last value of the increasing subsequences of length k+1.
+		tails []int
+		prev  = make([]int, len(values)) // index of the previous value in the subsequence, -1 for the first
+	)
+
+	for i, v := range values {
+		if v == 0 {
+			continue
+		}
+
+		k := sort.Search(len(tails), func(k int) bool { return values[tails[k]] >= v })
+
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	in := make([]bool, len(values))
+
+	if len(tails) == 0 {
+		return in
+	}
+
+	for i := tails[len(tails)-1]; i != -1; i = prev[i] {
+		in[i] = true
+	}
+
+	return in
+}
@@ -0,0 +1,160 @@
+package badgerdb
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"golang.org/x/text/language"
+)
+
+func Test_assignRanks(t *testing.T) {
+	t.Parallel()
+
+	stored := []rankedMessage{{id: "a", rank: rankGap}, {id: "b", rank: 2 * rankGap}, {id: "c", rank: 3 * rankGap}}
+
+	tests := []struct {
+		name   string
+		stored []rankedMessage
+		ids    []string
+		want   []uint64
+	}{
+		{
+			name: "new translation",
+			ids:  []string{"b", "a"},
+			want: []uint64{rankGap, 2 * rankGap},
+		},
+		{
+			name:   "unchanged",
+			stored: stored,
+			ids:    []string{"a", "b", "c"},
+			want:   []uint64{rankGap, 2 * rankGap, 3 * rankGap},
+		},
+		{
+			name:   "inserted first and last",
+			stored: stored,
+			ids:    []string{"x", "a", "b", "c", "y"},
+			want:   []uint64{rankGap / 2, rankGap, 2 * rankGap, 3 * rankGap, 4 * rankGap},
+		},
+		{
+			name:   "inserted between",
+			stored: stored,
+			ids:    []string{"a", "x", "y", "b", "c"},
+			want:   []uint64{rankGap, rankGap + rankGap/3, rankGap + 2*(rankGap/3), 2 * rankGap, 3 * rankGap},
+		},
+		{
+			name:   "moved and removed",
+			stored: stored,
+			ids:    []string{"c", "a"},
+			want:   []uint64{rankGap / 2, rankGap},
+		},
+		{
+			name:   "no gap",
+			stored: []rankedMessage{{id: "a", rank: 1}, {id: "b", rank: 2}},
+			ids:    []string{"a", "x", "b"},
+			want:   []uint64{rankGap, 2 * rankGap, 3 * rankGap},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := assignRanks(test.stored, test.ids)
+
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want ranks %v, got %v", test.want, got)
+			}
+
+			if !slices.IsSorted(got) {
+				t.Errorf("want ascending ranks, got %v", got)
+			}
+		})
+	}
+}
+
+func Test_saveMessagesReranked(t *testing.T) {
+	t.Parallel()
+
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer db.Close()
+
+	serviceID := uuid.New()
+	translation := &model.Translation{
+		Language: language.Latvian,
+		Messages: []model.Message{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+	}
+
+	// No gap between the stored ranks of "a" and "b", all messages are ranked again when a message is inserted
+	// between them, and the inserted message gets the previous rank of "c".
+	err = db.Update(func(txn *badger.Txn) error {
+		prefix := orderKeyPrefix(serviceID, translation.Language)
+
+		for i, msg := range translation.Messages {
+			rank := []uint64{rankGap, rankGap + 1, 2 * rankGap}[i]
+
+			b, inErr := json.Marshal(storedMessage{Message: msg, Rank: rank})
+			if inErr != nil {
+				return inErr //nolint:wrapcheck
+			}
+
+			inErr = txn.Set(messageKey(serviceID, translation.Language, msg.ID), b)
+			if inErr != nil {
+				return inErr //nolint:wrapcheck
+			}
+
+			inErr = txn.Set(rankKey(prefix, rank), []byte(msg.ID))
+			if inErr != nil {
+				return inErr //nolint:wrapcheck
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	translation.Messages = []model.Message{{ID: "a"}, {ID: "n"}, {ID: "b"}, {ID: "c"}}
+
+	err = db.Update(func(txn *badger.Txn) error {
+		_, _, inErr := saveMessages(txn, serviceID, translation)
+		return inErr
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var got []rankedMessage
+
+	err = db.View(func(txn *badger.Txn) error {
+		var inErr error
+
+		got, inErr = loadOrder(txn, serviceID, translation.Language)
+
+		return inErr
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []rankedMessage{
+		{id: "a", rank: rankGap}, {id: "n", rank: 2 * rankGap}, {id: "b", rank: 3 * rankGap}, {id: "c", rank: 4 * rankGap},
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want order %v, got %v", want, got)
+	}
+}
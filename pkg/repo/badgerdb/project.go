@@ -0,0 +1,121 @@
+package badgerdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+const projectPrefix = "project:"
+
+// projectKey converts a project ID to a BadgerDB key with prefix.
+func projectKey(id uuid.UUID) []byte {
+	return fmt.Appendf(nil, "%s%s", projectPrefix, id)
+}
+
+func (r *Repo) SaveProject(ctx context.Context, project *model.Project) error {
+	if project.ID == uuid.Nil {
+		project.ID = uuid.New()
+	}
+
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		return setProject(r.tx, project)
+	})
+}
+
+// setProject sets the project in the transaction.
+func setProject(txn *badger.Txn, project *model.Project) error {
+	val, err := json.Marshal(project)
+	if err != nil {
+		return fmt.Errorf("marshal project: %w", err)
+	}
+
+	err = txn.Set(projectKey(project.ID), val)
+	if err != nil {
+		return fmt.Errorf("repo: set project: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) LoadProject(_ context.Context, projectID uuid.UUID) (*model.Project, error) {
+	var project model.Project
+
+	err := r.view(func(txn *badger.Txn) error {
+		item, err := txn.Get(projectKey(projectID))
+
+		switch {
+		default:
+			return getValue(item, &project)
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get project: %w", err)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	return &project, nil
+}
+
+func (r *Repo) LoadProjects(_ context.Context) ([]model.Project, error) {
+	var projects []model.Project
+
+	err := r.view(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(projectPrefix)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var project model.Project
+
+			err := getValue(it.Item(), &project)
+			if err != nil {
+				return err
+			}
+
+			projects = append(projects, project)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	return projects, nil
+}
+
+// DeleteProject deletes the project, the services of the project are not checked.
+func (r *Repo) DeleteProject(ctx context.Context, projectID uuid.UUID) error {
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		key := projectKey(projectID)
+
+		// BadgerDB does not return an error if the key does not exist on Delete.
+		_, err := r.tx.Get(key)
+
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get project for deletion: %w", err)
+		}
+
+		err = r.tx.Delete(key)
+		if err != nil {
+			return fmt.Errorf("transaction: delete project: %w", err)
+		}
+
+		return nil
+	})
+}
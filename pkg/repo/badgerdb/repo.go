@@ -31,6 +31,14 @@ func NewRepo(opts ...Option) (*Repo, error) {
 		}
 	}
 
+	if r.db != nil {
+		err := checkVersion(r.db)
+		if err != nil {
+			r.db.Close() //nolint:errcheck
+			return nil, fmt.Errorf("check key format version: %w", err)
+		}
+	}
+
 	return r, nil
 }
 
@@ -50,21 +58,9 @@ type Option func(*Repo) error
 // If path is not provided defaults to in-memory storage.
 func WithDefaultDB() Option {
 	return func(r *Repo) error {
-		path := viper.GetString("db.badgerdb.path")
-		badgerOpts := badger.DefaultOptions(path)
-
-		// NOTE: The default value for in-memory storage of ValueThreshold is 1 MB.
-		// Currently increasing the maximum allowed value size using WithValueThreshold() results in
-		// panic 'Invalid ValueThreshold, must be less or equal to 1048576'.
-		if path == "" {
-			log.Println("INFO: badger db path not provided: defaulting to in-memory storage")
-
-			badgerOpts = badgerOpts.WithInMemory(true)
-		}
-
 		var err error
 
-		r.db, err = newDB(badgerOpts)
+		r.db, err = defaultDB()
 		if err != nil {
 			return fmt.Errorf("WithDefaultDB: new badger db: %w", err)
 		}
@@ -73,6 +69,24 @@ func WithDefaultDB() Option {
 	}
 }
 
+// defaultDB opens a new Badger database with the path from global config,
+// in-memory if the path is not provided.
+func defaultDB() (*badger.DB, error) {
+	path := viper.GetString("db.badgerdb.path")
+	badgerOpts := badger.DefaultOptions(path)
+
+	// NOTE: The default value for in-memory storage of ValueThreshold is 1 MB.
+	// Currently increasing the maximum allowed value size using WithValueThreshold() results in
+	// panic 'Invalid ValueThreshold, must be less or equal to 1048576'.
+	if path == "" {
+		log.Println("INFO: badger db path not provided: defaulting to in-memory storage")
+
+		badgerOpts = badgerOpts.WithInMemory(true)
+	}
+
+	return newDB(badgerOpts)
+}
+
 func (r *Repo) Tx(ctx context.Context, fn func(context.Context, repo.Repo) error) (err error) {
 	if r.tx != nil {
 		return errors.New("repo: tx already exists")
@@ -95,12 +109,7 @@ func (r *Repo) Tx(ctx context.Context, fn func(context.Context, repo.Repo) error
 		return fmt.Errorf("repo: execute tx: %w", err)
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return fmt.Errorf("repo: commit tx: %w", err)
-	}
-
-	return nil
+	return commit(tx)
 }
 
 // ensureTx checks for existing db transaction - if present uses existing, otherwise starts a new tx.
@@ -126,10 +135,15 @@ func (r *Repo) ensureTx(ctx context.Context, fn func(context.Context, *Repo) err
 		return fmt.Errorf("repo: execute tx: %w", err)
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return fmt.Errorf("repo: commit tx: %w", err)
+	return commit(tx)
+}
+
+// view runs fn in the existing transaction if present, so that reads in a transaction
+// see a consistent snapshot together with its writes, otherwise in a new read-only transaction.
+func (r *Repo) view(fn func(txn *badger.Txn) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
 	}
 
-	return nil
+	return r.db.View(fn) //nolint:wrapcheck
 }
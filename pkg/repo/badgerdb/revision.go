@@ -0,0 +1,239 @@
+package badgerdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+)
+
+const revisionPrefix = "revision:"
+
+// revisionKeyPrefix returns the key prefix of the message revisions of a service translation.
+func revisionKeyPrefix(serviceID uuid.UUID, language language.Tag) []byte {
+	return fmt.Appendf(nil, "%s%s:%s:", revisionPrefix, serviceID, language)
+}
+
+// messageRevisionKeyPrefix returns the key prefix of the revisions of a message,
+// the message ID is terminated by a zero byte, so that it is not a prefix of longer message IDs.
+func messageRevisionKeyPrefix(serviceID uuid.UUID, language language.Tag, messageID string) []byte {
+	return append(append(revisionKeyPrefix(serviceID, language), messageID...), 0)
+}
+
+// revisionKey converts a revision of a service message to a BadgerDB key with prefix,
+// revisions of a message are ordered by creation time.
+func revisionKey(serviceID uuid.UUID, revision *model.MessageRevision) []byte {
+	return fmt.Appendf(messageRevisionKeyPrefix(serviceID, revision.Language, revision.MessageID),
+		"%020d:%s", revision.CreatedAt.UnixNano(), revision.ID)
+}
+
+// saveMessageRevisions writes the message revisions of the service.
+func saveMessageRevisions(txn *badger.Txn, serviceID uuid.UUID, revisions []model.MessageRevision) error {
+	for i := range revisions {
+		b, err := json.Marshal(revisions[i])
+		if err != nil {
+			return fmt.Errorf("marshal message revision: %w", err)
+		}
+
+		err = txn.Set(revisionKey(serviceID, &revisions[i]), b)
+		if err != nil {
+			return fmt.Errorf("set message revision '%s': %w", revisions[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+// LoadMessageRevisions returns the revisions of the message of the service translation, newest first.
+func (r *Repo) LoadMessageRevisions(ctx context.Context, serviceID uuid.UUID, language language.Tag,
+	messageID string,
+) ([]model.MessageRevision, error) {
+	_, err := r.LoadService(ctx, serviceID)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("repo: load service: %w", err)
+	}
+
+	var revisions []model.MessageRevision
+
+	err = r.view(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = messageRevisionKeyPrefix(serviceID, language, messageID)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var revision model.MessageRevision
+
+			inErr := getValue(it.Item(), &revision)
+			if inErr != nil {
+				return fmt.Errorf("get message revision: %w", inErr)
+			}
+
+			revisions = append(revisions, revision)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	slices.Reverse(revisions)
+
+	return revisions, nil
+}
+
+// LoadTranslationRevisions returns the message revisions of the service translation, oldest first.
+func (r *Repo) LoadTranslationRevisions(_ context.Context, serviceID uuid.UUID, language language.Tag,
+) ([]model.MessageRevision, error) {
+	var revisions []model.MessageRevision
+
+	err := r.view(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = revisionKeyPrefix(serviceID, language)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var revision model.MessageRevision
+
+			inErr := getValue(it.Item(), &revision)
+			if inErr != nil {
+				return fmt.Errorf("get message revision: %w", inErr)
+			}
+
+			revisions = append(revisions, revision)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	// Keys are ordered by message ID first.
+	slices.SortStableFunc(revisions, func(a, b model.MessageRevision) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return revisions, nil
+}
+
+// ReplaceTranslationRevisions replaces the message revisions of the service translation.
+func (r *Repo) ReplaceTranslationRevisions(ctx context.Context, serviceID uuid.UUID, language language.Tag,
+	revisions []model.MessageRevision,
+) error {
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		_, err := r.tx.Get(translationKey(serviceID, language))
+
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get translation: %w", err)
+		}
+
+		err = deletePrefix(r.tx, revisionKeyPrefix(serviceID, language))
+		if err != nil {
+			return fmt.Errorf("transaction: delete translation message revisions: %w", err)
+		}
+
+		err = saveMessageRevisions(r.tx, serviceID, revisions)
+		if err != nil {
+			return fmt.Errorf("transaction: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteMessageRevisions deletes the message revisions created before the time.
+// Outside of a transaction, the revisions are deleted in batches, see DeleteService.
+func (r *Repo) DeleteMessageRevisions(ctx context.Context, before time.Time) error {
+	if r.tx == nil {
+		var writes []func(txn *badger.Txn) error
+
+		err := r.db.View(func(txn *badger.Txn) error {
+			var txErr error
+
+			writes, txErr = deleteMessageRevisionsWrites(txn, before)
+
+			return txErr
+		})
+		if err != nil {
+			return fmt.Errorf("repo: db view: %w", err)
+		}
+
+		err = writeBatches(r.db, writes)
+		if err != nil {
+			return fmt.Errorf("repo: delete message revisions: %w", err)
+		}
+
+		return nil
+	}
+
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		writes, err := deleteMessageRevisionsWrites(r.tx, before)
+		if err != nil {
+			return err
+		}
+
+		for _, write := range writes {
+			err = write(r.tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// deleteMessageRevisionsWrites returns the writes deleting the message revisions created before the time.
+func deleteMessageRevisionsWrites(txn *badger.Txn, before time.Time) ([]func(txn *badger.Txn) error, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = []byte(revisionPrefix)
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	var writes []func(txn *badger.Txn) error
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		var revision model.MessageRevision
+
+		err := getValue(it.Item(), &revision)
+		if err != nil {
+			return nil, fmt.Errorf("get message revision: %w", err)
+		}
+
+		if !revision.CreatedAt.Before(before) {
+			continue
+		}
+
+		key := it.Item().KeyCopy(nil)
+
+		writes = append(writes, func(txn *badger.Txn) error {
+			err := txn.Delete(key)
+			if err != nil {
+				return fmt.Errorf("delete message revision key '%s': %w", key, err)
+			}
+
+			return nil
+		})
+	}
+
+	return writes, nil
+}
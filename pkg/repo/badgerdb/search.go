@@ -0,0 +1,294 @@
+package badgerdb
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+)
+
+const searchPrefix = "search:"
+
+// searchKey returns the key of a word of the message in the inverted search index,
+// search:<word>:<service ID>:<language>:<message ID>. Words do not contain colons, see model.SearchWords.
+// The keys of a word prefix are iterated to find the messages with words starting with it.
+func searchKey(word string, serviceID uuid.UUID, lang language.Tag, messageID string) []byte {
+	return fmt.Appendf(nil, "%s%s:%s:%s:%s", searchPrefix, word, serviceID, lang, messageID)
+}
+
+// messageRef identifies a message of any service, parsed from the key suffix <service ID>:<language>:<message ID>.
+type messageRef struct {
+	language  string
+	messageID string
+	serviceID uuid.UUID
+}
+
+func parseMessageRef(s string) (messageRef, error) {
+	svc, rest, _ := strings.Cut(s, ":")
+	lang, messageID, ok := strings.Cut(rest, ":")
+
+	serviceID, err := uuid.Parse(svc)
+	if err != nil || !ok {
+		return messageRef{}, fmt.Errorf("invalid message reference '%s'", s)
+	}
+
+	return messageRef{serviceID: serviceID, language: lang, messageID: messageID}, nil
+}
+
+// searchWords returns the distinct lowercase words of the message text and ID.
+func searchWords(msg *model.Message) []string {
+	words := model.SearchTerms(msg.Message)
+
+	for _, word := range model.SearchTerms(msg.ID) {
+		if !slices.Contains(words, word) {
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+// indexMessage adds the words of the message to the search index.
+func indexMessage(txn *badger.Txn, serviceID uuid.UUID, lang language.Tag, msg *model.Message) error {
+	for _, word := range searchWords(msg) {
+		err := txn.Set(searchKey(word, serviceID, lang, msg.ID), nil)
+		if err != nil {
+			return fmt.Errorf("set search key of message '%s': %w", msg.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// unindexMessage removes the words of the message from the search index.
+func unindexMessage(txn *badger.Txn, serviceID uuid.UUID, lang language.Tag, msg *model.Message) error {
+	for _, word := range searchWords(msg) {
+		err := txn.Delete(searchKey(word, serviceID, lang, msg.ID))
+		if err != nil {
+			return fmt.Errorf("delete search key of message '%s': %w", msg.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// reindexMessages updates the search index of the translation messages from the previous to the current messages,
+// only messages added, removed or changed in text are reindexed.
+func reindexMessages(txn *badger.Txn, serviceID uuid.UUID, lang language.Tag, previous, current []model.Message,
+) error {
+	previousByID := make(map[string]*model.Message, len(previous))
+	for i := range previous {
+		previousByID[previous[i].ID] = &previous[i]
+	}
+
+	// The last message with an ID is stored.
+	currentByID := make(map[string]*model.Message, len(current))
+	for i := range current {
+		currentByID[current[i].ID] = &current[i]
+	}
+
+	// Words are removed first, words of both the previous and the current message are set again.
+	for id, old := range previousByID {
+		if msg, ok := currentByID[id]; !ok || msg.Message != old.Message {
+			err := unindexMessage(txn, serviceID, lang, old)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for id, msg := range currentByID {
+		if old, ok := previousByID[id]; !ok || old.Message != msg.Message {
+			err := indexMessage(txn, serviceID, lang, msg)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// indexMessages adds the words of the messages with the message key prefix to the search index.
+func indexMessages(txn *badger.Txn, prefix []byte) error {
+	return forEachMessage(txn, prefix, indexMessage)
+}
+
+// unindexMessages removes the words of the messages with the message key prefix from the search index,
+// e.g. before the messages of a translation or a service are deleted.
+func unindexMessages(txn *badger.Txn, prefix []byte) error {
+	return forEachMessage(txn, prefix, unindexMessage)
+}
+
+// forEachMessage calls fn for each message with the message key prefix.
+func forEachMessage(txn *badger.Txn, prefix []byte,
+	fn func(txn *badger.Txn, serviceID uuid.UUID, lang language.Tag, msg *model.Message) error,
+) error {
+	itOpts := badger.DefaultIteratorOptions
+	itOpts.Prefix = prefix
+
+	it := txn.NewIterator(itOpts)
+
+	// Messages are collected first, the iterator must be closed before the keys are written.
+	var (
+		refs     []messageRef
+		messages []model.Message
+	)
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		ref, err := parseMessageRef(strings.TrimPrefix(string(it.Item().Key()), messagePrefix))
+		if err != nil {
+			it.Close()
+			return err
+		}
+
+		var msg storedMessage
+
+		err = it.Item().Value(func(val []byte) error { return json.Unmarshal(val, &msg) }) //nolint:wrapcheck
+		if err != nil {
+			it.Close()
+			return fmt.Errorf("get message: %w", err)
+		}
+
+		refs = append(refs, ref)
+		messages = append(messages, msg.Message)
+	}
+
+	it.Close()
+
+	for i := range messages {
+		err := fn(txn, refs[i].serviceID, language.Make(refs[i].language), &messages[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SearchMessages returns the messages of all services matching the query,
+// ordered by service ID, language and message ID.
+// Messages are found in the inverted search index by the word prefixes of the query terms.
+func (r *Repo) SearchMessages(_ context.Context, opts repo.SearchMessagesOpts) ([]model.SearchResult, error) {
+	terms := model.SearchTerms(opts.Query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	languages := make([]string, 0, len(opts.FilterLanguages))
+	for _, lang := range opts.FilterLanguages {
+		languages = append(languages, lang.String())
+	}
+
+	var results []model.SearchResult
+
+	err := r.view(func(txn *badger.Txn) error {
+		refs, txErr := searchIndex(txn, terms)
+		if txErr != nil {
+			return txErr
+		}
+
+		for _, ref := range refs {
+			if opts.Limit > 0 && len(results) == opts.Limit {
+				break
+			}
+
+			if len(languages) > 0 && !slices.Contains(languages, ref.language) {
+				continue
+			}
+
+			lang, txErr := language.Parse(ref.language)
+			if txErr != nil {
+				return fmt.Errorf("parse language of message '%s': %w", ref.messageID, txErr)
+			}
+
+			item, txErr := txn.Get(messageKey(ref.serviceID, lang, ref.messageID))
+
+			switch {
+			case errors.Is(txErr, badger.ErrKeyNotFound):
+				continue // Stale index key, the index is updated with the messages.
+			case txErr != nil:
+				return fmt.Errorf("transaction: get message '%s': %w", ref.messageID, txErr)
+			}
+
+			var msg storedMessage
+
+			txErr = item.Value(func(val []byte) error { return json.Unmarshal(val, &msg) }) //nolint:wrapcheck
+			if txErr != nil {
+				return fmt.Errorf("get message '%s': %w", ref.messageID, txErr)
+			}
+
+			if len(opts.FilterStatuses) > 0 && !slices.Contains(opts.FilterStatuses, msg.Status) {
+				continue
+			}
+
+			results = append(results, model.SearchResult{ServiceID: ref.serviceID, Language: lang, Message: msg.Message})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	return results, nil
+}
+
+// searchIndex returns the messages with a word starting with each of the terms,
+// ordered by service ID, language and message ID.
+func searchIndex(txn *badger.Txn, terms []string) ([]messageRef, error) {
+	var found map[messageRef]bool // messages with words of all the terms so far
+
+	for i, term := range terms {
+		itOpts := badger.DefaultIteratorOptions
+		itOpts.Prefix = []byte(searchPrefix + term)
+		itOpts.PrefetchValues = false
+
+		it := txn.NewIterator(itOpts)
+		matched := make(map[messageRef]bool)
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			// The key is search:<word>:<message ref>.
+			_, s, _ := bytes.Cut(it.Item().Key()[len(searchPrefix):], []byte(":"))
+
+			ref, err := parseMessageRef(string(s))
+			if err != nil {
+				it.Close()
+				return nil, err
+			}
+
+			if i == 0 || found[ref] {
+				matched[ref] = true
+			}
+		}
+
+		it.Close()
+
+		found = matched
+	}
+
+	refs := make([]messageRef, 0, len(found))
+	for ref := range found {
+		refs = append(refs, ref)
+	}
+
+	slices.SortFunc(refs, func(a, b messageRef) int {
+		return cmp.Or(
+			strings.Compare(a.serviceID.String(), b.serviceID.String()),
+			strings.Compare(a.language, b.language),
+			strings.Compare(a.messageID, b.messageID),
+		)
+	})
+
+	return refs, nil
+}
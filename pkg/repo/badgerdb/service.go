@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"go.expect.digital/translate/pkg/model"
 	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
 )
 
 const servicePrefix = "service:"
@@ -19,35 +20,60 @@ func getServiceKey(id uuid.UUID) []byte {
 	return []byte(fmt.Sprintf("%s%s", servicePrefix, id))
 }
 
-func (r *Repo) SaveService(_ context.Context, service *model.Service) error {
+func (r *Repo) SaveService(ctx context.Context, service *model.Service) error {
 	if service.ID == uuid.Nil {
 		service.ID = uuid.New()
 	}
 
-	err := r.db.Update(func(txn *badger.Txn) error {
-		val, err := json.Marshal(service)
+	if service.ProjectID == uuid.Nil {
+		service.ProjectID = model.DefaultProjectID
+	}
+
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		var stored model.Service
+
+		item, err := r.tx.Get(getServiceKey(service.ID))
+
+		switch {
+		default:
+			err = getValue(item, &stored)
+			if err != nil {
+				return fmt.Errorf("repo: get service: %w", err)
+			}
+		case errors.Is(err, badger.ErrKeyNotFound):
+			// New service.
+		case err != nil:
+			return fmt.Errorf("transaction: get service: %w", err)
+		}
+
+		version, err := nextVersion(service.Version, stored.Version)
+		if err != nil {
+			return err
+		}
+
+		saved := *service
+		saved.Version = version
+
+		val, err := json.Marshal(saved)
 		if err != nil {
 			return fmt.Errorf("marshal service: %w", err)
 		}
 
-		err = txn.Set(getServiceKey(service.ID), val)
+		err = r.tx.Set(getServiceKey(service.ID), val)
 		if err != nil {
-			return fmt.Errorf("transaction: set service: %w", err)
+			return fmt.Errorf("repo: set service: %w", err)
 		}
 
+		service.Version = version
+
 		return nil
 	})
-	if err != nil {
-		return fmt.Errorf("repo: db update: %w", err)
-	}
-
-	return nil
 }
 
 func (r *Repo) LoadService(_ context.Context, serviceID uuid.UUID) (*model.Service, error) {
 	var service model.Service
 
-	err := r.db.View(func(txn *badger.Txn) error {
+	err := r.view(func(txn *badger.Txn) error {
 		item, err := txn.Get(getServiceKey(serviceID))
 
 		switch {
@@ -66,14 +92,14 @@ func (r *Repo) LoadService(_ context.Context, serviceID uuid.UUID) (*model.Servi
 	return &service, nil
 }
 
-func (r *Repo) LoadServices(_ context.Context) ([]model.Service, error) {
+func (r *Repo) LoadServices(_ context.Context, opts repo.LoadServicesOpts) ([]model.Service, error) {
 	var services []model.Service
 
-	err := r.db.View(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		opts.Prefix = []byte(servicePrefix)
+	err := r.view(func(txn *badger.Txn) error {
+		itOpts := badger.DefaultIteratorOptions
+		itOpts.Prefix = []byte(servicePrefix)
 
-		it := txn.NewIterator(opts)
+		it := txn.NewIterator(itOpts)
 		defer it.Close()
 
 		for it.Rewind(); it.Valid(); it.Next() {
@@ -86,6 +112,10 @@ func (r *Repo) LoadServices(_ context.Context) ([]model.Service, error) {
 				return err
 			}
 
+			if opts.FilterProjectID != uuid.Nil && service.ProjectID != opts.FilterProjectID {
+				continue
+			}
+
 			services = append(services, service)
 		}
 
@@ -98,31 +128,106 @@ func (r *Repo) LoadServices(_ context.Context) ([]model.Service, error) {
 	return services, nil
 }
 
-func (r *Repo) DeleteService(_ context.Context, serviceID uuid.UUID) error {
-	key := getServiceKey(serviceID)
+// DeleteService deletes the service with its translations, jobs and usage.
+// Outside of a transaction, the data of the service is deleted in batches first, so that a big service
+// does not exceed the transaction size, see badger.ErrTxnTooBig. The service itself is deleted last,
+// together with the data written meanwhile, so a failed deletion leaves the service to delete again.
+func (r *Repo) DeleteService(ctx context.Context, serviceID uuid.UUID) error {
+	if r.tx == nil {
+		var writes []func(txn *badger.Txn) error
 
-	err := r.db.Update(func(txn *badger.Txn) error {
-		// BadgerDB does not return an error if the key does not exist on Delete.
-		// So we have to check if the key exists first.
-		_, err := txn.Get(key)
+		err := r.db.View(func(txn *badger.Txn) error {
+			var txErr error
 
-		switch {
-		default:
-			deleteErr := txn.Delete(key)
-			if deleteErr != nil {
-				return fmt.Errorf("transaction: delete service: %w", deleteErr)
+			writes, txErr = deleteServiceWrites(txn, serviceID)
+
+			return txErr
+		})
+		if err != nil {
+			return fmt.Errorf("repo: db view: %w", err)
+		}
+
+		err = writeBatches(r.db, writes)
+		if err != nil {
+			return fmt.Errorf("repo: delete service data: %w", err)
+		}
+	}
+
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		writes, err := deleteServiceWrites(r.tx, serviceID)
+		if err != nil {
+			return err
+		}
+
+		for _, write := range writes {
+			err = write(r.tx)
+			if err != nil {
+				return err
 			}
+		}
 
-			return nil
-		case errors.Is(err, badger.ErrKeyNotFound):
-			return repo.ErrNotFound
-		case err != nil:
-			return fmt.Errorf("transaction: get service for deletion: %w", err)
+		err = r.tx.Delete(getServiceKey(serviceID))
+		if err != nil {
+			return fmt.Errorf("transaction: delete service: %w", err)
 		}
+
+		return nil
 	})
+}
+
+// deleteServiceWrites returns the idempotent writes deleting the data of the service:
+// translations, messages with their search index and order, revisions, usage, jobs, webhooks and webhook deliveries.
+func deleteServiceWrites(txn *badger.Txn, serviceID uuid.UUID) ([]func(txn *badger.Txn) error, error) {
+	// BadgerDB does not return an error if the key does not exist on Delete.
+	// So we have to check if the key exists first.
+	_, err := txn.Get(getServiceKey(serviceID))
+
+	switch {
+	case errors.Is(err, badger.ErrKeyNotFound):
+		return nil, repo.ErrNotFound
+	case err != nil:
+		return nil, fmt.Errorf("transaction: get service for deletion: %w", err)
+	}
+
+	var writes []func(txn *badger.Txn) error
+
+	// Messages are removed from the search index before they are deleted.
+	err = forEachMessage(txn, fmt.Appendf(nil, "%s%s:", messagePrefix, serviceID),
+		func(_ *badger.Txn, serviceID uuid.UUID, lang language.Tag, msg *model.Message) error {
+			writes = append(writes, func(txn *badger.Txn) error {
+				return unindexMessage(txn, serviceID, lang, msg)
+			})
+
+			return nil
+		})
 	if err != nil {
-		return fmt.Errorf("repo: db update: %w", err)
+		return nil, fmt.Errorf("transaction: unindex service messages: %w", err)
+	}
+
+	jobKeys, err := serviceJobKeys(txn, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("transaction: get service jobs: %w", err)
+	}
+
+	keys := jobKeys
+
+	for _, prefix := range []string{
+		translationPrefix, messagePrefix, orderPrefix, revisionPrefix, usagePrefix,
+		webhookPrefix, webhookDeliveryPrefix, webhookPendingPrefix,
+	} {
+		keys = append(keys, prefixKeys(txn, fmt.Appendf(nil, "%s%s:", prefix, serviceID))...)
+	}
+
+	for _, key := range keys {
+		writes = append(writes, func(txn *badger.Txn) error {
+			err := txn.Delete(key)
+			if err != nil {
+				return fmt.Errorf("delete key '%s': %w", key, err)
+			}
+
+			return nil
+		})
 	}
 
-	return nil
+	return writes, nil
 }
@@ -0,0 +1,93 @@
+package badgerdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil/rand"
+)
+
+func Test_DeleteServiceBatches(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	// A small memtable limits the transaction size, see badger.ErrTxnTooBig.
+	db, err := newDB(badger.DefaultOptions("").
+		WithInMemory(true).
+		WithMemTableSize(1 << 20).
+		WithValueThreshold(1 << 10).
+		WithLogger(nil))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer db.Close()
+
+	r := &Repo{db: db}
+	service := rand.ModelService()
+
+	err = r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// More usage than fits into a single transaction.
+	const count = 5000
+
+	writes := make([]func(txn *badger.Txn) error, 0, count)
+
+	for i := range count {
+		usage := &model.Usage{
+			ServiceID:  service.ID,
+			Date:       time.Now().AddDate(0, 0, -i),
+			Translator: fmt.Sprintf("Translator%d", i),
+			Characters: 1,
+			Requests:   1,
+		}
+
+		b, err := json.Marshal(usage)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		writes = append(writes, func(txn *badger.Txn) error { return txn.Set(usageKey(usage), b) })
+	}
+
+	err = writeBatches(db, writes)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = r.DeleteService(ctx, service.ID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = r.LoadService(ctx, service.ID)
+	if !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("want error '%v', got '%v'", repo.ErrNotFound, err)
+	}
+
+	err = db.View(func(txn *badger.Txn) error {
+		if keys := prefixKeys(txn, fmt.Appendf(nil, "%s%s:", usagePrefix, service.ID)); len(keys) != 0 {
+			t.Errorf("want no usage keys, got %d", len(keys))
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
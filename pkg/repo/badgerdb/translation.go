@@ -1,10 +1,16 @@
 package badgerdb
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/dgraph-io/badger/v4"
 	"github.com/google/uuid"
@@ -13,14 +19,38 @@ import (
 	"golang.org/x/text/language"
 )
 
-const translationPrefix = "translation:"
+const (
+	translationPrefix = "translation:"
+	messagePrefix     = "message:"
+)
 
 // translationKey converts a serviceID and language to a BadgerDB key with prefix.
+// The value is the translation without messages, each message is stored under its message key.
 func translationKey(serviceID uuid.UUID, language language.Tag) []byte {
 	return fmt.Appendf(nil, "%s%s:%s", translationPrefix, serviceID, language)
 }
 
+// messageKeyPrefix returns the key prefix of the messages of a service translation.
+func messageKeyPrefix(serviceID uuid.UUID, language language.Tag) []byte {
+	return fmt.Appendf(nil, "%s%s:%s:", messagePrefix, serviceID, language)
+}
+
+// messageKey converts a serviceID, language and message ID to a BadgerDB key with prefix,
+// messages of a translation are ordered by ID.
+func messageKey(serviceID uuid.UUID, language language.Tag, messageID string) []byte {
+	return append(messageKeyPrefix(serviceID, language), messageID...)
+}
+
+// storedMessage is the value of a message key.
+type storedMessage struct {
+	model.Message
+	// Rank orders the messages of the translation, keys are ordered by message ID. See assignRanks.
+	Rank uint64 `json:"rank"`
+}
+
 // SaveTranslation handles both Create and Update.
+// Only the changed messages are written, messages missing in the translation are deleted.
+// The version of an unchanged translation is not bumped.
 func (r *Repo) SaveTranslation(ctx context.Context, serviceID uuid.UUID, translation *model.Translation) error {
 	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
 		_, err := r.LoadService(ctx, serviceID)
@@ -28,7 +58,44 @@ func (r *Repo) SaveTranslation(ctx context.Context, serviceID uuid.UUID, transla
 			return fmt.Errorf("repo: load service: %w", err)
 		}
 
-		b, err := json.Marshal(translation)
+		var stored model.Translation
+
+		item, err := r.tx.Get(translationKey(serviceID, translation.Language))
+
+		switch {
+		default:
+			err = getValue(item, &stored)
+			if err != nil {
+				return fmt.Errorf("repo: get translation: %w", err)
+			}
+		case errors.Is(err, badger.ErrKeyNotFound):
+			// New translation.
+		case err != nil:
+			return fmt.Errorf("transaction: get translation: %w", err)
+		}
+
+		version, err := nextVersion(translation.Version, stored.Version)
+		if err != nil {
+			return err
+		}
+
+		previous, current, err := saveMessages(r.tx, serviceID, translation)
+		if err != nil {
+			return fmt.Errorf("repo: %w", err)
+		}
+
+		// The version is not bumped if no message was written or deleted and the translation is unchanged.
+		if stored.Version != 0 && stored.Original == translation.Original && len(previous) == 0 && len(current) == 0 {
+			translation.Version = stored.Version
+
+			return nil
+		}
+
+		b, err := json.Marshal(model.Translation{
+			Language: translation.Language,
+			Original: translation.Original,
+			Version:  version,
+		})
 		if err != nil {
 			return fmt.Errorf("marshal translation: %w", err)
 		}
@@ -38,11 +105,373 @@ func (r *Repo) SaveTranslation(ctx context.Context, serviceID uuid.UUID, transla
 			return fmt.Errorf("repo: set translation: %w", err)
 		}
 
+		revisions := model.NewMessageRevisions(translation.Language, previous, current,
+			repo.ActorFromContext(ctx), time.Now().UTC())
+
+		err = saveMessageRevisions(r.tx, serviceID, revisions)
+		if err != nil {
+			return fmt.Errorf("repo: %w", err)
+		}
+
+		translation.Version = version
+
 		return nil
 	})
 }
 
-// LoadTranslations retrieves translations from db based on serviceID and LoadMessageOpts.
+// SaveMessage handles both Create and Update of a message of the service translation,
+// a new message is ranked after the other messages.
+func (r *Repo) SaveMessage(ctx context.Context, serviceID uuid.UUID, language language.Tag, message *model.Message,
+) error {
+	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
+		var translation model.Translation
+
+		item, err := r.tx.Get(translationKey(serviceID, language))
+
+		switch {
+		default:
+			err = getValue(item, &translation)
+			if err != nil {
+				return fmt.Errorf("repo: get translation: %w", err)
+			}
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get translation: %w", err)
+		}
+
+		translation.Version++
+
+		b, err := json.Marshal(translation)
+		if err != nil {
+			return fmt.Errorf("marshal translation: %w", err)
+		}
+
+		err = r.tx.Set(translationKey(serviceID, language), b)
+		if err != nil {
+			return fmt.Errorf("repo: set translation: %w", err)
+		}
+
+		key := messageKey(serviceID, language, message.ID)
+
+		var (
+			previous []model.Message
+			stored   storedMessage
+		)
+
+		item, err = r.tx.Get(key)
+
+		switch {
+		default:
+			err = item.Value(func(val []byte) error { return json.Unmarshal(val, &stored) }) //nolint:wrapcheck
+			if err != nil {
+				return fmt.Errorf("repo: get message: %w", err)
+			}
+
+			previous = append(previous, stored.Message)
+		case errors.Is(err, badger.ErrKeyNotFound):
+			stored.Rank, err = r.nextRank(serviceID, language, message.ID)
+			if err != nil {
+				return fmt.Errorf("repo: %w", err)
+			}
+		case err != nil:
+			return fmt.Errorf("transaction: get message: %w", err)
+		}
+
+		b, err = json.Marshal(storedMessage{Message: *message, Rank: stored.Rank})
+		if err != nil {
+			return fmt.Errorf("marshal message: %w", err)
+		}
+
+		err = r.tx.Set(key, b)
+		if err != nil {
+			return fmt.Errorf("repo: set message: %w", err)
+		}
+
+		current := []model.Message{*message}
+
+		err = reindexMessages(r.tx, serviceID, language, previous, current)
+		if err != nil {
+			return fmt.Errorf("repo: reindex message: %w", err)
+		}
+
+		revisions := model.NewMessageRevisions(language, previous, current, repo.ActorFromContext(ctx), time.Now().UTC())
+
+		err = saveMessageRevisions(r.tx, serviceID, revisions)
+		if err != nil {
+			return fmt.Errorf("repo: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// nextRank sets the order key of a message added after the other messages of the translation and returns its rank.
+func (r *Repo) nextRank(serviceID uuid.UUID, language language.Tag, messageID string) (uint64, error) {
+	last, err := lastRank(r.tx, serviceID, language)
+	if err != nil {
+		return 0, err
+	}
+
+	if last > math.MaxUint64-rankGap {
+		return 0, errors.New("no rank after the last message")
+	}
+
+	rank := last + rankGap
+
+	err = r.tx.Set(rankKey(orderKeyPrefix(serviceID, language), rank), []byte(messageID))
+	if err != nil {
+		return 0, fmt.Errorf("set message order: %w", err)
+	}
+
+	return rank, nil
+}
+
+// LoadMessage returns the message of the service translation.
+func (r *Repo) LoadMessage(_ context.Context, serviceID uuid.UUID, language language.Tag, messageID string,
+) (*model.Message, error) {
+	var msg storedMessage
+
+	err := r.view(func(txn *badger.Txn) error {
+		item, err := txn.Get(messageKey(serviceID, language, messageID))
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return item.Value(func(val []byte) error { return json.Unmarshal(val, &msg) }) //nolint:wrapcheck
+	})
+
+	switch {
+	case errors.Is(err, badger.ErrKeyNotFound):
+		return nil, repo.ErrNotFound
+	case err != nil:
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	return &msg.Message, nil
+}
+
+// saveMessages writes the added, changed and moved messages of the translation and deletes the messages missing
+// in it, the search index is updated with them. Only the order keys and the messages of the translation are read,
+// unchanged messages are not written. It returns the previous and the current versions of the written
+// and deleted messages.
+func saveMessages(txn *badger.Txn, serviceID uuid.UUID, translation *model.Translation,
+) (previous, current []model.Message, err error) {
+	lang := translation.Language
+	messages := lastMessages(translation.Messages)
+
+	stored, err := loadOrder(txn, serviceID, lang)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+	}
+
+	ranks := assignRanks(stored, ids)
+
+	storedRanks := make(map[string]uint64, len(stored))
+	for _, msg := range stored {
+		storedRanks[msg.id] = msg.rank
+	}
+
+	order := orderKeyPrefix(serviceID, lang)
+
+	// The order keys are written after all stale order keys are deleted,
+	// a message might get the previous rank of another message.
+	var (
+		ranked     []rankedMessage
+		staleRanks []uint64
+	)
+
+	for i, msg := range messages {
+		b, err := json.Marshal(storedMessage{Message: msg, Rank: ranks[i]})
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshal message: %w", err)
+		}
+
+		key := messageKey(serviceID, lang, msg.ID)
+
+		old, changed, err := changedMessage(txn, key, b)
+		if err != nil {
+			return nil, nil, fmt.Errorf("message '%s': %w", msg.ID, err)
+		}
+
+		rank, ok := storedRanks[msg.ID]
+		delete(storedRanks, msg.ID)
+
+		if !changed {
+			continue
+		}
+
+		if old != nil {
+			previous = append(previous, *old)
+		}
+
+		current = append(current, msg)
+
+		err = txn.Set(key, b)
+		if err != nil {
+			return nil, nil, fmt.Errorf("set message '%s': %w", msg.ID, err)
+		}
+
+		if ok && rank == ranks[i] {
+			continue
+		}
+
+		if ok {
+			staleRanks = append(staleRanks, rank)
+		}
+
+		ranked = append(ranked, rankedMessage{id: msg.ID, rank: ranks[i]})
+	}
+
+	// The remaining stored messages are missing in the translation.
+	for id, rank := range storedRanks {
+		key := messageKey(serviceID, lang, id)
+
+		old, _, err := changedMessage(txn, key, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("message '%s': %w", id, err)
+		}
+
+		if old != nil {
+			previous = append(previous, *old)
+		}
+
+		err = txn.Delete(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("delete message key '%s': %w", key, err)
+		}
+
+		staleRanks = append(staleRanks, rank)
+	}
+
+	for _, rank := range staleRanks {
+		err = txn.Delete(rankKey(order, rank))
+		if err != nil {
+			return nil, nil, fmt.Errorf("delete order key '%s': %w", rankKey(order, rank), err)
+		}
+	}
+
+	for _, msg := range ranked {
+		err = txn.Set(rankKey(order, msg.rank), []byte(msg.id))
+		if err != nil {
+			return nil, nil, fmt.Errorf("set order of message '%s': %w", msg.id, err)
+		}
+	}
+
+	err = reindexMessages(txn, serviceID, lang, previous, current)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reindex messages: %w", err)
+	}
+
+	return previous, current, nil
+}
+
+// changedMessage returns the stored message of the key, nil if there is none,
+// and reports whether the stored value differs from the value.
+func changedMessage(txn *badger.Txn, key, value []byte) (*model.Message, bool, error) {
+	item, err := txn.Get(key)
+
+	switch {
+	case errors.Is(err, badger.ErrKeyNotFound):
+		return nil, true, nil
+	case err != nil:
+		return nil, false, fmt.Errorf("transaction: get message: %w", err)
+	}
+
+	var (
+		msg     storedMessage
+		changed bool
+	)
+
+	err = item.Value(func(val []byte) error {
+		if changed = !bytes.Equal(val, value); !changed {
+			return nil
+		}
+
+		return json.Unmarshal(val, &msg) //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("get message: %w", err)
+	}
+
+	return &msg.Message, changed, nil
+}
+
+// lastMessages returns the last of the messages with the same ID, as the last one is stored,
+// in the order of the last ones.
+func lastMessages(messages []model.Message) []model.Message {
+	last := make(map[string]int, len(messages))
+	for i, msg := range messages {
+		last[msg.ID] = i
+	}
+
+	if len(last) == len(messages) {
+		return messages
+	}
+
+	unique := make([]model.Message, 0, len(last))
+
+	for i, msg := range messages {
+		if last[msg.ID] == i {
+			unique = append(unique, msg)
+		}
+	}
+
+	return unique
+}
+
+// DeleteTranslation deletes the translation of the service language with its messages.
+func (r *Repo) DeleteTranslation(ctx context.Context, serviceID uuid.UUID, language language.Tag) error {
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		key := translationKey(serviceID, language)
+
+		// BadgerDB does not return an error if the key does not exist on Delete.
+		_, err := r.tx.Get(key)
+
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get translation for deletion: %w", err)
+		}
+
+		err = r.tx.Delete(key)
+		if err != nil {
+			return fmt.Errorf("transaction: delete translation: %w", err)
+		}
+
+		err = unindexMessages(r.tx, messageKeyPrefix(serviceID, language))
+		if err != nil {
+			return fmt.Errorf("transaction: unindex translation messages: %w", err)
+		}
+
+		err = deletePrefix(r.tx, messageKeyPrefix(serviceID, language))
+		if err != nil {
+			return fmt.Errorf("transaction: delete translation messages: %w", err)
+		}
+
+		err = deletePrefix(r.tx, orderKeyPrefix(serviceID, language))
+		if err != nil {
+			return fmt.Errorf("transaction: delete translation message order: %w", err)
+		}
+
+		err = deletePrefix(r.tx, revisionKeyPrefix(serviceID, language))
+		if err != nil {
+			return fmt.Errorf("transaction: delete translation message revisions: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// LoadTranslations retrieves translations from db based on serviceID and LoadTranslationsOpts.
+// Messages are read by key, only the messages selected by the message IDs, ID prefix,
+// pagination key and limit are read from the db.
 func (r *Repo) LoadTranslations(ctx context.Context, serviceID uuid.UUID, opts repo.LoadTranslationsOpts,
 ) (model.Translations, error) {
 	_, err := r.LoadService(ctx, serviceID)
@@ -52,33 +481,172 @@ func (r *Repo) LoadTranslations(ctx context.Context, serviceID uuid.UUID, opts r
 		return nil, fmt.Errorf("repo: load service: %w", err)
 	}
 
+	var translations model.Translations
+
 	// load all translations if languages are not provided.
 	if len(opts.FilterLanguages) == 0 {
-		var translations model.Translations
-
 		translations, err = r.loadTranslations(serviceID)
 		if err != nil {
 			return nil, fmt.Errorf("load translations by service '%s': %w", serviceID, err)
 		}
+	} else {
+		// load translations based on provided languages.
+		translations, err = r.loadTranslationsByLang(serviceID, opts.FilterLanguages)
+		if err != nil {
+			return nil, fmt.Errorf("load translations by languages: %w", err)
+		}
+	}
+
+	if opts.FilterOriginal {
+		translations = slices.DeleteFunc(translations, func(t model.Translation) bool { return !t.Original })
+	}
 
-		return translations, nil
+	if opts.Limit > 0 {
+		slices.SortFunc(translations, func(a, b model.Translation) int {
+			return strings.Compare(a.Language.String(), b.Language.String())
+		})
 	}
 
-	// load translations based on provided languages.
-	translations, err := r.loadTranslationsByLang(serviceID, opts.FilterLanguages)
+	// Lowercase the text once, not per message.
+	opts.FilterText = strings.ToLower(opts.FilterText)
+
+	err = r.view(func(txn *badger.Txn) error {
+		limit := opts.Limit // remaining number of messages, 0 for no limit
+
+		for i := range translations {
+			messages, txErr := loadMessages(txn, serviceID, translations[i].Language, &opts, limit)
+			if txErr != nil {
+				return fmt.Errorf("load '%s' messages: %w", translations[i].Language, txErr)
+			}
+
+			translations[i].Messages = messages
+
+			if opts.Limit == 0 {
+				continue
+			}
+
+			// Translations after the limit is reached are loaded without messages.
+			if limit -= len(messages); limit == 0 {
+				break
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("load translations by languages: %w", err)
+		return nil, fmt.Errorf("repo: db view: %w", err)
 	}
 
 	return translations, nil
 }
 
+// loadMessages returns at most limit messages of the translation selected by opts, ordered by ID,
+// or all selected messages in the order of the translation if limit is 0.
+// opts.FilterText must be lowercase.
+func loadMessages(txn *badger.Txn, serviceID uuid.UUID, lang language.Tag, opts *repo.LoadTranslationsOpts, limit int,
+) ([]model.Message, error) {
+	// Messages of languages up to the pagination key are on previous pages.
+	if opts.After != (repo.MessageKey{}) && lang.String() < opts.After.Language.String() {
+		return nil, nil
+	}
+
+	var messages []storedMessage
+
+	add := func(item *badger.Item) error {
+		var msg storedMessage
+
+		err := item.Value(func(val []byte) error { return json.Unmarshal(val, &msg) }) //nolint:wrapcheck
+		if err != nil {
+			return fmt.Errorf("get message: %w", err)
+		}
+
+		if matchMessage(lang, &msg.Message, opts) {
+			messages = append(messages, msg)
+		}
+
+		return nil
+	}
+
+	if len(opts.FilterMessageIDs) > 0 {
+		for _, id := range opts.FilterMessageIDs {
+			item, err := txn.Get(messageKey(serviceID, lang, id))
+
+			switch {
+			case errors.Is(err, badger.ErrKeyNotFound):
+				continue
+			case err != nil:
+				return nil, fmt.Errorf("transaction: get message '%s': %w", id, err)
+			}
+
+			err = add(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		iterOpts := badger.DefaultIteratorOptions
+		iterOpts.Prefix = append(messageKeyPrefix(serviceID, lang), opts.FilterMessageIDPrefix...)
+
+		it := txn.NewIterator(iterOpts)
+		defer it.Close()
+
+		it.Rewind()
+
+		if opts.After != (repo.MessageKey{}) && lang == opts.After.Language {
+			it.Seek(messageKey(serviceID, lang, opts.After.ID))
+		}
+
+		// Keys are ordered by message ID, stop at the limit.
+		for ; it.Valid() && (limit == 0 || len(messages) < limit); it.Next() {
+			err := add(it.Item())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if limit > 0 {
+		slices.SortFunc(messages, func(a, b storedMessage) int { return strings.Compare(a.ID, b.ID) })
+		messages = messages[:min(limit, len(messages))]
+	} else {
+		slices.SortFunc(messages, func(a, b storedMessage) int { return cmp.Compare(a.Rank, b.Rank) })
+	}
+
+	result := make([]model.Message, 0, len(messages))
+	for _, msg := range messages {
+		result = append(result, msg.Message)
+	}
+
+	return result, nil
+}
+
+// matchMessage checks if the message of the translation language is selected by opts.
+// opts.FilterText must be lowercase.
+func matchMessage(lang language.Tag, msg *model.Message, opts *repo.LoadTranslationsOpts) bool {
+	switch {
+	case len(opts.FilterMessageIDs) > 0 && !slices.Contains(opts.FilterMessageIDs, msg.ID),
+		len(opts.FilterStatuses) > 0 && !slices.Contains(opts.FilterStatuses, msg.Status),
+		!strings.HasPrefix(msg.ID, opts.FilterMessageIDPrefix),
+		opts.FilterText != "" && !strings.Contains(strings.ToLower(msg.Message), opts.FilterText),
+		opts.FilterMaxQualityScore != nil &&
+			(msg.QualityScore == nil || *msg.QualityScore > *opts.FilterMaxQualityScore):
+		return false
+	case opts.After == (repo.MessageKey{}):
+		return true
+	}
+
+	return cmp.Or(
+		strings.Compare(lang.String(), opts.After.Language.String()),
+		strings.Compare(msg.ID, opts.After.ID),
+	) > 0
+}
+
 // loadTranslationsByLang returns translations for service based on provided languages.
 func (r *Repo) loadTranslationsByLang(serviceID uuid.UUID, languages []language.Tag,
 ) (model.Translations, error) {
 	translations := make([]model.Translation, 0, len(languages))
 
-	err := r.db.View(func(txn *badger.Txn) error {
+	err := r.view(func(txn *badger.Txn) error {
 		for _, lang := range languages {
 			var translation model.Translation
 
@@ -93,7 +661,7 @@ func (r *Repo) loadTranslationsByLang(serviceID uuid.UUID, languages []language.
 
 				translations = append(translations, translation)
 			case errors.Is(txErr, badger.ErrKeyNotFound):
-				return nil // No Translations for this language (Not an error)
+				continue // No Translations for this language (Not an error)
 			case txErr != nil:
 				return fmt.Errorf("transaction: get translations by language '%s': %w", lang, txErr)
 			}
@@ -114,7 +682,7 @@ func (r *Repo) loadTranslations(serviceID uuid.UUID) (model.Translations, error)
 
 	var translations []model.Translation
 
-	err := r.db.View(func(txn *badger.Txn) error {
+	err := r.view(func(txn *badger.Txn) error {
 		it := txn.NewIterator(badger.DefaultIteratorOptions)
 		defer it.Close()
 
@@ -0,0 +1,148 @@
+package badgerdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+const usagePrefix = "usage:"
+
+// usageKey converts the usage service ID, date and translator to a BadgerDB key with prefix,
+// keys of a service are sorted by date and translator.
+func usageKey(usage *model.Usage) []byte {
+	return fmt.Appendf(nil, "%s%s:%s:%s", usagePrefix, usage.ServiceID, usage.Date.Format(time.DateOnly), usage.Translator)
+}
+
+// AddUsage adds the usage characters and requests to the counters of the service, translator and day.
+func (r *Repo) AddUsage(ctx context.Context, usage *model.Usage) error {
+	usage.Date = model.UsageDate(usage.Date)
+
+	add := func(txn *badger.Txn) error {
+		key := usageKey(usage)
+		total := *usage
+
+		item, err := txn.Get(key)
+
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+		case err != nil:
+			return fmt.Errorf("transaction: get usage: %w", err)
+		default:
+			var current model.Usage
+
+			err = getValue(item, &current)
+			if err != nil {
+				return err
+			}
+
+			total.Characters += current.Characters
+			total.Requests += current.Requests
+		}
+
+		b, err := json.Marshal(total)
+		if err != nil {
+			return fmt.Errorf("marshal usage: %w", err)
+		}
+
+		err = txn.Set(key, b)
+		if err != nil {
+			return fmt.Errorf("transaction: set usage: %w", err)
+		}
+
+		return nil
+	}
+
+	if r.tx != nil { // use existing tx
+		return add(r.tx)
+	}
+
+	// Usage is added concurrently, retry the increment if a concurrent transaction changed the counters.
+	for {
+		err := r.db.Update(add)
+
+		switch {
+		case errors.Is(err, badger.ErrConflict) && ctx.Err() == nil:
+			continue
+		case err != nil:
+			return fmt.Errorf("repo: db update: %w", err)
+		default:
+			return nil
+		}
+	}
+}
+
+// LoadUsage returns the usage of the service ordered by date and translator.
+func (r *Repo) LoadUsage(_ context.Context, serviceID uuid.UUID, opts repo.LoadUsageOpts) ([]model.Usage, error) {
+	var usage []model.Usage
+
+	err := r.view(func(txn *badger.Txn) error {
+		itOpts := badger.DefaultIteratorOptions
+		itOpts.Prefix = fmt.Appendf(nil, "%s%s:", usagePrefix, serviceID)
+
+		it := txn.NewIterator(itOpts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var u model.Usage
+
+			err := getValue(it.Item(), &u)
+			if err != nil {
+				return err
+			}
+
+			if !opts.FilterFrom.IsZero() && u.Date.Before(model.UsageDate(opts.FilterFrom)) {
+				continue
+			}
+
+			if !opts.FilterTo.IsZero() && !u.Date.Before(opts.FilterTo) {
+				continue
+			}
+
+			usage = append(usage, u)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	return usage, nil
+}
+
+// LockUsage rewrites the service in the transaction, so that a concurrent transaction
+// locking the usage of the service conflicts on commit.
+func (r *Repo) LockUsage(_ context.Context, serviceID uuid.UUID) error {
+	if r.tx == nil {
+		return nil
+	}
+
+	item, err := r.tx.Get(getServiceKey(serviceID))
+
+	switch {
+	case errors.Is(err, badger.ErrKeyNotFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("repo: get service: %w", err)
+	}
+
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		return fmt.Errorf("repo: copy service: %w", err)
+	}
+
+	err = r.tx.Set(item.KeyCopy(nil), value)
+	if err != nil {
+		return fmt.Errorf("repo: set service: %w", err)
+	}
+
+	return nil
+}
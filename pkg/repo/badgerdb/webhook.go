@@ -0,0 +1,430 @@
+package badgerdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+const (
+	webhookPrefix         = "webhook:"
+	webhookDeliveryPrefix = "webhook_delivery:"
+	// webhookPendingPrefix indexes the pending deliveries, so that they are read without reading the delivery log.
+	webhookPendingPrefix = "webhook_pending:"
+)
+
+// webhookKey returns the key of a service webhook.
+func webhookKey(serviceID, webhookID uuid.UUID) []byte {
+	return fmt.Appendf(nil, "%s%s:%s", webhookPrefix, serviceID, webhookID)
+}
+
+// webhookDeliveryKeyPrefix returns the key prefix of the deliveries of a service webhook.
+func webhookDeliveryKeyPrefix(serviceID, webhookID uuid.UUID) []byte {
+	return fmt.Appendf(nil, "%s%s:%s:", webhookDeliveryPrefix, serviceID, webhookID)
+}
+
+// webhookDeliveryKey returns the key of a delivery.
+func webhookDeliveryKey(delivery *model.WebhookDelivery) []byte {
+	return fmt.Appendf(webhookDeliveryKeyPrefix(delivery.ServiceID, delivery.WebhookID), "%s", delivery.ID)
+}
+
+// webhookPendingKey returns the key indexing a pending delivery, the delivery key with the pending prefix.
+func webhookPendingKey(delivery *model.WebhookDelivery) []byte {
+	return append([]byte(webhookPendingPrefix), webhookDeliveryKey(delivery)[len(webhookDeliveryPrefix):]...)
+}
+
+// webhookDeliveriesPrefix returns the prefix of the deliveries filtered by the service and the webhook.
+// The prefix of all deliveries is returned if the service is not filtered.
+func webhookDeliveriesPrefix(prefix string, opts repo.LoadWebhookDeliveriesOpts) []byte {
+	switch {
+	default:
+		return []byte(prefix)
+	case opts.FilterServiceID != uuid.Nil && opts.FilterWebhookID != uuid.Nil:
+		return fmt.Appendf(nil, "%s%s:%s:", prefix, opts.FilterServiceID, opts.FilterWebhookID)
+	case opts.FilterServiceID != uuid.Nil:
+		return fmt.Appendf(nil, "%s%s:", prefix, opts.FilterServiceID)
+	}
+}
+
+// SaveWebhook handles both Create and Update.
+func (r *Repo) SaveWebhook(ctx context.Context, webhook *model.Webhook) error {
+	if webhook.ID == uuid.Nil {
+		webhook.ID = uuid.New()
+	}
+
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now().UTC().Truncate(time.Microsecond) // MySQL DATETIME(6) precision
+	}
+
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		b, err := json.Marshal(webhook)
+		if err != nil {
+			return fmt.Errorf("marshal webhook: %w", err)
+		}
+
+		err = r.tx.Set(webhookKey(webhook.ServiceID, webhook.ID), b)
+		if err != nil {
+			return fmt.Errorf("repo: set webhook: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *Repo) LoadWebhook(_ context.Context, serviceID, webhookID uuid.UUID) (*model.Webhook, error) {
+	var webhook model.Webhook
+
+	err := r.view(func(txn *badger.Txn) error {
+		item, err := txn.Get(webhookKey(serviceID, webhookID))
+
+		switch {
+		default:
+			return getValue(item, &webhook)
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get webhook: %w", err)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+func (r *Repo) LoadWebhooks(_ context.Context, serviceID uuid.UUID) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+
+	err := r.view(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = fmt.Appendf(nil, "%s%s:", webhookPrefix, serviceID)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var webhook model.Webhook
+
+			err := getValue(it.Item(), &webhook)
+			if err != nil {
+				return err
+			}
+
+			webhooks = append(webhooks, webhook)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	slices.SortStableFunc(webhooks, func(a, b model.Webhook) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return webhooks, nil
+}
+
+func (r *Repo) DeleteWebhook(ctx context.Context, serviceID, webhookID uuid.UUID) error {
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		key := webhookKey(serviceID, webhookID)
+
+		// BadgerDB does not return an error if the key does not exist on Delete.
+		_, err := r.tx.Get(key)
+
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get webhook for deletion: %w", err)
+		}
+
+		err = r.tx.Delete(key)
+		if err != nil {
+			return fmt.Errorf("transaction: delete webhook: %w", err)
+		}
+
+		err = deletePrefix(r.tx, webhookDeliveryKeyPrefix(serviceID, webhookID))
+		if err != nil {
+			return fmt.Errorf("transaction: delete webhook deliveries: %w", err)
+		}
+
+		err = deletePrefix(r.tx, fmt.Appendf(nil, "%s%s:%s:", webhookPendingPrefix, serviceID, webhookID))
+		if err != nil {
+			return fmt.Errorf("transaction: delete pending webhook deliveries: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SaveWebhookDelivery handles both Create and Update.
+func (r *Repo) SaveWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.New()
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond) // MySQL DATETIME(6) precision
+
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = now
+	}
+
+	if delivery.NextAttemptAt.IsZero() {
+		delivery.NextAttemptAt = now
+	}
+
+	delivery.NextAttemptAt = delivery.NextAttemptAt.UTC().Truncate(time.Microsecond)
+	delivery.UpdatedAt = now
+
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		b, err := json.Marshal(delivery)
+		if err != nil {
+			return fmt.Errorf("marshal webhook delivery: %w", err)
+		}
+
+		err = r.tx.Set(webhookDeliveryKey(delivery), b)
+		if err != nil {
+			return fmt.Errorf("repo: set webhook delivery: %w", err)
+		}
+
+		if delivery.Status == model.WebhookDeliveryStatusPending {
+			err = r.tx.Set(webhookPendingKey(delivery), nil)
+		} else {
+			err = r.tx.Delete(webhookPendingKey(delivery))
+		}
+
+		if err != nil {
+			return fmt.Errorf("repo: index pending webhook delivery: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// LoadWebhookDeliveries reads the deliveries of the service or of the service webhook by prefix,
+// only the pending deliveries are read if filtered by the pending status.
+func (r *Repo) LoadWebhookDeliveries(_ context.Context, opts repo.LoadWebhookDeliveriesOpts,
+) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+
+	err := r.view(func(txn *badger.Txn) error {
+		if slices.Equal(opts.FilterStatuses, []model.WebhookDeliveryStatus{model.WebhookDeliveryStatusPending}) {
+			var err error
+
+			deliveries, err = loadPendingWebhookDeliveries(txn, opts)
+
+			return err
+		}
+
+		itOpts := badger.DefaultIteratorOptions
+		itOpts.Prefix = webhookDeliveriesPrefix(webhookDeliveryPrefix, opts)
+
+		it := txn.NewIterator(itOpts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var delivery model.WebhookDelivery
+
+			err := getValue(it.Item(), &delivery)
+			if err != nil {
+				return err
+			}
+
+			if opts.FilterWebhookID != uuid.Nil && delivery.WebhookID != opts.FilterWebhookID {
+				continue
+			}
+
+			if len(opts.FilterStatuses) > 0 && !slices.Contains(opts.FilterStatuses, delivery.Status) {
+				continue
+			}
+
+			deliveries = append(deliveries, delivery)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: db view: %w", err)
+	}
+
+	slices.SortStableFunc(deliveries, func(a, b model.WebhookDelivery) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return deliveries, nil
+}
+
+// loadPendingWebhookDeliveries reads the pending deliveries by the pending index, see webhookPendingKey.
+func loadPendingWebhookDeliveries(txn *badger.Txn, opts repo.LoadWebhookDeliveriesOpts,
+) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+
+	for _, key := range prefixKeys(txn, webhookDeliveriesPrefix(webhookPendingPrefix, opts)) {
+		item, err := txn.Get(append([]byte(webhookDeliveryPrefix), key[len(webhookPendingPrefix):]...))
+		if err != nil {
+			return nil, fmt.Errorf("transaction: get pending webhook delivery: %w", err)
+		}
+
+		var delivery model.WebhookDelivery
+
+		err = getValue(item, &delivery)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.FilterWebhookID != uuid.Nil && delivery.WebhookID != opts.FilterWebhookID {
+			continue
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// ClaimWebhookDelivery moves the next attempt of the delivery, the claim is checked and saved in one transaction.
+func (r *Repo) ClaimWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery, until time.Time) error {
+	now := time.Now().UTC().Truncate(time.Microsecond) // MySQL DATETIME(6) precision
+	until = until.UTC().Truncate(time.Microsecond)
+
+	var stored model.WebhookDelivery
+
+	err := r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		item, err := r.tx.Get(webhookDeliveryKey(delivery))
+
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("transaction: get webhook delivery: %w", err)
+		}
+
+		err = getValue(item, &stored)
+		if err != nil {
+			return err
+		}
+
+		if stored.Status != model.WebhookDeliveryStatusPending || stored.NextAttemptAt.After(now) {
+			return repo.ErrConflict
+		}
+
+		stored.NextAttemptAt = until
+		stored.UpdatedAt = now
+
+		b, err := json.Marshal(stored)
+		if err != nil {
+			return fmt.Errorf("marshal webhook delivery: %w", err)
+		}
+
+		err = r.tx.Set(webhookDeliveryKey(delivery), b)
+		if err != nil {
+			return fmt.Errorf("repo: set webhook delivery: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	delivery.NextAttemptAt = stored.NextAttemptAt
+	delivery.UpdatedAt = stored.UpdatedAt
+
+	return nil
+}
+
+// DeleteWebhookDeliveries deletes the deliveries of all services created before the time, except pending ones.
+func (r *Repo) DeleteWebhookDeliveries(ctx context.Context, before time.Time) error {
+	if r.tx == nil {
+		var keys [][]byte
+
+		err := r.db.View(func(txn *badger.Txn) error {
+			var txErr error
+
+			keys, txErr = finishedWebhookDeliveryKeys(txn, before)
+
+			return txErr
+		})
+		if err != nil {
+			return fmt.Errorf("repo: db view: %w", err)
+		}
+
+		err = writeBatches(r.db, deleteKeysWrites(keys))
+		if err != nil {
+			return fmt.Errorf("repo: delete webhook deliveries: %w", err)
+		}
+
+		return nil
+	}
+
+	return r.ensureTx(ctx, func(_ context.Context, r *Repo) error {
+		keys, err := finishedWebhookDeliveryKeys(r.tx, before)
+		if err != nil {
+			return err
+		}
+
+		for _, write := range deleteKeysWrites(keys) {
+			err = write(r.tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// finishedWebhookDeliveryKeys returns the keys of the deliveries that are not pending, created before the time.
+func finishedWebhookDeliveryKeys(txn *badger.Txn, before time.Time) ([][]byte, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = []byte(webhookDeliveryPrefix)
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	var keys [][]byte
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		var delivery model.WebhookDelivery
+
+		err := getValue(it.Item(), &delivery)
+		if err != nil {
+			return nil, fmt.Errorf("get webhook delivery: %w", err)
+		}
+
+		if delivery.Status != model.WebhookDeliveryStatusPending && delivery.CreatedAt.Before(before) {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+	}
+
+	return keys, nil
+}
+
+// deleteKeysWrites returns the writes deleting the keys, see writeBatches.
+func deleteKeysWrites(keys [][]byte) []func(txn *badger.Txn) error {
+	writes := make([]func(txn *badger.Txn) error, 0, len(keys))
+
+	for _, key := range keys {
+		writes = append(writes, func(txn *badger.Txn) error {
+			err := txn.Delete(key)
+			if err != nil {
+				return fmt.Errorf("delete key '%s': %w", key, err)
+			}
+
+			return nil
+		})
+	}
+
+	return writes
+}
@@ -0,0 +1,329 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"golang.org/x/text/language"
+)
+
+// CopyOpts configures Copy.
+type CopyOpts struct {
+	// OnService is called after each service is copied or skipped, nil to ignore.
+	OnService func(serviceID uuid.UUID, stats CopyStats)
+	// DryRun counts the data to copy without writing to the target repo.
+	DryRun bool
+}
+
+// CopyStats counts the copied data.
+type CopyStats struct {
+	Services     int // copied services
+	Skipped      int // services already copied to the target repo
+	Translations int // copied translations
+	Messages     int // copied messages
+	Revisions    int // copied message revisions
+	Usage        int // copied usage records
+	Webhooks     int // copied webhooks
+}
+
+func (s *CopyStats) add(other CopyStats) {
+	s.Services += other.Services
+	s.Skipped += other.Skipped
+	s.Translations += other.Translations
+	s.Messages += other.Messages
+	s.Revisions += other.Revisions
+	s.Usage += other.Usage
+	s.Webhooks += other.Webhooks
+}
+
+// Copy copies projects, and services with their translations, message revisions, usage and webhooks
+// from one repo to another.
+//
+// Projects are copied first, overwriting the projects of the target repo with the same ID.
+// Each service is copied in a single transaction of the target repo and verified by comparing
+// its translations with the target repo afterwards. Services that the target repo already contains
+// with the same translations and messages are skipped, so an interrupted copy can be resumed by running it again,
+// and services changed since the last copy are copied again.
+// Translations are loaded one at a time, so only one translation of a service is held in memory.
+// Jobs and webhook deliveries are not copied.
+func Copy(ctx context.Context, from, to Repo, opts CopyOpts) (CopyStats, error) {
+	var total CopyStats
+
+	if !opts.DryRun {
+		err := copyProjects(ctx, from, to)
+		if err != nil {
+			return total, err
+		}
+	}
+
+	services, err := from.LoadServices(ctx, LoadServicesOpts{})
+	if err != nil {
+		return total, fmt.Errorf("load services: %w", err)
+	}
+
+	for i := range services {
+		stats, err := copyService(ctx, from, to, &services[i], opts.DryRun)
+		if err != nil {
+			return total, fmt.Errorf("copy service '%s': %w", services[i].ID, err)
+		}
+
+		total.add(stats)
+
+		if opts.OnService != nil {
+			opts.OnService(services[i].ID, stats)
+		}
+	}
+
+	return total, nil
+}
+
+func copyProjects(ctx context.Context, from, to Repo) error {
+	projects, err := from.LoadProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("load projects: %w", err)
+	}
+
+	for i := range projects {
+		err = to.SaveProject(ctx, &projects[i])
+		if err != nil {
+			return fmt.Errorf("save project '%s': %w", projects[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+func copyService(ctx context.Context, from, to Repo, service *model.Service, dryRun bool) (CopyStats, error) {
+	var stats CopyStats
+
+	languages, err := translationLanguages(ctx, from, service.ID)
+	if err != nil {
+		return stats, err
+	}
+
+	usage, err := from.LoadUsage(ctx, service.ID, LoadUsageOpts{})
+	if err != nil {
+		return stats, fmt.Errorf("load usage: %w", err)
+	}
+
+	copied, err := isCopied(ctx, from, to, service.ID, languages)
+	if err != nil {
+		return stats, err
+	}
+
+	if copied {
+		stats.Skipped = 1
+		return stats, nil
+	}
+
+	// Usage is added to the counters of the target repo, it is copied only once.
+	targetUsage, err := to.LoadUsage(ctx, service.ID, LoadUsageOpts{})
+	if err != nil {
+		return stats, fmt.Errorf("load target usage: %w", err)
+	}
+
+	if len(targetUsage) > 0 {
+		usage = nil
+	}
+
+	webhooks, err := from.LoadWebhooks(ctx, service.ID)
+	if err != nil {
+		return stats, fmt.Errorf("load webhooks: %w", err)
+	}
+
+	stats = CopyStats{Services: 1, Usage: len(usage), Webhooks: len(webhooks)}
+
+	if dryRun {
+		for _, lang := range languages {
+			translation, revisions, inErr := loadTranslationWithRevisions(ctx, from, service.ID, lang)
+			if inErr != nil {
+				return CopyStats{}, inErr
+			}
+
+			if translation == nil {
+				continue // Translation was deleted since the languages were loaded.
+			}
+
+			stats.Translations++
+			stats.Messages += len(translation.Messages)
+			stats.Revisions += len(revisions)
+		}
+
+		return stats, nil
+	}
+
+	err = to.Tx(ctx, func(ctx context.Context, r Repo) error {
+		stats.Translations, stats.Messages, stats.Revisions = 0, 0, 0
+
+		// The service and translations overwrite the target repo, e.g. when changed since the last copy.
+		copiedService := *service
+		copiedService.Version = 0
+
+		inErr := r.SaveService(ctx, &copiedService)
+		if inErr != nil {
+			return fmt.Errorf("save service: %w", inErr)
+		}
+
+		for _, lang := range languages {
+			translation, revisions, inErr := loadTranslationWithRevisions(ctx, from, service.ID, lang)
+			if inErr != nil {
+				return inErr
+			}
+
+			if translation == nil {
+				continue // Translation was deleted since the languages were loaded.
+			}
+
+			translation.Version = 0
+
+			inErr = r.SaveTranslation(ctx, service.ID, translation)
+			if inErr != nil {
+				return fmt.Errorf("save translation '%s': %w", lang, inErr)
+			}
+
+			// The revisions recorded by SaveTranslation are replaced with the history of the source repo.
+			inErr = r.ReplaceTranslationRevisions(ctx, service.ID, lang, revisions)
+			if inErr != nil {
+				return fmt.Errorf("save revisions of translation '%s': %w", lang, inErr)
+			}
+
+			stats.Translations++
+			stats.Messages += len(translation.Messages)
+			stats.Revisions += len(revisions)
+		}
+
+		for i := range usage {
+			inErr = r.AddUsage(ctx, &usage[i])
+			if inErr != nil {
+				return fmt.Errorf("add usage: %w", inErr)
+			}
+		}
+
+		for i := range webhooks {
+			inErr = r.SaveWebhook(ctx, &webhooks[i])
+			if inErr != nil {
+				return fmt.Errorf("save webhook '%s': %w", webhooks[i].ID, inErr)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return CopyStats{}, fmt.Errorf("save to target repo: %w", err)
+	}
+
+	// The translations of the source repo may have changed since the languages were loaded.
+	languages, err = translationLanguages(ctx, from, service.ID)
+	if err != nil {
+		return CopyStats{}, err
+	}
+
+	copied, err = isCopied(ctx, from, to, service.ID, languages)
+	if err != nil {
+		return CopyStats{}, err
+	}
+
+	if !copied {
+		return CopyStats{}, errors.New("verify: translations of the target repo differ")
+	}
+
+	return stats, nil
+}
+
+// translationLanguages returns the languages of the service translations, without loading their messages.
+func translationLanguages(ctx context.Context, r Repo, serviceID uuid.UUID) ([]language.Tag, error) {
+	// Translations without selected messages are loaded without messages, at most one message is loaded.
+	translations, err := r.LoadTranslations(ctx, serviceID, LoadTranslationsOpts{Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("load translations: %w", err)
+	}
+
+	languages := make([]language.Tag, 0, len(translations))
+	for _, translation := range translations {
+		languages = append(languages, translation.Language)
+	}
+
+	return languages, nil
+}
+
+// loadTranslation loads the service translation of the language with its messages, nil if it does not exist.
+func loadTranslation(ctx context.Context, r Repo, serviceID uuid.UUID, lang language.Tag,
+) (*model.Translation, error) {
+	translations, err := r.LoadTranslations(ctx, serviceID, LoadTranslationsOpts{FilterLanguages: []language.Tag{lang}})
+	if err != nil {
+		return nil, fmt.Errorf("load translation '%s': %w", lang, err)
+	}
+
+	if len(translations) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &translations[0], nil
+}
+
+// loadTranslationWithRevisions loads the service translation of the language with its messages and
+// message revisions, nil if it does not exist.
+func loadTranslationWithRevisions(ctx context.Context, r Repo, serviceID uuid.UUID, lang language.Tag,
+) (*model.Translation, []model.MessageRevision, error) {
+	translation, err := loadTranslation(ctx, r, serviceID, lang)
+	if err != nil || translation == nil {
+		return nil, nil, err
+	}
+
+	revisions, err := r.LoadTranslationRevisions(ctx, serviceID, lang)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load revisions of translation '%s': %w", lang, err)
+	}
+
+	return translation, revisions, nil
+}
+
+// isCopied checks if the target repo contains the service with the same translations and messages
+// as the source repo, the translations are compared one language at a time.
+func isCopied(ctx context.Context, from, to Repo, serviceID uuid.UUID, languages []language.Tag) (bool, error) {
+	_, err := to.LoadService(ctx, serviceID)
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("load target service: %w", err)
+	}
+
+	targetLanguages, err := translationLanguages(ctx, to, serviceID)
+	if err != nil {
+		return false, fmt.Errorf("target: %w", err)
+	}
+
+	if len(targetLanguages) != len(languages) {
+		return false, nil
+	}
+
+	for _, lang := range languages {
+		translation, err := loadTranslation(ctx, from, serviceID, lang)
+		if err != nil {
+			return false, err
+		}
+
+		target, err := loadTranslation(ctx, to, serviceID, lang)
+		if err != nil {
+			return false, fmt.Errorf("target: %w", err)
+		}
+
+		if translation == nil || target == nil || !translationsEqual(translation, target) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// translationsEqual compares the translations and their messages, ignoring the version.
+func translationsEqual(a, b *model.Translation) bool {
+	return a.Language == b.Language && a.Original == b.Original &&
+		slices.EqualFunc(a.Messages, b.Messages, func(a, b model.Message) bool { return a.Equal(&b) })
+}
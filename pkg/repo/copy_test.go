@@ -0,0 +1,210 @@
+package repo_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/repo/badgerdb"
+	"go.expect.digital/translate/pkg/repo/sqlite"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+)
+
+func Test_Copy(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	from, err := badgerdb.NewRepo(badgerdb.WithDefaultDB())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer from.Close()
+
+	to, err := sqlite.NewRepo(sqlite.WithConf(ctx, &sqlite.Conf{}))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer to.Close()
+
+	project := &model.Project{Name: "Team"}
+
+	err = from.SaveProject(ctx, project)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	services := rand.ModelServices(2)
+	services[0].ProjectID = project.ID
+
+	for _, service := range services {
+		err = from.SaveService(ctx, service)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		for _, translation := range []*model.Translation{
+			rand.ModelTranslation(3, nil, rand.WithLanguage(language.English), rand.WithOriginal(true)),
+			rand.ModelTranslation(3, nil, rand.WithLanguage(language.German), rand.WithOriginal(false)),
+			rand.ModelTranslation(0, nil, rand.WithLanguage(language.Latvian), rand.WithOriginal(false)),
+		} {
+			err = from.SaveTranslation(ctx, service.ID, translation)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		err = from.SaveWebhook(ctx, &model.Webhook{
+			ServiceID: service.ID,
+			URL:       "https://example.com/hook",
+			Secret:    "secret",
+			Events:    []model.WebhookEvent{model.WebhookEventJobFinished},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = from.AddUsage(ctx, &model.Usage{
+			ServiceID:  service.ID,
+			Date:       model.UsageDate(time.Now()),
+			Translator: "PseudoTranslate",
+			Characters: 10,
+			Requests:   1,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	tests := []struct {
+		name      string
+		wantStats repo.CopyStats
+		dryRun    bool
+	}{
+		{
+			name:   "dry run",
+			dryRun: true,
+			wantStats: repo.CopyStats{
+				Services: 2, Translations: 6, Messages: 12, Revisions: 12, Usage: 2, Webhooks: 2,
+			},
+		},
+		{
+			name: "copy",
+			wantStats: repo.CopyStats{
+				Services: 2, Translations: 6, Messages: 12, Revisions: 12, Usage: 2, Webhooks: 2,
+			},
+		},
+		{
+			name:      "resume",
+			wantStats: repo.CopyStats{Skipped: 2},
+		},
+	}
+
+	// Steps depend on each other, run sequentially.
+	for _, test := range tests {
+		stats, err := repo.Copy(ctx, from, to, repo.CopyOpts{DryRun: test.dryRun})
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			return
+		}
+
+		if stats != test.wantStats {
+			t.Errorf("%s: want stats %+v, got %+v", test.name, test.wantStats, stats)
+		}
+	}
+
+	// A service changed since the last copy is copied again, its usage only once.
+	changed, err := from.LoadTranslations(ctx, services[1].ID,
+		repo.LoadTranslationsOpts{FilterLanguages: []language.Tag{language.German}})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	changed[0].Messages[0].Message = "{Changed}"
+
+	err = from.SaveTranslation(ctx, services[1].ID, &changed[0])
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	stats, err := repo.Copy(ctx, from, to, repo.CopyOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := repo.CopyStats{Services: 1, Skipped: 1, Translations: 3, Messages: 6, Revisions: 7, Webhooks: 1}
+	if stats != want {
+		t.Errorf("changed: want stats %+v, got %+v", want, stats)
+	}
+
+	// The message revisions are copied as recorded by the source repo.
+	wantRevisions, err := from.LoadTranslationRevisions(ctx, services[1].ID, language.German)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	gotRevisions, err := to.LoadTranslationRevisions(ctx, services[1].ID, language.German)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(wantRevisions, gotRevisions) {
+		t.Errorf("want revisions %v, got %v", wantRevisions, gotRevisions)
+	}
+
+	// The project is copied with its service.
+	projectServices, err := to.LoadServices(ctx, repo.LoadServicesOpts{FilterProjectID: project.ID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(projectServices) != 1 || projectServices[0].ID != services[0].ID {
+		t.Errorf("want service %s in project, got %v", services[0].ID, projectServices)
+	}
+
+	copied, err := to.LoadServices(ctx, repo.LoadServicesOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, service := range copied {
+		usage, err := to.LoadUsage(ctx, service.ID, repo.LoadUsageOpts{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(usage) != 1 || usage[0].Characters != 10 {
+			t.Errorf("want usage copied once, got %v", usage)
+		}
+
+		webhooks, err := to.LoadWebhooks(ctx, service.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(webhooks) != 1 || webhooks[0].Secret != "secret" {
+			t.Errorf("want webhook copied, got %v", webhooks)
+		}
+	}
+}
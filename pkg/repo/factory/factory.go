@@ -5,17 +5,20 @@ import (
 	"fmt"
 	"strings"
 
+	"go.expect.digital/translate/migrate"
 	"go.expect.digital/translate/pkg/repo"
 	"go.expect.digital/translate/pkg/repo/badgerdb"
 	"go.expect.digital/translate/pkg/repo/mysql"
+	"go.expect.digital/translate/pkg/repo/sqlite"
 )
 
 const (
 	BadgerDB = "badgerdb"
 	MySQL    = "mysql"
+	SQLite   = "sqlite"
 )
 
-var SupportedDBs = []string{MySQL, BadgerDB}
+var SupportedDBs = []string{MySQL, BadgerDB, SQLite}
 
 // Usage returns a string describing the supported databases for CLI.
 func Usage() string {
@@ -34,6 +37,8 @@ func NewRepo(ctx context.Context, db string) (repo.Repo, error) { //nolint:iretu
 		repo, err = mysql.NewRepo(mysql.WithDefaultDB(ctx))
 	case SupportedDBs[1]: // badgerdb
 		repo, err = badgerdb.NewRepo(badgerdb.WithDefaultDB())
+	case SupportedDBs[2]: // sqlite
+		repo, err = sqlite.NewRepo(sqlite.WithDefaultDB(ctx))
 	default:
 		return nil, fmt.Errorf("unsupported database: '%s', list of supported db: %s", db, strings.Join(SupportedDBs, ", "))
 	}
@@ -44,3 +49,28 @@ func NewRepo(ctx context.Context, db string) (repo.Repo, error) { //nolint:iretu
 
 	return repo, nil
 }
+
+// NewMigrator creates a new schema migrator based on the provided database string.
+func NewMigrator(ctx context.Context, db string) (migrate.Migrator, error) { //nolint:ireturn
+	var (
+		migrator migrate.Migrator
+		err      error
+	)
+
+	switch v := strings.TrimSpace(strings.ToLower(db)); v {
+	case MySQL:
+		migrator, err = mysql.NewMigrator(ctx, mysql.DefaultConf())
+	case BadgerDB:
+		migrator, err = badgerdb.NewMigrator()
+	case SQLite:
+		migrator, err = sqlite.NewMigrator(ctx, sqlite.DefaultConf())
+	default:
+		return nil, fmt.Errorf("unsupported database: '%s', list of supported db: %s", db, strings.Join(SupportedDBs, ", "))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("new '%s' migrator: %w", db, err)
+	}
+
+	return migrator, nil
+}
@@ -14,6 +14,7 @@ import (
 	"go.expect.digital/translate/pkg/repo"
 	"go.expect.digital/translate/pkg/repo/badgerdb"
 	"go.expect.digital/translate/pkg/repo/mysql"
+	"go.expect.digital/translate/pkg/repo/sqlite"
 	"go.expect.digital/translate/pkg/testutil"
 )
 
@@ -44,6 +45,18 @@ func initBadgerDB() error {
 	return nil
 }
 
+// initSQLite creates a new SQLite repo and adds it to the repos map.
+func initSQLite(ctx context.Context) error {
+	repo, err := sqlite.NewRepo(sqlite.WithDefaultDB(ctx))
+	if err != nil {
+		return fmt.Errorf("create new sqlite repo: %w", err)
+	}
+
+	repos["SQLite"] = repo
+
+	return nil
+}
+
 func TestMain(m *testing.M) {
 	os.Exit(testMain(m))
 }
@@ -73,6 +86,13 @@ func testMain(m *testing.M) int {
 		os.Exit(1)
 	}
 
+	// SQLite
+	err = initSQLite(ctx)
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+
 	// Close all connections
 	for _, repo := range repos {
 		defer repo.Close()
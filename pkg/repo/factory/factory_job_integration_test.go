@@ -0,0 +1,343 @@
+//go:build integration
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil"
+	"go.expect.digital/translate/pkg/testutil/rand"
+)
+
+// prepareJob saves a random service and returns a pending job for it.
+func prepareJob(ctx context.Context, t *testing.T, repository repo.Repo) *model.Job {
+	t.Helper()
+
+	service := rand.ModelService()
+
+	err := repository.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return nil
+	}
+
+	languages := rand.Languages(3)
+
+	return &model.Job{
+		ServiceID: service.ID,
+		Languages: languages,
+		Total:     len(languages),
+		Status:    model.JobStatusPending,
+	}
+}
+
+func Test_SaveJob(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, subTest testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		job := prepareJob(testCtx, t, repository)
+
+		subTest("Create", func(ctx context.Context, t *testing.T) { //nolint:thelper
+			err := repository.SaveJob(ctx, job)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			got, err := repository.LoadJob(ctx, job.ID)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !reflect.DeepEqual(job, got) {
+				t.Errorf("\nwant %v\ngot  %v", job, got)
+			}
+		})
+
+		subTest("Update", func(ctx context.Context, t *testing.T) { //nolint:thelper
+			job.Status = model.JobStatusFailed
+			job.Done = 1
+			job.Error = "translate failed"
+
+			err := repository.SaveJob(ctx, job)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			got, err := repository.LoadJob(ctx, job.ID)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !reflect.DeepEqual(job, got) {
+				t.Errorf("\nwant %v\ngot  %v", job, got)
+			}
+		})
+	})
+}
+
+func Test_LoadJobNotFound(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(_ *testing.T, repository repo.Repo, subTest testutil.SubtestFn) {
+		subTest("Not found", func(ctx context.Context, t *testing.T) { //nolint:thelper
+			_, err := repository.LoadJob(ctx, uuid.New())
+			if !errors.Is(err, repo.ErrNotFound) {
+				t.Errorf("want '%v', got '%v'", repo.ErrNotFound, err)
+			}
+		})
+	})
+}
+
+func Test_LoadJobs(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, subTest testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		pending := prepareJob(testCtx, t, repository)
+
+		err := repository.SaveJob(testCtx, pending)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		succeeded := &model.Job{ServiceID: pending.ServiceID, Status: model.JobStatusSucceeded}
+
+		err = repository.SaveJob(testCtx, succeeded)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		tests := []struct {
+			name string
+			opts repo.LoadJobsOpts
+			want []uuid.UUID
+		}{
+			{
+				name: "All service jobs",
+				opts: repo.LoadJobsOpts{FilterServiceID: pending.ServiceID},
+				want: []uuid.UUID{pending.ID, succeeded.ID},
+			},
+			{
+				name: "Service jobs by status",
+				opts: repo.LoadJobsOpts{
+					FilterServiceID: pending.ServiceID,
+					FilterStatuses:  []model.JobStatus{model.JobStatusSucceeded},
+				},
+				want: []uuid.UUID{succeeded.ID},
+			},
+			{
+				name: "Service without jobs",
+				opts: repo.LoadJobsOpts{FilterServiceID: uuid.New()},
+				want: nil,
+			},
+		}
+
+		for _, test := range tests {
+			subTest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
+				jobs, err := repository.LoadJobs(ctx, test.opts)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				var got []uuid.UUID
+				for _, job := range jobs {
+					got = append(got, job.ID)
+				}
+
+				if !reflect.DeepEqual(test.want, got) {
+					t.Errorf("want jobs %v, got %v", test.want, got)
+				}
+			})
+		}
+	})
+}
+
+func Test_ClaimJob(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		job := prepareJob(testCtx, t, repository)
+
+		err := repository.SaveJob(testCtx, job)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		owner, other := uuid.New(), uuid.New()
+
+		// Each step claims the job claimed by the previous step.
+		tests := []struct {
+			wantErr    error
+			leaseUntil time.Time
+			name       string
+			owner      uuid.UUID
+		}{
+			{
+				name:       "Pending",
+				owner:      owner,
+				leaseUntil: time.Now().Add(time.Minute),
+			},
+			{
+				name:       "Claimed by other",
+				owner:      other,
+				leaseUntil: time.Now().Add(time.Minute),
+				wantErr:    repo.ErrConflict,
+			},
+			{
+				name:       "Extended by owner",
+				owner:      owner,
+				leaseUntil: time.Now().Add(-time.Minute),
+			},
+			{
+				name:       "Lease expired",
+				owner:      other,
+				leaseUntil: time.Now().Add(time.Minute),
+			},
+		}
+
+		for _, test := range tests {
+			err = repository.ClaimJob(testCtx, job, test.owner, test.leaseUntil)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("%s: want error '%v', got '%v'", test.name, test.wantErr, err)
+				return
+			}
+
+			got, err := repository.LoadJob(testCtx, job.ID)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !reflect.DeepEqual(job, got) {
+				t.Errorf("%s:\nwant %v\ngot  %v", test.name, job, got)
+			}
+
+			if test.wantErr == nil && (got.Status != model.JobStatusRunning || got.Owner != test.owner) {
+				t.Errorf("%s: want job running by %s, got %v", test.name, test.owner, got)
+			}
+		}
+
+		// Finished jobs are not claimed.
+		job.Status = model.JobStatusSucceeded
+
+		err = repository.SaveJob(testCtx, job)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = repository.ClaimJob(testCtx, job, job.Owner, time.Now().Add(time.Minute))
+		if !errors.Is(err, repo.ErrConflict) {
+			t.Errorf("want error '%v', got '%v'", repo.ErrConflict, err)
+		}
+
+		err = repository.ClaimJob(testCtx, &model.Job{ID: uuid.New()}, owner, time.Now().Add(time.Minute))
+		if !errors.Is(err, repo.ErrNotFound) {
+			t.Errorf("want error '%v', got '%v'", repo.ErrNotFound, err)
+		}
+	})
+}
+
+func Test_LoadJobsPage(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		job := prepareJob(testCtx, t, repository)
+		createdAt := time.Now().UTC().Truncate(time.Microsecond)
+
+		// Jobs created at the same time are ordered by ID.
+		jobs := make([]model.Job, 3)
+		for i := range jobs {
+			jobs[i] = *job
+			jobs[i].CreatedAt = createdAt
+
+			err := repository.SaveJob(testCtx, &jobs[i])
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		slices.SortFunc(jobs, func(a, b model.Job) int { return bytes.Compare(a.ID[:], b.ID[:]) })
+
+		got, err := repository.LoadJobs(testCtx, repo.LoadJobsOpts{
+			FilterServiceID: job.ServiceID,
+			After:           repo.JobKey{CreatedAt: jobs[0].CreatedAt, ID: jobs[0].ID},
+			Limit:           1,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(got) != 1 || got[0].ID != jobs[1].ID {
+			t.Errorf("want job %s, got %v", jobs[1].ID, got)
+		}
+	})
+}
+
+func Test_DeleteJobs(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		job := prepareJob(testCtx, t, repository)
+
+		statuses := []model.JobStatus{
+			model.JobStatusPending, model.JobStatusRunning, model.JobStatusSucceeded, model.JobStatusFailed,
+		}
+
+		for _, jobStatus := range statuses {
+			saved := *job
+			saved.Status = jobStatus
+
+			err := repository.SaveJob(testCtx, &saved)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		err := repository.DeleteJobs(testCtx, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		got, err := repository.LoadJobs(testCtx, repo.LoadJobsOpts{FilterServiceID: job.ServiceID})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Pending and running jobs are kept.
+		if len(got) != 2 || got[0].Finished() || got[1].Finished() {
+			t.Errorf("want pending and running jobs, got %v", got)
+		}
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"errors"
 	"reflect"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/google/uuid"
@@ -184,6 +186,197 @@ func Test_SaveTranslationUpdate(t *testing.T) {
 	})
 }
 
+func Test_SaveTranslationRemovedMessages(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		service := prepareService(testCtx, t, repository)
+		wantTranslation := rand.ModelTranslation(3, nil)
+
+		err := repository.SaveTranslation(testCtx, service.ID, wantTranslation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Remove the last message, mark another as obsolete, and add a message first.
+		wantTranslation.Messages = append([]model.Message{*rand.ModelMessage()}, wantTranslation.Messages[:2]...)
+		wantTranslation.Messages[2].Status = model.MessageStatusObsolete
+
+		err = repository.SaveTranslation(testCtx, service.ID, wantTranslation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Assure that the removed message is deleted, and the order of the messages is kept
+
+		gotTranslation, err := repository.LoadTranslations(testCtx, service.ID,
+			repo.LoadTranslationsOpts{FilterLanguages: []language.Tag{wantTranslation.Language}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !reflect.DeepEqual(*wantTranslation, gotTranslation[0]) {
+			t.Errorf("\nwant %v\ngot  %v", *wantTranslation, gotTranslation[0])
+		}
+	})
+}
+
+func Test_SaveTranslationUnchanged(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		service := prepareService(testCtx, t, repository)
+		translation := rand.ModelTranslation(3, nil)
+
+		err := repository.SaveTranslation(testCtx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Saving an unchanged translation keeps the version,
+		// moving a message or changing the original flag bumps it.
+		steps := []struct {
+			change      func(translation *model.Translation)
+			name        string
+			wantVersion int
+		}{
+			{
+				name:        "unchanged",
+				change:      func(*model.Translation) {},
+				wantVersion: 1,
+			},
+			{
+				name: "moved message",
+				change: func(translation *model.Translation) {
+					translation.Messages[0], translation.Messages[1] = translation.Messages[1], translation.Messages[0]
+				},
+				wantVersion: 2,
+			},
+			{
+				name: "original",
+				change: func(translation *model.Translation) {
+					translation.Original = !translation.Original
+				},
+				wantVersion: 3,
+			},
+		}
+
+		for _, step := range steps {
+			step.change(translation)
+
+			err = repository.SaveTranslation(testCtx, service.ID, translation)
+			if err != nil {
+				t.Errorf("%s: %v", step.name, err)
+				return
+			}
+
+			if translation.Version != step.wantVersion {
+				t.Errorf("%s: want version %d, got %d", step.name, step.wantVersion, translation.Version)
+			}
+
+			got, err := repository.LoadTranslations(testCtx, service.ID,
+				repo.LoadTranslationsOpts{FilterLanguages: []language.Tag{translation.Language}})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !reflect.DeepEqual(*translation, got[0]) {
+				t.Errorf("%s:\nwant %v\ngot  %v", step.name, *translation, got[0])
+			}
+		}
+	})
+}
+
+func Test_SaveMessage(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		service := prepareService(testCtx, t, repository)
+		wantTranslation := rand.ModelTranslation(3, nil)
+
+		// Saving a message of a missing translation fails.
+		err := repository.SaveMessage(testCtx, service.ID, wantTranslation.Language, rand.ModelMessage())
+		if !errors.Is(err, repo.ErrNotFound) {
+			t.Errorf("want error '%v', got '%v'", repo.ErrNotFound, err)
+		}
+
+		err = repository.SaveTranslation(testCtx, service.ID, wantTranslation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Update the second message, and add a message.
+		wantTranslation.Messages[1].Message = gofakeit.Sentence()
+		wantTranslation.Messages = append(wantTranslation.Messages, *rand.ModelMessage())
+
+		for _, i := range []int{1, 3} {
+			err = repository.SaveMessage(testCtx, service.ID, wantTranslation.Language, &wantTranslation.Messages[i])
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		// Assure that the updated message keeps its place, the added message is the last one,
+		// and the version is incremented for each message.
+		gotTranslations, err := repository.LoadTranslations(testCtx, service.ID,
+			repo.LoadTranslationsOpts{FilterLanguages: []language.Tag{wantTranslation.Language}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		wantTranslation.Version += 2
+
+		if !reflect.DeepEqual(*wantTranslation, gotTranslations[0]) {
+			t.Errorf("\nwant %v\ngot  %v", *wantTranslation, gotTranslations[0])
+		}
+
+		gotMessage, err := repository.LoadMessage(testCtx, service.ID, wantTranslation.Language,
+			wantTranslation.Messages[3].ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !reflect.DeepEqual(wantTranslation.Messages[3], *gotMessage) {
+			t.Errorf("\nwant %v\ngot  %v", wantTranslation.Messages[3], *gotMessage)
+		}
+
+		_, err = repository.LoadMessage(testCtx, service.ID, wantTranslation.Language, "missing")
+		if !errors.Is(err, repo.ErrNotFound) {
+			t.Errorf("want error '%v', got '%v'", repo.ErrNotFound, err)
+		}
+
+		// Assure that the change of the updated message is recorded.
+		revisions, err := repository.LoadMessageRevisions(testCtx, service.ID, wantTranslation.Language,
+			wantTranslation.Messages[1].ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(revisions) != 2 || revisions[0].New.Message != wantTranslation.Messages[1].Message {
+			t.Errorf("want the update as the latest of 2 revisions, got %v", revisions)
+		}
+	})
+}
+
 func Test_LoadTranslation(t *testing.T) {
 	t.Parallel()
 
@@ -324,3 +517,509 @@ func Test_LoadAllTranslationsForService(t *testing.T) {
 		}
 	})
 }
+
+func Test_LoadTranslationWithoutMessages(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		ctx, _ := testutil.Trace(t)
+
+		// Prepare
+		service := prepareService(ctx, t, repository)
+		translation := rand.ModelTranslation(0, nil, rand.WithOriginal(true))
+
+		err := repository.SaveTranslation(ctx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		gotTranslations, err := repository.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(gotTranslations) != 1 ||
+			gotTranslations[0].Language != translation.Language ||
+			!gotTranslations[0].Original ||
+			len(gotTranslations[0].Messages) != 0 {
+			t.Errorf("want original translation '%s' without messages, got %v", translation.Language, gotTranslations)
+		}
+	})
+}
+
+func Test_DeleteTranslation(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, subtest testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		service := prepareService(testCtx, t, repository)
+		languages := rand.Languages(2)
+
+		for _, lang := range languages {
+			err := repository.SaveTranslation(testCtx, service.ID, rand.ModelTranslation(3, nil, rand.WithLanguage(lang)))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		tests := []struct {
+			wantErr   error
+			name      string
+			language  language.Tag
+			serviceID uuid.UUID
+		}{
+			{
+				name:      "All OK",
+				serviceID: service.ID,
+				language:  languages[0],
+			},
+			{
+				name:      "Nonexistent language",
+				serviceID: service.ID,
+				language:  language.Make("x-none"),
+				wantErr:   repo.ErrNotFound,
+			},
+			{
+				name:      "Nonexistent service",
+				serviceID: uuid.New(),
+				language:  languages[1],
+				wantErr:   repo.ErrNotFound,
+			},
+		}
+
+		for _, test := range tests {
+			subtest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
+				err := repository.DeleteTranslation(ctx, test.serviceID, test.language)
+				if !errors.Is(err, test.wantErr) {
+					t.Errorf("want error '%s', got '%s'", test.wantErr, err)
+					return
+				}
+
+				if test.wantErr != nil {
+					return
+				}
+
+				// Other translations of the service are kept.
+				gotTranslations, err := repository.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if gotTranslations.HasLanguage(languages[0]) || !gotTranslations.HasLanguage(languages[1]) {
+					t.Errorf("want only '%s' translation, got %v", languages[1], gotTranslations)
+				}
+			})
+		}
+	})
+}
+
+// Test_MessageRoundTrip asserts that every model.Message field is saved and loaded identically by all repos.
+func Test_MessageRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	qualityScore := 0.75
+
+	full := model.Message{
+		ID:           "{count} file",
+		PluralID:     "{count} files",
+		Message:      "{{Hello, {$name}!}}",
+		Description:  "Greeting",
+		StatusReason: "low quality score",
+		Positions:    model.Positions{"src/config.go:10", "src/config.go:20"},
+		Status:       model.MessageStatusFuzzy,
+		QualityScore: &qualityScore,
+	}
+
+	// A new model.Message field must be set above, so that it is checked to round-trip on all repos.
+	v := reflect.ValueOf(full)
+	for i := range v.NumField() {
+		if v.Field(i).IsZero() {
+			t.Errorf("want model.Message field '%s' set", v.Type().Field(i).Name)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		message model.Message
+	}{
+		{
+			name:    "All fields",
+			message: full,
+		},
+		{
+			name:    "Zero values",
+			message: model.Message{ID: "Hello", Message: "{{Hello}}"},
+		},
+	}
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, subtest testutil.SubtestFn) { //nolint:thelper
+		for _, test := range tests {
+			subtest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
+				service := prepareService(ctx, t, repository)
+				want := model.Translation{Language: language.English, Messages: []model.Message{test.message}}
+
+				err := repository.SaveTranslation(ctx, service.ID, &want)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				got, err := repository.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if len(got) != 1 || !reflect.DeepEqual(want, got[0]) {
+					t.Errorf("\nwant %v\ngot  %v", want, got)
+				}
+			})
+		}
+	})
+}
+
+func Test_LoadTranslationsFilter(t *testing.T) {
+	t.Parallel()
+
+	lowScore, highScore := 0.3, 0.9
+
+	messages := []model.Message{
+		{ID: "a.title", Message: "Hello World", Status: model.MessageStatusTranslated, QualityScore: &highScore},
+		{ID: "a.body", Message: "100% hello_", Status: model.MessageStatusFuzzy, QualityScore: &lowScore},
+		{ID: "b.title", Message: "Bye", Status: model.MessageStatusUntranslated},
+		{ID: "A.title", Message: "Upper", Status: model.MessageStatusTranslated},
+		{ID: "c.title", Message: "Ärger im Café", Status: model.MessageStatusTranslated},
+	}
+
+	tests := []struct {
+		want map[string][]string // message IDs by language, sorted
+		name string
+		opts repo.LoadTranslationsOpts
+	}{
+		{
+			name: "No filters",
+			want: map[string][]string{
+				"en": {"A.title", "a.body", "a.title", "b.title", "c.title"},
+				"de": {"A.title", "a.body", "a.title", "b.title", "c.title"},
+			},
+		},
+		{
+			name: "Original",
+			opts: repo.LoadTranslationsOpts{FilterOriginal: true, FilterMessageIDs: []string{"b.title"}},
+			want: map[string][]string{"en": {"b.title"}},
+		},
+		{
+			name: "Message IDs prefix, case-sensitive",
+			opts: repo.LoadTranslationsOpts{FilterLanguages: []language.Tag{language.German}, FilterMessageIDPrefix: "a."},
+			want: map[string][]string{"de": {"a.body", "a.title"}},
+		},
+		{
+			name: "Message IDs prefix with wildcards",
+			opts: repo.LoadTranslationsOpts{FilterMessageIDPrefix: "a_"},
+			want: map[string][]string{"en": nil, "de": nil},
+		},
+		{
+			name: "Statuses",
+			opts: repo.LoadTranslationsOpts{
+				FilterStatuses: []model.MessageStatus{model.MessageStatusFuzzy, model.MessageStatusUntranslated},
+			},
+			want: map[string][]string{"en": {"a.body", "b.title"}, "de": {"a.body", "b.title"}},
+		},
+		{
+			name: "Text, case-insensitive",
+			opts: repo.LoadTranslationsOpts{FilterText: "HELLO"},
+			want: map[string][]string{"en": {"a.body", "a.title"}, "de": {"a.body", "a.title"}},
+		},
+		{
+			name: "Text with wildcards",
+			opts: repo.LoadTranslationsOpts{FilterText: "o_"},
+			want: map[string][]string{"en": {"a.body"}, "de": {"a.body"}},
+		},
+		{
+			name: "Text, case-insensitive beyond ASCII",
+			opts: repo.LoadTranslationsOpts{FilterText: "äRGER IM"},
+			want: map[string][]string{"en": {"c.title"}, "de": {"c.title"}},
+		},
+		{
+			name: "Text, accent-sensitive",
+			opts: repo.LoadTranslationsOpts{FilterText: "cafe"},
+			want: map[string][]string{"en": nil, "de": nil},
+		},
+		{
+			name: "Max quality score",
+			opts: repo.LoadTranslationsOpts{FilterMaxQualityScore: &lowScore},
+			want: map[string][]string{"en": {"a.body"}, "de": {"a.body"}},
+		},
+		{
+			name: "No matching messages",
+			opts: repo.LoadTranslationsOpts{FilterMessageIDs: []string{"missing"}},
+			want: map[string][]string{"en": nil, "de": nil},
+		},
+		{
+			name: "After and limit",
+			opts: repo.LoadTranslationsOpts{
+				After: repo.MessageKey{Language: language.German, ID: "a.title"},
+				Limit: 3,
+			},
+			want: map[string][]string{"en": {"A.title"}, "de": {"b.title", "c.title"}},
+		},
+	}
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, subtest testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		service := prepareService(testCtx, t, repository)
+
+		for _, translation := range []model.Translation{
+			{Language: language.English, Original: true, Messages: messages},
+			{Language: language.German, Messages: messages},
+		} {
+			err := repository.SaveTranslation(testCtx, service.ID, &translation)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		for _, test := range tests {
+			subtest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
+				translations, err := repository.LoadTranslations(ctx, service.ID, test.opts)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				got := make(map[string][]string, len(translations))
+
+				for _, translation := range translations {
+					var ids []string
+
+					for _, msg := range translation.Messages {
+						ids = append(ids, msg.ID)
+					}
+
+					slices.Sort(ids)
+					got[translation.Language.String()] = ids
+				}
+
+				if !reflect.DeepEqual(test.want, got) {
+					t.Errorf("\nwant %v\ngot  %v", test.want, got)
+				}
+			})
+		}
+	})
+}
+
+func Test_LoadMessageRevisions(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		service := prepareService(testCtx, t, repository)
+		translation := rand.ModelTranslation(2, nil, rand.WithLanguage(language.Latvian))
+		first := translation.Messages[0]
+
+		// Every save is a separate change, the messages are added, then the first message is changed twice.
+		for i, actor := range []string{"", "alice", "bob"} {
+			if i > 0 {
+				translation.Messages[0].Message = first.Message + strings.Repeat("!", i)
+				translation.Messages[0].Status = model.MessageStatusFuzzy
+			}
+
+			err := repository.SaveTranslation(repo.WithActor(testCtx, actor), service.ID, translation)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		// Saving unchanged messages records no revisions.
+		err := repository.SaveTranslation(testCtx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		revisions, err := repository.LoadMessageRevisions(testCtx, service.ID, language.Latvian, first.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(revisions) != 3 {
+			t.Errorf("want 3 revisions, got %d", len(revisions))
+			return
+		}
+
+		// Newest first.
+		if revisions[0].Actor != "bob" || revisions[1].Actor != "alice" || revisions[2].Actor != "" {
+			t.Errorf("want revisions by bob, alice and unknown actor, got %v", revisions)
+		}
+
+		want := model.MessageState{Message: first.Message, Status: first.Status}
+
+		if revisions[2].Old != nil || revisions[2].New != want {
+			t.Errorf("want added message %v, got %v", want, revisions[2])
+		}
+
+		if revisions[1].Old == nil || *revisions[1].Old != want {
+			t.Errorf("want changed message %v, got %v", want, revisions[1].Old)
+		}
+
+		if revisions[0].New.Message != translation.Messages[0].Message || revisions[0].Language != language.Latvian ||
+			revisions[0].MessageID != first.ID || revisions[0].ID == uuid.Nil || revisions[0].CreatedAt.IsZero() {
+			t.Errorf("want latest message %v, got %v", translation.Messages[0], revisions[0])
+		}
+
+		// Revisions are deleted with the translation.
+		err = repository.DeleteTranslation(testCtx, service.ID, language.Latvian)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		revisions, err = repository.LoadMessageRevisions(testCtx, service.ID, language.Latvian, first.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(revisions) != 0 {
+			t.Errorf("want no revisions of deleted translation, got %d", len(revisions))
+		}
+	})
+}
+
+func Test_ReplaceTranslationRevisions(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		service := prepareService(testCtx, t, repository)
+		translation := rand.ModelTranslation(2, nil, rand.WithLanguage(language.Latvian))
+
+		// Replacing revisions of a missing translation fails.
+		err := repository.ReplaceTranslationRevisions(testCtx, service.ID, language.Latvian, nil)
+		if !errors.Is(err, repo.ErrNotFound) {
+			t.Errorf("want error '%v', got '%v'", repo.ErrNotFound, err)
+		}
+
+		err = repository.SaveTranslation(testCtx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// The revisions of a backup, the second message changed after it was added.
+		createdAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+		want := []model.MessageRevision{
+			{
+				ID:        uuid.New(),
+				Language:  language.Latvian,
+				MessageID: translation.Messages[1].ID,
+				New:       model.MessageState{Message: "added", Status: model.MessageStatusUntranslated},
+				CreatedAt: createdAt,
+			},
+			{
+				ID:        uuid.New(),
+				Language:  language.Latvian,
+				MessageID: translation.Messages[0].ID,
+				New:       model.MessageState{Message: "added", Status: model.MessageStatusFuzzy},
+				Actor:     "alice",
+				CreatedAt: createdAt.Add(time.Minute),
+			},
+			{
+				ID:        uuid.New(),
+				Language:  language.Latvian,
+				MessageID: translation.Messages[1].ID,
+				Old:       &model.MessageState{Message: "added", Status: model.MessageStatusUntranslated},
+				New:       model.MessageState{Message: "changed", Status: model.MessageStatusTranslated},
+				Actor:     "bob",
+				CreatedAt: createdAt.Add(2 * time.Minute),
+			},
+		}
+
+		err = repository.ReplaceTranslationRevisions(testCtx, service.ID, language.Latvian, want)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// The revisions recorded by SaveTranslation are replaced, oldest first.
+		got, err := repository.LoadTranslationRevisions(testCtx, service.ID, language.Latvian)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want revisions %v, got %v", want, got)
+		}
+	})
+}
+
+func Test_DeleteMessageRevisions(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		service := prepareService(testCtx, t, repository)
+		translation := rand.ModelTranslation(1, nil, rand.WithLanguage(language.Latvian))
+
+		err := repository.SaveTranslation(testCtx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		old := model.MessageRevision{
+			ID:        uuid.New(),
+			Language:  language.Latvian,
+			MessageID: translation.Messages[0].ID,
+			New:       model.MessageState{Message: "old", Status: model.MessageStatusUntranslated},
+			CreatedAt: now.Add(-48 * time.Hour),
+		}
+		recent := model.MessageRevision{
+			ID:        uuid.New(),
+			Language:  language.Latvian,
+			MessageID: translation.Messages[0].ID,
+			Old:       &old.New,
+			New:       model.MessageState{Message: "recent", Status: model.MessageStatusTranslated},
+			CreatedAt: now.Add(-time.Hour),
+		}
+
+		err = repository.ReplaceTranslationRevisions(testCtx, service.ID, language.Latvian,
+			[]model.MessageRevision{old, recent})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Only the revisions created before the time are deleted.
+		err = repository.DeleteMessageRevisions(testCtx, now.Add(-24*time.Hour))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		got, err := repository.LoadTranslationRevisions(testCtx, service.ID, language.Latvian)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if want := []model.MessageRevision{recent}; !reflect.DeepEqual(want, got) {
+			t.Errorf("want revisions %v, got %v", want, got)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+//go:build integration
+
+package factory
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil"
+	"go.expect.digital/translate/pkg/testutil/rand"
+)
+
+func Test_SaveProject(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		project := &model.Project{Name: gofakeit.Company()}
+
+		err := repository.SaveProject(testCtx, project)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Update
+		project.Name = gofakeit.Company()
+
+		err = repository.SaveProject(testCtx, project)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		got, err := repository.LoadProject(testCtx, project.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !reflect.DeepEqual(project, got) {
+			t.Errorf("want project %v, got %v", project, got)
+		}
+
+		// The default project is created with the database.
+		projects, err := repository.LoadProjects(testCtx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		for _, id := range []uuid.UUID{model.DefaultProjectID, project.ID} {
+			if !slices.ContainsFunc(projects, func(p model.Project) bool { return p.ID == id }) {
+				t.Errorf("want projects %v to contain %s", projects, id)
+			}
+		}
+
+		_, err = repository.LoadProject(testCtx, uuid.New())
+		if !errors.Is(err, repo.ErrNotFound) {
+			t.Errorf("want error '%s', got '%s'", repo.ErrNotFound, err)
+		}
+	})
+}
+
+func Test_LoadServicesByProject(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		project := &model.Project{Name: gofakeit.Company()}
+
+		err := repository.SaveProject(testCtx, project)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		service := rand.ModelService(rand.WithProjectID(project.ID))
+		defaultService := rand.ModelService()
+
+		for _, s := range []*model.Service{service, defaultService} {
+			err = repository.SaveService(testCtx, s)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		// Test
+		if defaultService.ProjectID != model.DefaultProjectID {
+			t.Errorf("want service saved to the default project, got %s", defaultService.ProjectID)
+		}
+
+		got, err := repository.LoadServices(testCtx, repo.LoadServicesOpts{FilterProjectID: project.ID})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !reflect.DeepEqual([]model.Service{*service}, got) {
+			t.Errorf("want services %v, got %v", []model.Service{*service}, got)
+		}
+
+		// The project is deleted after its services.
+		err = repository.DeleteService(testCtx, service.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = repository.DeleteProject(testCtx, project.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = repository.DeleteProject(testCtx, project.ID)
+		if !errors.Is(err, repo.ErrNotFound) {
+			t.Errorf("want error '%s', got '%s'", repo.ErrNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+//go:build integration
+
+package factory
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+)
+
+func Test_SearchMessages(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		// Messages of other tests share the repo, the searched word is unique.
+		word := strings.ToLower(gofakeit.LetterN(12))
+
+		// A word differing from the searched word in the last letter only.
+		near := word[:len(word)-1] + "x"
+		if strings.HasSuffix(word, "x") {
+			near = word[:len(word)-1] + "y"
+		}
+
+		services := []*model.Service{rand.ModelService(), rand.ModelService()}
+
+		// Results are ordered by service ID.
+		if services[0].ID.String() > services[1].ID.String() {
+			services[0], services[1] = services[1], services[0]
+		}
+
+		translations := []struct {
+			translation *model.Translation
+			serviceID   uuid.UUID
+		}{
+			{
+				serviceID: services[0].ID,
+				translation: &model.Translation{
+					Language: language.English,
+					Original: true,
+					Messages: []model.Message{
+						{ID: "checkout.title", Message: "Pay " + word + "Now", Status: model.MessageStatusTranslated},
+					},
+				},
+			},
+			{
+				serviceID: services[0].ID,
+				translation: &model.Translation{
+					Language: language.Latvian,
+					Messages: []model.Message{
+						{ID: "checkout.title", Message: "Apmaksāt " + word, Status: model.MessageStatusTranslated},
+						{ID: "other", Message: "Cits " + near, Status: model.MessageStatusTranslated},
+					},
+				},
+			},
+			{
+				serviceID: services[1].ID,
+				translation: &model.Translation{
+					Language: language.Latvian,
+					Messages: []model.Message{
+						{ID: word + "_button", Message: "Poga", Status: model.MessageStatusUntranslated},
+					},
+				},
+			},
+		}
+
+		for _, service := range services {
+			err := repository.SaveService(testCtx, service)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		for _, v := range translations {
+			err := repository.SaveTranslation(testCtx, v.serviceID, v.translation)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		type result struct {
+			id        string
+			language  language.Tag
+			serviceID uuid.UUID
+		}
+
+		search := func(opts repo.SearchMessagesOpts) []result {
+			found, err := repository.SearchMessages(testCtx, opts)
+			if err != nil {
+				t.Error(err)
+				return nil
+			}
+
+			results := make([]result, 0, len(found))
+			for _, v := range found {
+				results = append(results, result{serviceID: v.ServiceID, language: v.Language, id: v.Message.ID})
+			}
+
+			return results
+		}
+
+		// Test
+		tests := []struct {
+			name string
+			opts repo.SearchMessagesOpts
+			want []result
+		}{
+			{
+				name: "Word in text and ID",
+				opts: repo.SearchMessagesOpts{Query: word},
+				want: []result{
+					{serviceID: services[0].ID, language: language.English, id: "checkout.title"},
+					{serviceID: services[0].ID, language: language.Latvian, id: "checkout.title"},
+					{serviceID: services[1].ID, language: language.Latvian, id: word + "_button"},
+				},
+			},
+			{
+				name: "Word prefixes of all terms",
+				opts: repo.SearchMessagesOpts{Query: "PAY " + word[:6]},
+				want: []result{{serviceID: services[0].ID, language: language.English, id: "checkout.title"}},
+			},
+			{
+				name: "Filter languages and statuses",
+				opts: repo.SearchMessagesOpts{
+					Query:           word,
+					FilterLanguages: []language.Tag{language.Latvian},
+					FilterStatuses:  []model.MessageStatus{model.MessageStatusUntranslated},
+				},
+				want: []result{{serviceID: services[1].ID, language: language.Latvian, id: word + "_button"}},
+			},
+			{
+				name: "Limit",
+				opts: repo.SearchMessagesOpts{Query: word, Limit: 1},
+				want: []result{{serviceID: services[0].ID, language: language.English, id: "checkout.title"}},
+			},
+		}
+
+		for _, test := range tests {
+			if got := search(test.opts); !slices.Equal(test.want, got) {
+				t.Errorf("%s: want %v, got %v", test.name, test.want, got)
+			}
+		}
+
+		// Changed and deleted messages are not found.
+		translations[1].translation.Messages[0].Message = "Apmaksāt"
+
+		err := repository.SaveTranslation(testCtx, services[0].ID, translations[1].translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = repository.DeleteService(testCtx, services[1].ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		want := []result{{serviceID: services[0].ID, language: language.English, id: "checkout.title"}}
+
+		if got := search(repo.SearchMessagesOpts{Query: word}); !slices.Equal(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+}
@@ -33,6 +33,14 @@ func Test_SaveService(t *testing.T) {
 				name:    "Without UUID",
 				service: rand.ModelService(rand.WithID(uuid.Nil)),
 			},
+			{
+				name:    "With translator routing",
+				service: rand.ModelService(rand.WithTranslatorRouting("GoogleTranslate", model.TranslatorNone)),
+			},
+			{
+				name:    "With monthly character quota",
+				service: rand.ModelService(rand.WithMonthlyCharacterQuota(gofakeit.Int64())),
+			},
 		}
 		for _, test := range tests {
 			subTest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
@@ -96,6 +104,41 @@ func Test_UpdateService(t *testing.T) {
 	})
 }
 
+func Test_SaveServiceConflict(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		service := rand.ModelService()
+
+		err := repository.SaveService(testCtx, service)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		stale := *service
+
+		err = repository.SaveService(testCtx, service)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Test
+		if service.Version != stale.Version+1 {
+			t.Errorf("want version %d, got %d", stale.Version+1, service.Version)
+		}
+
+		err = repository.SaveService(testCtx, &stale)
+		if !errors.Is(err, repo.ErrConflict) {
+			t.Errorf("want error '%s', got '%s'", repo.ErrConflict, err)
+		}
+	})
+}
+
 func Test_LoadService(t *testing.T) {
 	t.Parallel()
 
@@ -162,7 +205,7 @@ func Test_LoadServices(t *testing.T) {
 			}
 		}
 
-		got, err := repository.LoadServices(testCtx)
+		got, err := repository.LoadServices(testCtx, repo.LoadServicesOpts{})
 		if err != nil {
 			t.Error(err)
 			return
@@ -197,6 +240,19 @@ func Test_DeleteService(t *testing.T) {
 			return
 		}
 
+		// Translations and jobs are deleted with the service.
+		err = repository.SaveTranslation(testCtx, service.ID, rand.ModelTranslation(3, nil))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = repository.SaveJob(testCtx, &model.Job{ServiceID: service.ID, Status: model.JobStatusPending})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
 		tests := []struct {
 			wantErr   error
 			name      string
@@ -227,6 +283,26 @@ func Test_DeleteService(t *testing.T) {
 				if !errors.Is(err, repo.ErrNotFound) {
 					t.Errorf("want error '%s', got '%s'", repo.ErrNotFound, err)
 				}
+
+				translations, err := repository.LoadTranslations(ctx, test.serviceID, repo.LoadTranslationsOpts{})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if len(translations) != 0 {
+					t.Errorf("want no translations, got %d", len(translations))
+				}
+
+				jobs, err := repository.LoadJobs(ctx, repo.LoadJobsOpts{FilterServiceID: test.serviceID})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if len(jobs) != 0 {
+					t.Errorf("want no jobs, got %d", len(jobs))
+				}
 			})
 		}
 	})
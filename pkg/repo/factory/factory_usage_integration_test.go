@@ -0,0 +1,177 @@
+//go:build integration
+
+package factory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil"
+	"go.expect.digital/translate/pkg/testutil/rand"
+)
+
+func Test_AddUsage(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, subTest testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		service := rand.ModelService()
+
+		err := repository.SaveService(testCtx, service)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		today := model.UsageDate(time.Now())
+		yesterday := today.AddDate(0, 0, -1)
+
+		// Concurrent requests add to the same counters.
+		var wg sync.WaitGroup
+
+		for range 10 {
+			wg.Go(func() {
+				addErr := repository.AddUsage(testCtx,
+					&model.Usage{ServiceID: service.ID, Date: time.Now(), Translator: "GoogleTranslate", Characters: 5, Requests: 1})
+				if addErr != nil {
+					t.Error(addErr)
+				}
+			})
+		}
+
+		wg.Wait()
+
+		for _, usage := range []model.Usage{
+			{ServiceID: service.ID, Date: yesterday, Translator: "AWSTranslate", Characters: 7, Requests: 1},
+			{ServiceID: service.ID, Date: today, Translator: "AWSTranslate", Characters: 3, Requests: 1},
+		} {
+			err = repository.AddUsage(testCtx, &usage)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		tests := []struct {
+			name string
+			opts repo.LoadUsageOpts
+			want []model.Usage
+		}{
+			{
+				name: "All",
+				want: []model.Usage{
+					{ServiceID: service.ID, Date: yesterday, Translator: "AWSTranslate", Characters: 7, Requests: 1},
+					{ServiceID: service.ID, Date: today, Translator: "AWSTranslate", Characters: 3, Requests: 1},
+					{ServiceID: service.ID, Date: today, Translator: "GoogleTranslate", Characters: 50, Requests: 10},
+				},
+			},
+			{
+				name: "From today",
+				opts: repo.LoadUsageOpts{FilterFrom: time.Now()},
+				want: []model.Usage{
+					{ServiceID: service.ID, Date: today, Translator: "AWSTranslate", Characters: 3, Requests: 1},
+					{ServiceID: service.ID, Date: today, Translator: "GoogleTranslate", Characters: 50, Requests: 10},
+				},
+			},
+			{
+				name: "Before today",
+				opts: repo.LoadUsageOpts{FilterTo: today},
+				want: []model.Usage{
+					{ServiceID: service.ID, Date: yesterday, Translator: "AWSTranslate", Characters: 7, Requests: 1},
+				},
+			},
+		}
+
+		for _, test := range tests {
+			subTest(test.name, func(ctx context.Context, t *testing.T) { //nolint:thelper
+				got, err := repository.LoadUsage(ctx, service.ID, test.opts)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if len(test.want) != len(got) {
+					t.Errorf("\nwant %v\ngot  %v", test.want, got)
+					return
+				}
+
+				for i := range test.want {
+					want := test.want[i]
+					if !want.Date.Equal(got[i].Date) || want.Translator != got[i].Translator ||
+						want.Characters != got[i].Characters || want.Requests != got[i].Requests ||
+						want.ServiceID != got[i].ServiceID {
+						t.Errorf("\nwant %v\ngot  %v", want, got[i])
+					}
+				}
+			})
+		}
+	})
+}
+
+func Test_AddUsageCheckedInTx(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		service := rand.ModelService()
+
+		err := repository.SaveService(testCtx, service)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Concurrent requests load the usage to check the quota and add to the counters of the day
+		// in a transaction with the usage locked, as the server does. The counters of the day do not exist yet.
+		var wg sync.WaitGroup
+
+		for range 10 {
+			wg.Go(func() {
+				for {
+					txErr := repository.Tx(testCtx, func(ctx context.Context, r repo.Repo) error {
+						err := r.LockUsage(ctx, service.ID)
+						if err != nil {
+							return err //nolint:wrapcheck
+						}
+
+						_, err = r.LoadUsage(ctx, service.ID, repo.LoadUsageOpts{FilterFrom: model.MonthStart(time.Now())})
+						if err != nil {
+							return err //nolint:wrapcheck
+						}
+
+						return r.AddUsage(ctx, //nolint:wrapcheck
+							&model.Usage{ServiceID: service.ID, Date: time.Now(), Translator: "GoogleTranslate", Characters: 5, Requests: 1})
+					})
+					if errors.Is(txErr, repo.ErrConflict) {
+						continue // Usage was added concurrently, check again.
+					}
+
+					if txErr != nil {
+						t.Error(txErr)
+					}
+
+					return
+				}
+			})
+		}
+
+		wg.Wait()
+
+		got, err := repository.LoadUsage(testCtx, service.ID, repo.LoadUsageOpts{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(got) != 1 || got[0].Characters != 50 || got[0].Requests != 10 {
+			t.Errorf("want 50 characters in 10 requests, got %v", got)
+		}
+	})
+}
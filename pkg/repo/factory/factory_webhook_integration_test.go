@@ -0,0 +1,348 @@
+//go:build integration
+
+package factory
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil"
+	"go.expect.digital/translate/pkg/testutil/rand"
+)
+
+func Test_SaveWebhook(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		service := rand.ModelService()
+
+		err := repository.SaveService(testCtx, service)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		webhook := &model.Webhook{
+			ServiceID: service.ID,
+			URL:       gofakeit.URL(),
+			Secret:    gofakeit.Password(true, true, true, false, false, 16),
+			Events:    []model.WebhookEvent{model.WebhookEventTranslationCompleted, model.WebhookEventJobFinished},
+		}
+
+		err = repository.SaveWebhook(testCtx, webhook)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Test
+		got, err := repository.LoadWebhook(testCtx, service.ID, webhook.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !reflect.DeepEqual(webhook, got) {
+			t.Errorf("want webhook %v, got %v", webhook, got)
+		}
+
+		webhooks, err := repository.LoadWebhooks(testCtx, service.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !reflect.DeepEqual([]model.Webhook{*webhook}, webhooks) {
+			t.Errorf("want webhooks %v, got %v", []model.Webhook{*webhook}, webhooks)
+		}
+
+		// A webhook is loaded only with its service.
+		_, err = repository.LoadWebhook(testCtx, uuid.New(), webhook.ID)
+		if !errors.Is(err, repo.ErrNotFound) {
+			t.Errorf("want error '%s', got '%s'", repo.ErrNotFound, err)
+		}
+	})
+}
+
+func Test_WebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		service := rand.ModelService()
+
+		err := repository.SaveService(testCtx, service)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		webhook := &model.Webhook{
+			ServiceID: service.ID,
+			URL:       gofakeit.URL(),
+			Events:    []model.WebhookEvent{model.WebhookEventOriginalChanged},
+		}
+
+		err = repository.SaveWebhook(testCtx, webhook)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		pending := &model.WebhookDelivery{
+			WebhookID: webhook.ID,
+			ServiceID: service.ID,
+			Event:     model.WebhookEventOriginalChanged,
+			Payload:   `{"event":"ORIGINAL_CHANGED"}`,
+			Status:    model.WebhookDeliveryStatusPending,
+		}
+
+		failed := &model.WebhookDelivery{
+			WebhookID:      webhook.ID,
+			ServiceID:      service.ID,
+			Event:          model.WebhookEventOriginalChanged,
+			Payload:        `{"event":"ORIGINAL_CHANGED"}`,
+			Status:         model.WebhookDeliveryStatusFailed,
+			Attempts:       5,
+			ResponseStatus: 500,
+			Error:          "unexpected status 500",
+		}
+
+		for _, delivery := range []*model.WebhookDelivery{pending, failed} {
+			err = repository.SaveWebhookDelivery(testCtx, delivery)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		// Test
+		got, err := repository.LoadWebhookDeliveries(testCtx, repo.LoadWebhookDeliveriesOpts{
+			FilterWebhookID: webhook.ID,
+			FilterStatuses:  []model.WebhookDeliveryStatus{model.WebhookDeliveryStatusPending},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !reflect.DeepEqual([]model.WebhookDelivery{*pending}, got) {
+			t.Errorf("want deliveries %v, got %v", []model.WebhookDelivery{*pending}, got)
+		}
+
+		// Deliveries are deleted with the webhook.
+		err = repository.DeleteWebhook(testCtx, service.ID, webhook.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		got, err = repository.LoadWebhookDeliveries(testCtx, repo.LoadWebhookDeliveriesOpts{FilterWebhookID: webhook.ID})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(got) != 0 {
+			t.Errorf("want no deliveries, got %d", len(got))
+		}
+
+		err = repository.DeleteWebhook(testCtx, service.ID, webhook.ID)
+		if !errors.Is(err, repo.ErrNotFound) {
+			t.Errorf("want error '%s', got '%s'", repo.ErrNotFound, err)
+		}
+	})
+}
+
+func Test_ClaimWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		service := rand.ModelService()
+
+		err := repository.SaveService(testCtx, service)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		webhook := &model.Webhook{
+			ServiceID: service.ID,
+			URL:       gofakeit.URL(),
+			Events:    []model.WebhookEvent{model.WebhookEventOriginalChanged},
+		}
+
+		err = repository.SaveWebhook(testCtx, webhook)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		delivery := &model.WebhookDelivery{
+			WebhookID: webhook.ID,
+			ServiceID: service.ID,
+			Event:     model.WebhookEventOriginalChanged,
+			Payload:   `{"event":"ORIGINAL_CHANGED"}`,
+			Status:    model.WebhookDeliveryStatusPending,
+		}
+
+		err = repository.SaveWebhookDelivery(testCtx, delivery)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// The first claim moves the next attempt, the delivery is not due for the second claim.
+		until := time.Now().Add(time.Minute)
+
+		err = repository.ClaimWebhookDelivery(testCtx, delivery, until)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = repository.ClaimWebhookDelivery(testCtx, delivery, until)
+		if !errors.Is(err, repo.ErrConflict) {
+			t.Errorf("want error '%v', got '%v'", repo.ErrConflict, err)
+		}
+
+		got, err := repository.LoadWebhookDeliveries(testCtx, repo.LoadWebhookDeliveriesOpts{FilterWebhookID: webhook.ID})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if !reflect.DeepEqual([]model.WebhookDelivery{*delivery}, got) {
+			t.Errorf("\nwant %v\ngot  %v", []model.WebhookDelivery{*delivery}, got)
+		}
+
+		// Finished deliveries are not claimed.
+		delivery.Status = model.WebhookDeliveryStatusSucceeded
+		delivery.NextAttemptAt = time.Now().Add(-time.Minute)
+
+		err = repository.SaveWebhookDelivery(testCtx, delivery)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = repository.ClaimWebhookDelivery(testCtx, delivery, until)
+		if !errors.Is(err, repo.ErrConflict) {
+			t.Errorf("want error '%v', got '%v'", repo.ErrConflict, err)
+		}
+
+		err = repository.ClaimWebhookDelivery(testCtx,
+			&model.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, ServiceID: service.ID}, until)
+		if !errors.Is(err, repo.ErrNotFound) {
+			t.Errorf("want error '%v', got '%v'", repo.ErrNotFound, err)
+		}
+	})
+}
+
+func Test_DeleteWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	allRepos(t, func(t *testing.T, repository repo.Repo, _ testutil.SubtestFn) { //nolint:thelper
+		testCtx, _ := testutil.Trace(t)
+
+		// Prepare
+		service := rand.ModelService()
+
+		err := repository.SaveService(testCtx, service)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		webhook := &model.Webhook{
+			ServiceID: service.ID,
+			URL:       gofakeit.URL(),
+			Events:    []model.WebhookEvent{model.WebhookEventOriginalChanged},
+		}
+
+		err = repository.SaveWebhook(testCtx, webhook)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		pending := &model.WebhookDelivery{
+			WebhookID: webhook.ID,
+			ServiceID: service.ID,
+			Event:     model.WebhookEventOriginalChanged,
+			Payload:   `{"event":"ORIGINAL_CHANGED"}`,
+			Status:    model.WebhookDeliveryStatusPending,
+		}
+
+		succeeded := &model.WebhookDelivery{
+			WebhookID: webhook.ID,
+			ServiceID: service.ID,
+			Event:     model.WebhookEventOriginalChanged,
+			Payload:   `{"event":"ORIGINAL_CHANGED"}`,
+			Status:    model.WebhookDeliveryStatusPending,
+		}
+
+		for _, delivery := range []*model.WebhookDelivery{pending, succeeded} {
+			err = repository.SaveWebhookDelivery(testCtx, delivery)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		// A delivered webhook leaves the pending deliveries.
+		succeeded.Status = model.WebhookDeliveryStatusSucceeded
+		succeeded.Attempts = 1
+		succeeded.ResponseStatus = 200
+
+		err = repository.SaveWebhookDelivery(testCtx, succeeded)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		got, err := repository.LoadWebhookDeliveries(testCtx, repo.LoadWebhookDeliveriesOpts{
+			FilterWebhookID: webhook.ID,
+			FilterStatuses:  []model.WebhookDeliveryStatus{model.WebhookDeliveryStatusPending},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(got) != 1 || got[0].ID != pending.ID {
+			t.Errorf("want pending delivery %s, got %v", pending.ID, got)
+		}
+
+		// Test
+		err = repository.DeleteWebhookDeliveries(testCtx, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		got, err = repository.LoadWebhookDeliveries(testCtx, repo.LoadWebhookDeliveriesOpts{FilterWebhookID: webhook.ID})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// Pending deliveries are kept.
+		if len(got) != 1 || got[0].ID != pending.ID {
+			t.Errorf("want delivery %s, got %v", pending.ID, got)
+		}
+	})
+}
@@ -0,0 +1,274 @@
+// Package sqlrepo holds the queries shared by the MySQL and SQLite repositories.
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+)
+
+// DB interface defines method signatures found both in sql.DB and sql.Tx.
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// Dialect is the difference of the databases in the shared queries.
+type Dialect struct {
+	// UUID is the placeholder of a UUID parameter.
+	UUID string
+	// RevisionTiebreak orders the revisions created at the same time in the order they were inserted,
+	// empty if the database orders them by creation time only.
+	RevisionTiebreak string
+	// MessageOrder orders the messages of a translation in the order they were saved.
+	MessageOrder string
+}
+
+var (
+	// MySQL stores UUIDs as binary.
+	MySQL = Dialect{UUID: "UUID_TO_BIN(?)", MessageOrder: "ordinal"}
+	// SQLite stores UUIDs as text, rows are numbered in the order they were inserted.
+	SQLite = Dialect{UUID: "?", RevisionTiebreak: "r.rowid", MessageOrder: "rowid"}
+)
+
+// uuids replaces the "$uuid" placeholders of the query with the UUID placeholder of the dialect.
+func (d Dialect) uuids(query string) string {
+	return strings.ReplaceAll(query, "$uuid", d.UUID)
+}
+
+// revisionOrder returns the ORDER BY columns of revisions, oldest first or newest first if desc is set.
+func (d Dialect) revisionOrder(desc bool) string {
+	columns := []string{"r.created_at"}
+	if d.RevisionTiebreak != "" {
+		columns = append(columns, d.RevisionTiebreak)
+	}
+
+	if desc {
+		for i := range columns {
+			columns[i] += " DESC"
+		}
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// LoadMessages loads the messages of the translation in the order they were saved.
+func (d Dialect) LoadMessages(ctx context.Context, db DB, translationID uuid.UUID) ([]model.Message, error) {
+	rows, err := db.QueryContext(ctx, d.uuids(`SELECT id, message, description, plural_id, positions, status,
+	status_reason, quality_score FROM message WHERE translation_id = $uuid ORDER BY `+d.MessageOrder),
+		translationID)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query messages: %w", err)
+	}
+
+	defer rows.Close()
+
+	var messages []model.Message
+
+	for rows.Next() {
+		var (
+			msg          model.Message
+			pluralID     sql.NullString
+			statusReason sql.NullString
+			qualityScore sql.NullFloat64
+		)
+
+		err = rows.Scan(&msg.ID, &msg.Message, &msg.Description, &pluralID, &msg.Positions, &msg.Status,
+			&statusReason, &qualityScore)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan message: %w", err)
+		}
+
+		msg.PluralID = pluralID.String
+		msg.StatusReason = statusReason.String
+
+		if qualityScore.Valid {
+			msg.QualityScore = &qualityScore.Float64
+		}
+
+		messages = append(messages, msg)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// InsertMessageRevisions inserts the message revisions of the translation.
+func (d Dialect) InsertMessageRevisions(ctx context.Context, db DB, translationID uuid.UUID,
+	revisions []model.MessageRevision,
+) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	stmt, err := db.PrepareContext(
+		ctx,
+		d.uuids(`INSERT INTO message_revision
+	(id, translation_id, message_id, old_message, old_status, new_message, new_status, actor, created_at)
+VALUES
+	($uuid, $uuid, ?, ?, ?, ?, ?, ?, ?)`),
+	)
+	if err != nil {
+		return fmt.Errorf("repo: prepare stmt to insert message revision: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, revision := range revisions {
+		var oldMessage, oldStatus sql.NullString
+
+		if revision.Old != nil {
+			oldMessage = sql.NullString{String: revision.Old.Message, Valid: true}
+			oldStatus = sql.NullString{String: revision.Old.Status.String(), Valid: true}
+		}
+
+		_, err = stmt.ExecContext(
+			ctx,
+			revision.ID,
+			translationID,
+			revision.MessageID,
+			oldMessage,
+			oldStatus,
+			revision.New.Message,
+			&revision.New.Status,
+			revision.Actor,
+			revision.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("repo: insert message revision: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LoadMessageRevisions returns the revisions of the message of the service translation, newest first.
+func (d Dialect) LoadMessageRevisions(ctx context.Context, db DB, serviceID uuid.UUID, language language.Tag,
+	messageID string,
+) ([]model.MessageRevision, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		d.uuids(`SELECT r.id, r.message_id, r.old_message, r.old_status, r.new_message, r.new_status, r.actor, r.created_at
+FROM message_revision r
+JOIN translation t ON t.id = r.translation_id
+WHERE t.service_id = $uuid AND t.language = ? AND r.message_id = ?
+ORDER BY `)+d.revisionOrder(true),
+		serviceID,
+		language.String(),
+		messageID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query message revisions: %w", err)
+	}
+
+	return scanMessageRevisions(rows, language)
+}
+
+// LoadTranslationRevisions returns the message revisions of the service translation, oldest first.
+func (d Dialect) LoadTranslationRevisions(ctx context.Context, db DB, serviceID uuid.UUID, language language.Tag,
+) ([]model.MessageRevision, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		d.uuids(`SELECT r.id, r.message_id, r.old_message, r.old_status, r.new_message, r.new_status, r.actor, r.created_at
+FROM message_revision r
+JOIN translation t ON t.id = r.translation_id
+WHERE t.service_id = $uuid AND t.language = ?
+ORDER BY `)+d.revisionOrder(false),
+		serviceID,
+		language.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query translation revisions: %w", err)
+	}
+
+	return scanMessageRevisions(rows, language)
+}
+
+// ReplaceTranslationRevisions replaces the message revisions of the service translation,
+// db must be a transaction.
+func (d Dialect) ReplaceTranslationRevisions(ctx context.Context, db DB, serviceID uuid.UUID,
+	language language.Tag, revisions []model.MessageRevision,
+) error {
+	var translationID uuid.UUID
+
+	row := db.QueryRowContext(ctx, d.uuids(`SELECT id FROM translation WHERE service_id = $uuid AND language = ?`),
+		serviceID, language.String())
+
+	switch err := row.Scan(&translationID); {
+	case errors.Is(err, sql.ErrNoRows):
+		return repo.ErrNotFound
+	case err != nil:
+		return fmt.Errorf("repo: scan translation: %w", err)
+	}
+
+	_, err := db.ExecContext(ctx, d.uuids(`DELETE FROM message_revision WHERE translation_id = $uuid`), translationID)
+	if err != nil {
+		return fmt.Errorf("repo: delete message revisions: %w", err)
+	}
+
+	return d.InsertMessageRevisions(ctx, db, translationID, revisions)
+}
+
+// DeleteMessageRevisions deletes the message revisions created before the time.
+// Revisions are created in UTC, so the time is compared in UTC.
+func (d Dialect) DeleteMessageRevisions(ctx context.Context, db DB, before time.Time) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM message_revision WHERE created_at < ?`, before.UTC())
+	if err != nil {
+		return fmt.Errorf("repo: delete message revisions: %w", err)
+	}
+
+	return nil
+}
+
+// scanMessageRevisions scans and closes rows of message revisions of the translation language.
+func scanMessageRevisions(rows *sql.Rows, language language.Tag) ([]model.MessageRevision, error) {
+	defer rows.Close()
+
+	var revisions []model.MessageRevision
+
+	for rows.Next() {
+		var (
+			oldMessage sql.NullString
+			oldStatus  sql.NullString
+		)
+
+		revision := model.MessageRevision{Language: language}
+
+		err := rows.Scan(&revision.ID, &revision.MessageID, &oldMessage, &oldStatus, &revision.New.Message,
+			&revision.New.Status, &revision.Actor, &revision.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan message revision: %w", err)
+		}
+
+		if oldStatus.Valid {
+			revision.Old = &model.MessageState{Message: oldMessage.String}
+
+			err = revision.Old.Status.Scan(oldStatus.String)
+			if err != nil {
+				return nil, fmt.Errorf("repo: scan message revision old status: %w", err)
+			}
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan message revisions: %w", err)
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,219 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+// SaveJob handles both Create and Update.
+func (r *Repo) SaveJob(ctx context.Context, job *model.Job) error {
+	query := `INSERT INTO job
+	(id, service_id, languages, status, total, done, error, owner, lease_until, created_at, updated_at)
+VALUES
+	(UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, ?, ?, UUID_TO_BIN(?), ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+	languages = VALUES(languages),
+	status = VALUES(status),
+	total = VALUES(total),
+	done = VALUES(done),
+	error = VALUES(error),
+	owner = VALUES(owner),
+	lease_until = VALUES(lease_until),
+	updated_at = VALUES(updated_at)`
+
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond) // MySQL DATETIME(6) precision
+
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = now
+	}
+
+	job.UpdatedAt = now
+
+	languages, err := json.Marshal(job.Languages)
+	if err != nil {
+		return fmt.Errorf("repo: marshal job languages: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		job.ID,
+		job.ServiceID,
+		languages,
+		&job.Status,
+		job.Total,
+		job.Done,
+		job.Error,
+		uuid.NullUUID{UUID: job.Owner, Valid: job.Owner != uuid.Nil},
+		sql.NullTime{Time: job.LeaseUntil, Valid: !job.LeaseUntil.IsZero()},
+		job.CreatedAt,
+		job.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("repo: insert job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) LoadJob(ctx context.Context, jobID uuid.UUID) (*model.Job, error) {
+	jobs, err := r.loadJobs(ctx, sq.Expr("id = UUID_TO_BIN(?)", jobID), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jobs) == 0 {
+		return nil, repo.ErrNotFound
+	}
+
+	return &jobs[0], nil
+}
+
+func (r *Repo) LoadJobs(ctx context.Context, opts repo.LoadJobsOpts) ([]model.Job, error) {
+	where := sq.And{}
+
+	if opts.FilterServiceID != uuid.Nil {
+		where = append(where, sq.Expr("service_id = UUID_TO_BIN(?)", opts.FilterServiceID))
+	}
+
+	if len(opts.FilterStatuses) > 0 {
+		statuses := make([]string, 0, len(opts.FilterStatuses))
+		for _, s := range opts.FilterStatuses {
+			statuses = append(statuses, s.String())
+		}
+
+		where = append(where, eq("status", statuses))
+	}
+
+	if opts.After != (repo.JobKey{}) {
+		where = append(where, sq.Or{
+			sq.Gt{"created_at": opts.After.CreatedAt},
+			sq.And{sq.Eq{"created_at": opts.After.CreatedAt}, sq.Expr("id > UUID_TO_BIN(?)", opts.After.ID)},
+		})
+	}
+
+	return r.loadJobs(ctx, where, opts.Limit)
+}
+
+// DeleteJobs deletes the succeeded and failed jobs last updated before the time.
+func (r *Repo) DeleteJobs(ctx context.Context, before time.Time) error {
+	succeeded, failed := model.JobStatusSucceeded, model.JobStatusFailed
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM job WHERE status IN (?, ?) AND updated_at < ?`,
+		&succeeded, &failed, before.UTC())
+	if err != nil {
+		return fmt.Errorf("repo: delete jobs: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimJob sets the job running by the owner with a conditional update, so that a job is claimed by one owner only.
+// A running job without a lease, left by a version without leases, is claimed as if the lease expired.
+func (r *Repo) ClaimJob(ctx context.Context, job *model.Job, owner uuid.UUID, leaseUntil time.Time) error {
+	query := `UPDATE job SET status = ?, owner = UUID_TO_BIN(?), lease_until = ?, updated_at = ?
+WHERE id = UUID_TO_BIN(?) AND (
+	status = ? OR
+	status = ? AND (owner = UUID_TO_BIN(?) OR lease_until IS NULL OR lease_until < ?))`
+
+	now := time.Now().UTC().Truncate(time.Microsecond) // MySQL DATETIME(6) precision
+
+	leaseUntil = leaseUntil.UTC().Truncate(time.Microsecond)
+
+	running, pending := model.JobStatusRunning, model.JobStatusPending
+
+	result, err := r.db.ExecContext(ctx, query,
+		&running, owner, leaseUntil, now,
+		job.ID, &pending, &running, owner, now,
+	)
+	if err != nil {
+		return fmt.Errorf("repo: claim job: %w", err)
+	}
+
+	switch count, err := result.RowsAffected(); {
+	case err != nil:
+		return fmt.Errorf("repo: claim job result: %w", err)
+	case count == 0:
+		// Tell a missing job from a job claimed by another owner.
+		_, err = r.LoadJob(ctx, job.ID)
+		if err != nil {
+			return err
+		}
+
+		return repo.ErrConflict
+	}
+
+	job.Status = running
+	job.Owner = owner
+	job.LeaseUntil = leaseUntil
+	job.UpdatedAt = now
+
+	return nil
+}
+
+// loadJobs returns at most limit jobs matching the where clause, oldest first, all jobs if limit is 0.
+func (r *Repo) loadJobs(ctx context.Context, where sq.Sqlizer, limit int) ([]model.Job, error) {
+	query := sq.
+		Select("id, service_id, languages, status, total, done, error, owner, lease_until, created_at, updated_at").
+		From("job").
+		Where(where).
+		OrderBy("created_at", "id")
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit)) //nolint:gosec
+	}
+
+	rows, err := query.RunWith(r.db).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query jobs: %w", err)
+	}
+
+	defer rows.Close()
+
+	var jobs []model.Job
+
+	for rows.Next() {
+		var (
+			job        model.Job
+			languages  []byte
+			jobErr     sql.NullString
+			owner      uuid.NullUUID
+			leaseUntil sql.NullTime
+		)
+
+		err = rows.Scan(&job.ID, &job.ServiceID, &languages, &job.Status, &job.Total, &job.Done, &jobErr,
+			&owner, &leaseUntil, &job.CreatedAt, &job.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan job: %w", err)
+		}
+
+		if len(languages) > 0 {
+			err = json.Unmarshal(languages, &job.Languages)
+			if err != nil {
+				return nil, fmt.Errorf("repo: unmarshal job languages: %w", err)
+			}
+		}
+
+		job.Error = jobErr.String
+		job.Owner = owner.UUID
+		job.LeaseUntil = leaseUntil.Time
+		jobs = append(jobs, job)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan jobs: %w", err)
+	}
+
+	return jobs, nil
+}
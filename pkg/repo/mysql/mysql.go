@@ -8,6 +8,7 @@ import (
 	"github.com/XSAM/otelsql"
 	_ "github.com/go-sql-driver/mysql" // MySQL driver
 	"github.com/spf13/viper"
+	"go.expect.digital/translate/migrate"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
 
@@ -20,7 +21,7 @@ type Conf struct {
 }
 
 func (c *Conf) ConnectionString() string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", c.User, c.Password, c.Host, c.Port, c.Database)
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true", c.User, c.Password, c.Host, c.Port, c.Database)
 }
 
 func DefaultConf() *Conf {
@@ -34,10 +35,25 @@ func DefaultConf() *Conf {
 }
 
 func NewDB(ctx context.Context, conf *Conf) (*sql.DB, error) {
+	return openDB(ctx, conf, conf.ConnectionString())
+}
+
+// NewMigrator connects to the MySQL database and returns its migrator.
+// Migration scripts contain multiple statements, so the migrator uses a separate connection that allows them.
+func NewMigrator(ctx context.Context, conf *Conf) (*migrate.SQLMigrator, error) {
+	db, err := openDB(ctx, conf, conf.ConnectionString()+"&multiStatements=true")
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.NewSQLMigrator(db, migrate.MySQL, "mysql"), nil
+}
+
+func openDB(ctx context.Context, conf *Conf, connectionString string) (*sql.DB, error) {
 	// https://github.com/XSAM/otelsql
 	db, err := otelsql.Open(
 		"mysql",
-		conf.ConnectionString(),
+		connectionString,
 		otelsql.WithAttributes(
 			semconv.DBSystemMySQL,
 			semconv.DBNameKey.String(conf.Database),
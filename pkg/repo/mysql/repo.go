@@ -169,3 +169,22 @@ func (r *Repo) ensureTx(ctx context.Context, fn func(context.Context, *Repo) err
 
 	return nil
 }
+
+// nextVersion returns the version of an entity saved with the version, the stored version is selected by the query.
+// An entity with a non-zero version must have the stored version, see repo.ErrConflict.
+func (r *Repo) nextVersion(ctx context.Context, version int, query string, args ...any) (int, error) {
+	var stored int
+
+	switch err := r.db.QueryRowContext(ctx, query, args...).Scan(&stored); {
+	default:
+		if version != 0 && version != stored {
+			return 0, repo.ErrConflict
+		}
+
+		return stored + 1, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return 1, nil // new entity
+	case err != nil:
+		return 0, fmt.Errorf("repo: select version: %w", err)
+	}
+}
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+)
+
+// SearchMessages returns the messages of all services matching the query,
+// ordered by service ID, language and message ID.
+// Messages are found with the message_search FULLTEXT index in boolean mode by the word prefixes of the query terms.
+// Words shorter than innodb_ft_min_token_size and stopwords are not indexed by MySQL and cannot be found.
+func (r *Repo) SearchMessages(ctx context.Context, opts repo.SearchMessagesOpts) ([]model.SearchResult, error) {
+	terms := model.SearchTerms(opts.Query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	// Terms are words without boolean mode operators, each term is a required prefix.
+	against := make([]string, 0, len(terms))
+	for _, term := range terms {
+		against = append(against, "+"+term+"*")
+	}
+
+	// Compare byte-wise, m.binary_id is the byte-wise copy of m.id.
+	query := sq.
+		Select("t.service_id, t.language, m.id, m.message, m.description, m.plural_id, m.positions, m.status, "+
+			"m.status_reason, m.quality_score").
+		From("message m").
+		Join("translation t ON t.id = m.translation_id").
+		Where("MATCH (m.id, m.message) AGAINST (? IN BOOLEAN MODE)", strings.Join(against, " ")).
+		Where(eq("t.language", langToStringSlice(opts.FilterLanguages))).
+		Where(eq("m.status", statusToStringSlice(opts.FilterStatuses))).
+		OrderBy("t.service_id", "t.language", "m.binary_id")
+
+	if opts.Limit > 0 {
+		query = query.Limit(uint64(opts.Limit)) //nolint:gosec
+	}
+
+	rows, err := query.RunWith(r.db).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query message search: %w", err)
+	}
+
+	defer rows.Close()
+
+	var results []model.SearchResult
+
+	for rows.Next() {
+		var (
+			result       model.SearchResult
+			lang         string
+			pluralID     sql.NullString
+			statusReason sql.NullString
+			qualityScore sql.NullFloat64
+		)
+
+		msg := &result.Message
+
+		err = rows.Scan(&result.ServiceID, &lang, &msg.ID, &msg.Message, &msg.Description, &pluralID, &msg.Positions,
+			&msg.Status, &statusReason, &qualityScore)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan found message: %w", err)
+		}
+
+		result.Language, err = language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("repo: parse language of found message: %w", err)
+		}
+
+		msg.PluralID = pluralID.String // NULL for messages saved before plural IDs were persisted
+		msg.StatusReason = statusReason.String
+
+		if qualityScore.Valid {
+			msg.QualityScore = &qualityScore.Float64
+		}
+
+		results = append(results, result)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan found messages: %w", err)
+	}
+
+	return results, nil
+}
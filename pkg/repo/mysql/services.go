@@ -3,37 +3,77 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"go.expect.digital/translate/pkg/model"
 	"go.expect.digital/translate/pkg/repo"
 )
 
 func (r *Repo) SaveService(ctx context.Context, service *model.Service) error {
-	query := `INSERT INTO service (id, name) VALUES (UUID_TO_BIN(?), ?) ON DUPLICATE KEY UPDATE name = VALUES (name)`
+	query := `INSERT INTO service (id, name, translator_routing, monthly_character_quota, version, project_id)
+VALUES (UUID_TO_BIN(?), ?, ?, ?, ?, UUID_TO_BIN(?))
+ON DUPLICATE KEY UPDATE
+	name = VALUES (name),
+	translator_routing = VALUES (translator_routing),
+	monthly_character_quota = VALUES (monthly_character_quota),
+	version = VALUES (version),
+	project_id = VALUES (project_id)`
 
 	if service.ID == uuid.Nil {
 		service.ID = uuid.New()
 	}
 
-	_, err := r.db.ExecContext(ctx, query, service.ID, service.Name)
+	if service.ProjectID == uuid.Nil {
+		service.ProjectID = model.DefaultProjectID
+	}
+
+	routing, err := json.Marshal(service.TranslatorRouting)
 	if err != nil {
-		return fmt.Errorf("repo: insert service: %w", err)
+		return fmt.Errorf("repo: marshal service translator routing: %w", err)
 	}
 
-	return nil
+	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
+		// The row is locked until the end of the transaction, so that concurrent saves are serialized.
+		version, err := r.nextVersion(ctx, service.Version,
+			`SELECT version FROM service WHERE id = UUID_TO_BIN(?) FOR UPDATE`, service.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.ExecContext(ctx, query, service.ID, service.Name, routing, service.MonthlyCharacterQuota,
+			version, service.ProjectID)
+		if err != nil {
+			return fmt.Errorf("repo: insert service: %w", err)
+		}
+
+		service.Version = version
+
+		return nil
+	})
 }
 
 func (r *Repo) LoadService(ctx context.Context, serviceID uuid.UUID) (*model.Service, error) {
-	query := `SELECT id, name FROM service WHERE id = UUID_TO_BIN(?)`
+	query := `SELECT id, name, translator_routing, monthly_character_quota, version, project_id
+FROM service WHERE id = UUID_TO_BIN(?)`
 	row := r.db.QueryRowContext(ctx, query, serviceID)
 
-	var service model.Service
+	var (
+		service model.Service
+		routing []byte
+	)
 
-	switch err := row.Scan(&service.ID, &service.Name); {
+	switch err := row.Scan(&service.ID, &service.Name, &routing, &service.MonthlyCharacterQuota, &service.Version,
+		&service.ProjectID); {
 	default:
+		err = unmarshalTranslatorRouting(routing, &service)
+		if err != nil {
+			return nil, err
+		}
+
 		return &service, nil
 	case errors.Is(err, sql.ErrNoRows):
 		return nil, repo.ErrNotFound
@@ -42,10 +82,19 @@ func (r *Repo) LoadService(ctx context.Context, serviceID uuid.UUID) (*model.Ser
 	}
 }
 
-func (r *Repo) LoadServices(ctx context.Context) ([]model.Service, error) {
-	query := `SELECT id, name FROM service`
+func (r *Repo) LoadServices(ctx context.Context, opts repo.LoadServicesOpts) ([]model.Service, error) {
+	where := sq.And{}
+
+	if opts.FilterProjectID != uuid.Nil {
+		where = append(where, sq.Expr("project_id = UUID_TO_BIN(?)", opts.FilterProjectID))
+	}
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := sq.
+		Select("id, name, translator_routing, monthly_character_quota, version, project_id").
+		From("service").
+		Where(where).
+		RunWith(r.db).
+		QueryContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("repo: select services: %w", err)
 	}
@@ -54,13 +103,22 @@ func (r *Repo) LoadServices(ctx context.Context) ([]model.Service, error) {
 	var services []model.Service
 
 	for rows.Next() {
-		var service model.Service
+		var (
+			service model.Service
+			routing []byte
+		)
 
-		err = rows.Scan(&service.ID, &service.Name)
+		err = rows.Scan(&service.ID, &service.Name, &routing, &service.MonthlyCharacterQuota, &service.Version,
+			&service.ProjectID)
 		if err != nil {
 			return nil, fmt.Errorf("repo: scan service: %w", err)
 		}
 
+		err = unmarshalTranslatorRouting(routing, &service)
+		if err != nil {
+			return nil, err
+		}
+
 		services = append(services, service)
 	}
 
@@ -72,20 +130,49 @@ func (r *Repo) LoadServices(ctx context.Context) ([]model.Service, error) {
 	return services, nil
 }
 
-func (r *Repo) DeleteService(ctx context.Context, serviceID uuid.UUID) error {
-	query := `DELETE FROM service WHERE id = UUID_TO_BIN(?)`
+// unmarshalTranslatorRouting sets the service translator routing from the translator_routing column,
+// the column is NULL for services saved before translator routing was introduced.
+func unmarshalTranslatorRouting(routing []byte, service *model.Service) error {
+	if len(routing) == 0 {
+		return nil
+	}
 
-	result, err := r.db.ExecContext(ctx, query, serviceID)
+	err := json.Unmarshal(routing, &service.TranslatorRouting)
 	if err != nil {
-		return fmt.Errorf("repo: delete service: %w", err)
+		return fmt.Errorf("repo: unmarshal service translator routing: %w", err)
 	}
 
-	switch count, err := result.RowsAffected(); {
-	default:
-		return nil
-	case err != nil:
-		return fmt.Errorf("repo: delete service result: %w", err)
-	case count == 0:
-		return repo.ErrNotFound
-	}
+	return nil
+}
+
+// DeleteService deletes the service with its translations and messages,
+// jobs, usage and webhooks are deleted by the foreign key ON DELETE CASCADE.
+func (r *Repo) DeleteService(ctx context.Context, serviceID uuid.UUID) error {
+	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
+		_, err := r.db.ExecContext(ctx, `DELETE m FROM message m
+JOIN translation t ON t.id = m.translation_id
+WHERE t.service_id = UUID_TO_BIN(?)`, serviceID)
+		if err != nil {
+			return fmt.Errorf("repo: delete service messages: %w", err)
+		}
+
+		_, err = r.db.ExecContext(ctx, `DELETE FROM translation WHERE service_id = UUID_TO_BIN(?)`, serviceID)
+		if err != nil {
+			return fmt.Errorf("repo: delete service translations: %w", err)
+		}
+
+		result, err := r.db.ExecContext(ctx, `DELETE FROM service WHERE id = UUID_TO_BIN(?)`, serviceID)
+		if err != nil {
+			return fmt.Errorf("repo: delete service: %w", err)
+		}
+
+		switch count, err := result.RowsAffected(); {
+		default:
+			return nil
+		case err != nil:
+			return fmt.Errorf("repo: delete service result: %w", err)
+		case count == 0:
+			return repo.ErrNotFound
+		}
+	})
 }
@@ -5,14 +5,32 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"go.expect.digital/translate/pkg/model"
 	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/repo/internal/sqlrepo"
 	"golang.org/x/text/language"
 )
 
+// upsertMessageQuery inserts or updates a message, the ordinal orders the messages of the translation.
+const upsertMessageQuery = `INSERT INTO message
+	(translation_id, id, message, description, plural_id, positions, status, status_reason, quality_score, ordinal)
+VALUES
+	(UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+	message = VALUES(message),
+	description = VALUES(description),
+	plural_id = VALUES(plural_id),
+	positions = VALUES(positions),
+	status = VALUES(status),
+	status_reason = VALUES(status_reason),
+	quality_score = VALUES(quality_score),
+	ordinal = VALUES(ordinal)`
+
 func (r *Repo) SaveTranslation(ctx context.Context, serviceID uuid.UUID, translation *model.Translation) error {
 	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
 		_, err := r.LoadService(ctx, serviceID)
@@ -21,32 +39,68 @@ func (r *Repo) SaveTranslation(ctx context.Context, serviceID uuid.UUID, transla
 		}
 
 		// Check if translation already exist
-		var translationID uuid.UUID
+		var (
+			translationID uuid.UUID
+			version       int
+			original      bool
+			previous      []model.Message // messages replaced by the translation
+		)
 
 		row := r.db.QueryRowContext(
 			ctx,
-			`SELECT id FROM translation WHERE service_id = UUID_TO_BIN(?) AND language = ?`,
+			`SELECT id, version, original FROM translation
+WHERE service_id = UUID_TO_BIN(?) AND language = ? FOR UPDATE`,
 			serviceID,
 			translation.Language.String(),
 		)
 
 		// Check if translation already exists, if not, create a new one
-		switch err = row.Scan(&translationID); {
-		// Translation already exists
+		switch err = row.Scan(&translationID, &version, &original); {
+		// Translation already exists, the translation is replaced
 		default:
-			// noop
+			if translation.Version != 0 && translation.Version != version {
+				return repo.ErrConflict
+			}
+
+			previous, err = sqlrepo.MySQL.LoadMessages(ctx, r.db, translationID)
+			if err != nil {
+				return err
+			}
+
+			// The version is not bumped and nothing is written if the translation is unchanged.
+			if original == translation.Original && translation.EqualMessages(&model.Translation{Messages: previous}) {
+				translation.Version = version
+
+				return nil
+			}
+
+			version++
+
+			_, err = r.db.ExecContext(ctx, `UPDATE translation SET original = ?, version = ? WHERE id = UUID_TO_BIN(?)`,
+				translation.Original, version, translationID)
+			if err != nil {
+				return fmt.Errorf("repo: update translation: %w", err)
+			}
+
+			err = r.deleteRemovedMessages(ctx, translationID, previous, translation)
+			if err != nil {
+				return err
+			}
 
 		// Translation does not exist
 		case errors.Is(err, sql.ErrNoRows):
 			translationID = uuid.New()
+			version = 1
 
 			_, err = r.db.ExecContext(
 				ctx,
-				`INSERT INTO translation (id, service_id, language, original) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?)`,
+				`INSERT INTO translation (id, service_id, language, original, version)
+VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?)`,
 				translationID,
 				serviceID,
 				translation.Language.String(),
 				translation.Original,
+				version,
 			)
 			if err != nil {
 				return fmt.Errorf("repo: insert message: %w", err)
@@ -59,86 +113,220 @@ func (r *Repo) SaveTranslation(ctx context.Context, serviceID uuid.UUID, transla
 
 		// Insert into message table,
 		// on duplicate message.id and message.translation_id,
-		// update message's message, description and status values.
-		stmt, err := r.db.PrepareContext(
-			ctx,
-			`INSERT INTO message
-	(translation_id, id, message, description, positions, status)
-VALUES
-	(UUID_TO_BIN(?), ?, ?, ?, ?, ?)
-ON DUPLICATE KEY UPDATE
-	message = VALUES(message),
-	description = VALUES(description),
-	positions = VALUES(positions),
-	status = VALUES(status)`,
-		)
+		// update message's message, description, status and ordinal values.
+		// Every message is upserted, the ordinal keeps the order of the messages.
+		stmt, err := r.db.PrepareContext(ctx, upsertMessageQuery)
 		if err != nil {
 			return fmt.Errorf("repo: prepare stmt to insert message: %w", err)
 		}
 		defer stmt.Close()
 
-		for _, m := range translation.Messages {
+		for i, m := range translation.Messages {
 			_, err = stmt.ExecContext(
 				ctx,
 				translationID,
 				m.ID,
 				m.Message,
 				m.Description,
+				m.PluralID,
 				&m.Positions,
 				&m.Status,
+				m.StatusReason,
+				m.QualityScore,
+				i,
 			)
 			if err != nil {
 				return fmt.Errorf("repo: insert message: %w", err)
 			}
 		}
 
+		now := time.Now().UTC().Truncate(time.Microsecond) // MySQL DATETIME(6) precision
+		revisions := model.NewMessageRevisions(translation.Language, previous, translation.Messages,
+			repo.ActorFromContext(ctx), now)
+
+		err = sqlrepo.MySQL.InsertMessageRevisions(ctx, r.db, translationID, revisions)
+		if err != nil {
+			return err
+		}
+
+		translation.Version = version
+
+		return nil
+	})
+}
+
+// deleteRemovedMessages deletes the previous messages of the translation missing in the saved translation.
+func (r *Repo) deleteRemovedMessages(ctx context.Context, translationID uuid.UUID, previous []model.Message,
+	translation *model.Translation,
+) error {
+	removed := (&model.Translation{Messages: previous}).FindRemovedMessages(translation)
+	if len(removed) == 0 {
 		return nil
+	}
+
+	// Compare byte-wise, binary_id is the byte-wise copy of id, which uses a case-insensitive collation.
+	stmt, err := r.db.PrepareContext(ctx,
+		`DELETE FROM message WHERE translation_id = UUID_TO_BIN(?) AND binary_id = ?`)
+	if err != nil {
+		return fmt.Errorf("repo: prepare stmt to delete message: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, m := range removed {
+		_, err = stmt.ExecContext(ctx, translationID, m.ID)
+		if err != nil {
+			return fmt.Errorf("repo: delete message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repo) SaveMessage(ctx context.Context, serviceID uuid.UUID, lang language.Tag, message *model.Message,
+) error {
+	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
+		var translationID uuid.UUID
+
+		row := r.db.QueryRowContext(ctx,
+			`SELECT id FROM translation WHERE service_id = UUID_TO_BIN(?) AND language = ? FOR UPDATE`,
+			serviceID, lang.String())
+
+		switch err := row.Scan(&translationID); {
+		case errors.Is(err, sql.ErrNoRows):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("repo: scan translation: %w", err)
+		}
+
+		_, err := r.db.ExecContext(ctx, `UPDATE translation SET version = version + 1 WHERE id = UUID_TO_BIN(?)`,
+			translationID)
+		if err != nil {
+			return fmt.Errorf("repo: update translation version: %w", err)
+		}
+
+		var (
+			previous []model.Message
+			old      = model.Message{ID: message.ID}
+			ordinal  int
+		)
+
+		row = r.db.QueryRowContext(ctx,
+			`SELECT message, status, ordinal FROM message WHERE translation_id = UUID_TO_BIN(?) AND binary_id = ?`,
+			translationID, message.ID)
+
+		switch err = row.Scan(&old.Message, &old.Status, &ordinal); {
+		default:
+			previous = append(previous, old)
+		case errors.Is(err, sql.ErrNoRows):
+			// A new message is ordered after the other messages.
+			err = r.db.QueryRowContext(ctx,
+				`SELECT COALESCE(MAX(ordinal) + 1, 0) FROM message WHERE translation_id = UUID_TO_BIN(?)`,
+				translationID).Scan(&ordinal)
+			if err != nil {
+				return fmt.Errorf("repo: scan message ordinal: %w", err)
+			}
+		case err != nil:
+			return fmt.Errorf("repo: scan message: %w", err)
+		}
+
+		_, err = r.db.ExecContext(ctx, upsertMessageQuery,
+			translationID,
+			message.ID,
+			message.Message,
+			message.Description,
+			message.PluralID,
+			&message.Positions,
+			&message.Status,
+			message.StatusReason,
+			message.QualityScore,
+			ordinal,
+		)
+		if err != nil {
+			return fmt.Errorf("repo: upsert message: %w", err)
+		}
+
+		now := time.Now().UTC().Truncate(time.Microsecond) // MySQL DATETIME(6) precision
+		revisions := model.NewMessageRevisions(lang, previous, []model.Message{*message},
+			repo.ActorFromContext(ctx), now)
+
+		return sqlrepo.MySQL.InsertMessageRevisions(ctx, r.db, translationID, revisions) //nolint:wrapcheck
 	})
 }
 
+func (r *Repo) LoadMessage(ctx context.Context, serviceID uuid.UUID, lang language.Tag, messageID string,
+) (*model.Message, error) {
+	translations, err := r.LoadTranslations(ctx, serviceID, repo.LoadTranslationsOpts{
+		FilterLanguages:  []language.Tag{lang},
+		FilterMessageIDs: []string{messageID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(translations) == 0 || len(translations[0].Messages) == 0 {
+		return nil, repo.ErrNotFound
+	}
+
+	return &translations[0].Messages[0], nil
+}
+
 func (r *Repo) LoadTranslations(ctx context.Context, serviceID uuid.UUID, opts repo.LoadTranslationsOpts,
 ) (model.Translations, error) {
-	rows, err := sq.
-		Select("m.id, m.message, m.description, m.positions, m.status, t.language, t.original").
+	// Translations are loaded separately from messages to include translations without messages.
+	translationsLookup, err := r.loadTranslations(ctx, serviceID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	query := sq.
+		Select("m.id, m.message, m.description, m.plural_id, m.positions, m.status, m.status_reason, "+
+			"m.quality_score, t.language").
 		From("message m").
 		Join("translation t ON t.id = m.translation_id").
 		Where("t.service_id = UUID_TO_BIN(?)", serviceID).
-		Where(eq("t.language", langToStringSlice(opts.FilterLanguages))).
-		RunWith(r.db).
-		QueryContext(ctx)
+		Where(filterTranslations("t", opts)).
+		Where(filterMessages(opts))
+
+	// Compare byte-wise, m.binary_id is the byte-wise copy of m.id.
+	if opts.Limit > 0 {
+		query = query.OrderBy("t.language", "m.binary_id").Limit(uint64(opts.Limit)) //nolint:gosec
+	} else {
+		query = query.OrderBy("m.ordinal") // keep the order of the saved messages
+	}
+
+	rows, err := query.RunWith(r.db).QueryContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("repo: query translations: %w", err)
+		return nil, fmt.Errorf("repo: query messages: %w", err)
 	}
 
 	defer rows.Close()
 
-	translationsLookup := make(map[string]*model.Translation)
-
 	for rows.Next() {
 		var (
-			msg      model.Message
-			lang     string
-			original bool
+			msg          model.Message
+			pluralID     sql.NullString
+			statusReason sql.NullString
+			qualityScore sql.NullFloat64
+			lang         string
 		)
 
-		err = rows.Scan(&msg.ID, &msg.Message, &msg.Description, &msg.Positions, &msg.Status, &lang, &original)
+		err = rows.Scan(&msg.ID, &msg.Message, &msg.Description, &pluralID, &msg.Positions, &msg.Status,
+			&statusReason, &qualityScore, &lang)
 		if err != nil {
 			return nil, fmt.Errorf("repo: scan message: %w", err)
 		}
 
-		// Lookup translation by language
-		translation, ok := translationsLookup[lang]
-		// If not found, create a new one
-		if !ok {
-			translation = &model.Translation{
-				Language: language.MustParse(lang),
-				Original: original,
-			}
-			// Add to lookup
-			translationsLookup[lang] = translation
+		msg.PluralID = pluralID.String // NULL for messages saved before plural IDs were persisted
+		msg.StatusReason = statusReason.String
+
+		if qualityScore.Valid {
+			msg.QualityScore = &qualityScore.Float64
 		}
+
 		// Add scanned message to translation
-		translation.Messages = append(translation.Messages, msg)
+		if translation, ok := translationsLookup[lang]; ok {
+			translation.Messages = append(translation.Messages, msg)
+		}
 	}
 
 	err = rows.Err()
@@ -154,6 +342,84 @@ func (r *Repo) LoadTranslations(ctx context.Context, serviceID uuid.UUID, opts r
 	return allTranslations, nil
 }
 
+// loadTranslations loads the service translations without messages by language.
+func (r *Repo) loadTranslations(ctx context.Context, serviceID uuid.UUID, opts repo.LoadTranslationsOpts,
+) (map[string]*model.Translation, error) {
+	rows, err := sq.
+		Select("language, original, version").
+		From("translation").
+		Where("service_id = UUID_TO_BIN(?)", serviceID).
+		Where(filterTranslations("translation", opts)).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query translations: %w", err)
+	}
+
+	defer rows.Close()
+
+	translationsLookup := make(map[string]*model.Translation)
+
+	for rows.Next() {
+		var (
+			lang     string
+			original bool
+			version  int
+		)
+
+		err = rows.Scan(&lang, &original, &version)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan translation: %w", err)
+		}
+
+		translationsLookup[lang] = &model.Translation{
+			Language: language.MustParse(lang),
+			Original: original,
+			Version:  version,
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan translations: %w", err)
+	}
+
+	return translationsLookup, nil
+}
+
+// DeleteTranslation deletes the translation of the service language with its messages.
+func (r *Repo) DeleteTranslation(ctx context.Context, serviceID uuid.UUID, language language.Tag) error {
+	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
+		var translationID uuid.UUID
+
+		row := r.db.QueryRowContext(
+			ctx,
+			`SELECT id FROM translation WHERE service_id = UUID_TO_BIN(?) AND language = ?`,
+			serviceID,
+			language.String(),
+		)
+
+		switch err := row.Scan(&translationID); {
+		case errors.Is(err, sql.ErrNoRows):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("repo: scan translation: %w", err)
+		}
+
+		_, err := r.db.ExecContext(ctx, `DELETE FROM message WHERE translation_id = UUID_TO_BIN(?)`, translationID)
+		if err != nil {
+			return fmt.Errorf("repo: delete translation messages: %w", err)
+		}
+
+		_, err = r.db.ExecContext(ctx, `DELETE FROM translation WHERE id = UUID_TO_BIN(?)`, translationID)
+		if err != nil {
+			return fmt.Errorf("repo: delete translation: %w", err)
+		}
+
+		return nil
+	})
+}
+
 // helpers
 
 func langToStringSlice(languages []language.Tag) []string {
@@ -164,3 +430,58 @@ func langToStringSlice(languages []language.Tag) []string {
 
 	return lt
 }
+
+// filterTranslations returns the conditions selecting translations of the table by opts.
+func filterTranslations(table string, opts repo.LoadTranslationsOpts) sq.And {
+	cond := sq.And{eq(table+".language", langToStringSlice(opts.FilterLanguages))}
+
+	if opts.FilterOriginal {
+		cond = append(cond, sq.Eq{table + ".original": true})
+	}
+
+	return cond
+}
+
+// filterMessages returns the conditions selecting messages of the translations joined as m and t by opts.
+// Message IDs and the pagination key are compared byte-wise by m.binary_id, the byte-wise copy of m.id,
+// the message text is compared lowercase and byte-wise.
+func filterMessages(opts repo.LoadTranslationsOpts) sq.And {
+	cond := sq.And{
+		eq("m.binary_id", opts.FilterMessageIDs),
+		eq("m.status", statusToStringSlice(opts.FilterStatuses)),
+	}
+
+	if opts.FilterMessageIDPrefix != "" {
+		cond = append(cond, sq.Expr("m.binary_id LIKE ?", escapeLike(opts.FilterMessageIDPrefix)+"%"))
+	}
+
+	if opts.FilterText != "" {
+		cond = append(cond, sq.Expr("LOWER(m.message) COLLATE utf8mb4_bin LIKE ?",
+			"%"+escapeLike(strings.ToLower(opts.FilterText))+"%"))
+	}
+
+	if opts.FilterMaxQualityScore != nil {
+		cond = append(cond, sq.LtOrEq{"m.quality_score": *opts.FilterMaxQualityScore})
+	}
+
+	if opts.After != (repo.MessageKey{}) {
+		cond = append(cond, sq.Expr("(t.language > ? OR (t.language = ? AND m.binary_id > ?))",
+			opts.After.Language.String(), opts.After.Language.String(), opts.After.ID))
+	}
+
+	return cond
+}
+
+func statusToStringSlice(statuses []model.MessageStatus) []string {
+	s := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		s = append(s, status.String())
+	}
+
+	return s
+}
+
+// escapeLike escapes the LIKE wildcards in s with a backslash, the default escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,105 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+// AddUsage adds the usage characters and requests to the counters of the service, translator and day.
+func (r *Repo) AddUsage(ctx context.Context, usage *model.Usage) error {
+	query := `INSERT INTO translator_usage
+	(service_id, date, translator, characters, requests)
+VALUES
+	(UUID_TO_BIN(?), ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+	characters = characters + VALUES(characters),
+	requests = requests + VALUES(requests)`
+
+	usage.Date = model.UsageDate(usage.Date)
+
+	_, err := r.db.ExecContext(ctx, query,
+		usage.ServiceID,
+		usage.Date.Format(time.DateOnly),
+		usage.Translator,
+		usage.Characters,
+		usage.Requests,
+	)
+	if err != nil {
+		return fmt.Errorf("repo: insert usage: %w", err)
+	}
+
+	return nil
+}
+
+// LoadUsage returns the usage of the service ordered by date and translator.
+func (r *Repo) LoadUsage(ctx context.Context, serviceID uuid.UUID, opts repo.LoadUsageOpts) ([]model.Usage, error) {
+	where := sq.And{sq.Expr("service_id = UUID_TO_BIN(?)", serviceID)}
+
+	if !opts.FilterFrom.IsZero() {
+		where = append(where, sq.GtOrEq{"date": model.UsageDate(opts.FilterFrom).Format(time.DateOnly)})
+	}
+
+	// Include days up to the day of the last instant before FilterTo.
+	if !opts.FilterTo.IsZero() {
+		where = append(where, sq.LtOrEq{"date": model.UsageDate(opts.FilterTo.Add(-time.Nanosecond)).Format(time.DateOnly)})
+	}
+
+	rows, err := sq.
+		Select("service_id, date, translator, characters, requests").
+		From("translator_usage").
+		Where(where).
+		OrderBy("date", "translator").
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query usage: %w", err)
+	}
+
+	defer rows.Close()
+
+	var usage []model.Usage
+
+	for rows.Next() {
+		var u model.Usage
+
+		err = rows.Scan(&u.ServiceID, &u.Date, &u.Translator, &u.Characters, &u.Requests)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan usage: %w", err)
+		}
+
+		usage = append(usage, u)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+// LockUsage locks the service row until the end of the transaction.
+// Locking the usage rows instead takes gap locks when the row of the day does not exist yet,
+// and concurrent inserts of the row deadlock.
+func (r *Repo) LockUsage(ctx context.Context, serviceID uuid.UUID) error {
+	if _, ok := r.db.(*sql.Tx); !ok {
+		return nil
+	}
+
+	var id []byte
+
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM service WHERE id = UUID_TO_BIN(?) FOR UPDATE`, serviceID).Scan(&id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("repo: lock service: %w", err)
+	}
+
+	return nil
+}
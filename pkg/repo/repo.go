@@ -4,35 +4,203 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"go.expect.digital/translate/pkg/model"
 	"golang.org/x/text/language"
 )
 
-var ErrNotFound = errors.New("entity not found")
+var (
+	ErrNotFound = errors.New("entity not found")
+	// ErrConflict is returned when an entity was changed since it was loaded, see model.Service.Version.
+	ErrConflict = errors.New("entity version conflict")
+)
+
+type ProjectsRepo interface {
+	// SaveProject handles both Create and Update.
+	SaveProject(ctx context.Context, project *model.Project) error
+	LoadProject(ctx context.Context, projectID uuid.UUID) (*model.Project, error)
+	LoadProjects(ctx context.Context) ([]model.Project, error)
+	// DeleteProject deletes the project, the project must not have services.
+	DeleteProject(ctx context.Context, projectID uuid.UUID) error
+}
+
+type LoadServicesOpts struct {
+	FilterProjectID uuid.UUID
+}
 
 type ServicesRepo interface {
-	// SaveService handles both Create and Update
+	// SaveService handles both Create and Update and increments the service version.
+	// A service with a non-zero version is saved only if it has the stored version, otherwise ErrConflict is returned.
+	// A service without a project is saved to model.DefaultProjectID.
 	SaveService(ctx context.Context, service *model.Service) error
 	LoadService(ctx context.Context, serviceID uuid.UUID) (*model.Service, error)
-	LoadServices(ctx context.Context) ([]model.Service, error)
+	LoadServices(ctx context.Context, opts LoadServicesOpts) ([]model.Service, error)
+	// DeleteService deletes the service with its translations, jobs, usage and webhooks.
 	DeleteService(ctx context.Context, serviceID uuid.UUID) error
 }
 
+// MessageKey identifies a message of a service translation.
+type MessageKey struct {
+	Language language.Tag
+	ID       string
+}
+
+// LoadTranslationsOpts filters the loaded translations and their messages.
+// Language and original filters select translations, the other options select messages
+// of the selected translations - translations without selected messages are loaded without messages.
 type LoadTranslationsOpts struct {
+	// FilterMaxQualityScore keeps messages scored at most the score, unscored messages are dropped.
+	FilterMaxQualityScore *float64
+	// After keeps messages ordered after the key by language and ID, for pagination. Zero for no lower bound.
+	After MessageKey
+	// FilterMessageIDPrefix keeps messages with IDs starting with the prefix, case-sensitive.
+	FilterMessageIDPrefix string
+	// FilterText keeps messages containing the text, case-insensitive: both are lowercased by Unicode rules
+	// and compared byte-wise, so accents are significant.
+	FilterText       string
+	FilterLanguages  []language.Tag
+	FilterMessageIDs []string
+	FilterStatuses   []model.MessageStatus
+	// Limit is the max number of messages loaded, 0 for no limit.
+	// When set, messages are ordered by language and ID, compared byte-wise.
+	Limit          int
+	FilterOriginal bool
+}
+
+// SearchMessagesOpts selects the messages found by TranslationsRepo.SearchMessages.
+type SearchMessagesOpts struct {
+	// Query is the searched text, each word of the query must start a word of the message text or ID,
+	// case-insensitive, see model.SearchTerms.
+	Query           string
 	FilterLanguages []language.Tag
+	FilterStatuses  []model.MessageStatus
+	// Limit is the max number of messages found, 0 for no limit.
+	Limit int
 }
 
 type TranslationsRepo interface {
-	// SaveTranslation handles both Create and Update
+	// SaveTranslation handles both Create and Update and increments the translation version,
+	// unless the stored translation is unchanged.
+	// The stored messages are replaced: messages missing in the translation are deleted,
+	// and the messages are loaded in the saved order.
+	// A translation with a non-zero version is saved only if it has the stored version,
+	// otherwise ErrConflict is returned.
+	// A revision is recorded for every added message and every message changed in text or status,
+	// with the actor of the context, see WithActor.
 	SaveTranslation(ctx context.Context, serviceID uuid.UUID, translation *model.Translation) error
 	LoadTranslations(ctx context.Context, serviceID uuid.UUID, opts LoadTranslationsOpts) (model.Translations, error)
+	// SaveMessage handles both Create and Update of a message of the service translation
+	// and increments the translation version, the other messages are not changed.
+	// A new message is added after the other messages. ErrNotFound is returned if the translation does not exist.
+	// A revision is recorded if the message is added or changed in text or status, as in SaveTranslation.
+	SaveMessage(ctx context.Context, serviceID uuid.UUID, language language.Tag, message *model.Message) error
+	// LoadMessage returns the message of the service translation, ErrNotFound if it does not exist.
+	LoadMessage(ctx context.Context, serviceID uuid.UUID, language language.Tag, messageID string,
+	) (*model.Message, error)
+	// DeleteTranslation deletes the translation of the service language with its messages.
+	DeleteTranslation(ctx context.Context, serviceID uuid.UUID, language language.Tag) error
+	// LoadMessageRevisions returns the revisions of the message of the service translation, newest first.
+	LoadMessageRevisions(ctx context.Context, serviceID uuid.UUID, language language.Tag, messageID string,
+	) ([]model.MessageRevision, error)
+	// LoadTranslationRevisions returns the message revisions of the service translation, oldest first.
+	LoadTranslationRevisions(ctx context.Context, serviceID uuid.UUID, language language.Tag,
+	) ([]model.MessageRevision, error)
+	// ReplaceTranslationRevisions replaces the message revisions of the service translation,
+	// e.g. to restore the revisions of a backup. ErrNotFound is returned if the translation does not exist.
+	ReplaceTranslationRevisions(ctx context.Context, serviceID uuid.UUID, language language.Tag,
+		revisions []model.MessageRevision) error
+	// DeleteMessageRevisions deletes the message revisions of all services created before the time.
+	DeleteMessageRevisions(ctx context.Context, before time.Time) error
+	// SearchMessages returns the messages of all services matching the query,
+	// ordered by service ID, language and message ID.
+	SearchMessages(ctx context.Context, opts SearchMessagesOpts) ([]model.SearchResult, error)
+}
+
+// JobKey identifies a job in the order of the loaded jobs.
+type JobKey struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type LoadJobsOpts struct {
+	// After keeps jobs ordered after the key, for pagination. Zero for no lower bound.
+	After           JobKey
+	FilterStatuses  []model.JobStatus
+	FilterServiceID uuid.UUID
+	// Limit is the max number of jobs loaded, 0 for no limit.
+	Limit int
+}
+
+type JobsRepo interface {
+	// SaveJob handles both Create and Update
+	SaveJob(ctx context.Context, job *model.Job) error
+	LoadJob(ctx context.Context, jobID uuid.UUID) (*model.Job, error)
+	// LoadJobs returns jobs ordered by creation time and ID, oldest first. IDs are compared byte-wise.
+	LoadJobs(ctx context.Context, opts LoadJobsOpts) ([]model.Job, error)
+	// DeleteJobs deletes the finished jobs of all services last updated before the time.
+	DeleteJobs(ctx context.Context, before time.Time) error
+	// ClaimJob atomically sets the job running by the owner until leaseUntil if the job is pending,
+	// running with an expired lease, or already claimed by the owner, e.g. to extend the lease.
+	// On success the status, owner and lease of the job are updated.
+	// ErrConflict is returned if the job is finished or claimed by another owner, ErrNotFound if it does not exist.
+	ClaimJob(ctx context.Context, job *model.Job, owner uuid.UUID, leaseUntil time.Time) error
+}
+
+type LoadUsageOpts struct {
+	// FilterFrom includes usage from the day of FilterFrom, zero for no lower bound.
+	FilterFrom time.Time
+	// FilterTo includes usage of days starting before FilterTo, zero for no upper bound.
+	FilterTo time.Time
+}
+
+type UsageRepo interface {
+	// AddUsage adds the usage characters and requests to the counters of the service, translator and day.
+	AddUsage(ctx context.Context, usage *model.Usage) error
+	// LoadUsage returns the usage of the service ordered by date and translator.
+	LoadUsage(ctx context.Context, serviceID uuid.UUID, opts LoadUsageOpts) ([]model.Usage, error)
+	// LockUsage locks the usage of the service until the end of the transaction, so that the usage loaded
+	// in the transaction does not change until AddUsage, e.g. when the quota is checked before adding the usage.
+	// Outside a transaction it does nothing.
+	LockUsage(ctx context.Context, serviceID uuid.UUID) error
+}
+
+type LoadWebhookDeliveriesOpts struct {
+	FilterStatuses  []model.WebhookDeliveryStatus
+	FilterServiceID uuid.UUID
+	FilterWebhookID uuid.UUID
+}
+
+type WebhooksRepo interface {
+	// SaveWebhook handles both Create and Update.
+	SaveWebhook(ctx context.Context, webhook *model.Webhook) error
+	LoadWebhook(ctx context.Context, serviceID, webhookID uuid.UUID) (*model.Webhook, error)
+	// LoadWebhooks returns the webhooks of the service ordered by creation time, oldest first.
+	LoadWebhooks(ctx context.Context, serviceID uuid.UUID) ([]model.Webhook, error)
+	// DeleteWebhook deletes the webhook of the service with its deliveries.
+	DeleteWebhook(ctx context.Context, serviceID, webhookID uuid.UUID) error
+	// SaveWebhookDelivery handles both Create and Update.
+	SaveWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+	// LoadWebhookDeliveries returns deliveries ordered by creation time, oldest first.
+	LoadWebhookDeliveries(ctx context.Context, opts LoadWebhookDeliveriesOpts) ([]model.WebhookDelivery, error)
+	// ClaimWebhookDelivery atomically moves the next attempt of the pending delivery due for an attempt to until,
+	// so that other servers skip the delivery while it is sent. On success NextAttemptAt of the delivery is updated.
+	// ErrConflict is returned if the delivery is not pending or not due, e.g. claimed by another server,
+	// ErrNotFound if it does not exist.
+	ClaimWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery, until time.Time) error
+	// DeleteWebhookDeliveries deletes the deliveries of all services created before the time,
+	// pending deliveries are kept.
+	DeleteWebhookDeliveries(ctx context.Context, before time.Time) error
 }
 
 type Repo interface {
+	ProjectsRepo
 	ServicesRepo
 	TranslationsRepo
+	JobsRepo
+	UsageRepo
+	WebhooksRepo
 
 	io.Closer
 
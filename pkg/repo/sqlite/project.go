@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+func (r *Repo) SaveProject(ctx context.Context, project *model.Project) error {
+	query := `INSERT INTO project (id, name) VALUES (?, ?)
+ON CONFLICT (id) DO UPDATE SET name = excluded.name`
+
+	if project.ID == uuid.Nil {
+		project.ID = uuid.New()
+	}
+
+	_, err := r.db.ExecContext(ctx, query, project.ID, project.Name)
+	if err != nil {
+		return fmt.Errorf("repo: insert project: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) LoadProject(ctx context.Context, projectID uuid.UUID) (*model.Project, error) {
+	var project model.Project
+
+	row := r.db.QueryRowContext(ctx, `SELECT id, name FROM project WHERE id = ?`, projectID)
+
+	switch err := row.Scan(&project.ID, &project.Name); {
+	default:
+		return &project, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, repo.ErrNotFound
+	case err != nil:
+		return nil, fmt.Errorf("repo: select project: %w", err)
+	}
+}
+
+func (r *Repo) LoadProjects(ctx context.Context) ([]model.Project, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name FROM project`)
+	if err != nil {
+		return nil, fmt.Errorf("repo: select projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []model.Project
+
+	for rows.Next() {
+		var project model.Project
+
+		err = rows.Scan(&project.ID, &project.Name)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan project: %w", err)
+		}
+
+		projects = append(projects, project)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// DeleteProject deletes the project, the foreign key of the services prevents deleting a project with services.
+func (r *Repo) DeleteProject(ctx context.Context, projectID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM project WHERE id = ?`, projectID)
+	if err != nil {
+		return fmt.Errorf("repo: delete project: %w", err)
+	}
+
+	switch count, err := result.RowsAffected(); {
+	default:
+		return nil
+	case err != nil:
+		return fmt.Errorf("repo: delete project result: %w", err)
+	case count == 0:
+		return repo.ErrNotFound
+	}
+}
@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo/internal/sqlrepo"
+	"golang.org/x/text/language"
+)
+
+// LoadMessageRevisions returns the revisions of the message of the service translation, newest first.
+func (r *Repo) LoadMessageRevisions(ctx context.Context, serviceID uuid.UUID, language language.Tag,
+	messageID string,
+) ([]model.MessageRevision, error) {
+	return sqlrepo.SQLite.LoadMessageRevisions(ctx, r.db, serviceID, language, messageID) //nolint:wrapcheck
+}
+
+// LoadTranslationRevisions returns the message revisions of the service translation, oldest first.
+func (r *Repo) LoadTranslationRevisions(ctx context.Context, serviceID uuid.UUID, language language.Tag,
+) ([]model.MessageRevision, error) {
+	return sqlrepo.SQLite.LoadTranslationRevisions(ctx, r.db, serviceID, language) //nolint:wrapcheck
+}
+
+// ReplaceTranslationRevisions replaces the message revisions of the service translation.
+func (r *Repo) ReplaceTranslationRevisions(ctx context.Context, serviceID uuid.UUID, language language.Tag,
+	revisions []model.MessageRevision,
+) error {
+	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
+		return sqlrepo.SQLite.ReplaceTranslationRevisions(ctx, r.db, serviceID, language, revisions) //nolint:wrapcheck
+	})
+}
+
+// DeleteMessageRevisions deletes the message revisions created before the time.
+func (r *Repo) DeleteMessageRevisions(ctx context.Context, before time.Time) error {
+	return sqlrepo.SQLite.DeleteMessageRevisions(ctx, r.db, before) //nolint:wrapcheck
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+)
+
+// SearchMessages returns the messages of all services matching the query,
+// ordered by service ID, language and message ID.
+// Messages are found in the message_search FTS5 table by the word prefixes of the query terms.
+func (r *Repo) SearchMessages(ctx context.Context, opts repo.SearchMessagesOpts) ([]model.SearchResult, error) {
+	terms := model.SearchTerms(opts.Query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	// Terms are words without quotes, each term is a quoted prefix query.
+	match := make([]string, 0, len(terms))
+	for _, term := range terms {
+		match = append(match, `"`+term+`"*`)
+	}
+
+	query := sq.
+		Select("t.service_id, t.language, m.id, m.message, m.description, m.plural_id, m.positions, m.status, "+
+			"m.status_reason, m.quality_score").
+		From("message_search").
+		Join("message m ON m.rowid = message_search.rowid").
+		Join("translation t ON t.id = m.translation_id").
+		Where("message_search MATCH ?", strings.Join(match, " AND ")).
+		Where(eq("t.language", langToStringSlice(opts.FilterLanguages))).
+		Where(eq("m.status", statusToStringSlice(opts.FilterStatuses))).
+		OrderBy("t.service_id", "t.language", "m.id")
+
+	if opts.Limit > 0 {
+		query = query.Limit(uint64(opts.Limit)) //nolint:gosec
+	}
+
+	rows, err := query.RunWith(r.db).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query message search: %w", err)
+	}
+
+	defer rows.Close()
+
+	var results []model.SearchResult
+
+	for rows.Next() {
+		var (
+			result       model.SearchResult
+			lang         string
+			pluralID     sql.NullString
+			statusReason sql.NullString
+			qualityScore sql.NullFloat64
+		)
+
+		msg := &result.Message
+
+		err = rows.Scan(&result.ServiceID, &lang, &msg.ID, &msg.Message, &msg.Description, &pluralID, &msg.Positions,
+			&msg.Status, &statusReason, &qualityScore)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan found message: %w", err)
+		}
+
+		result.Language, err = language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("repo: parse language of found message: %w", err)
+		}
+
+		msg.PluralID = pluralID.String
+		msg.StatusReason = statusReason.String
+
+		if qualityScore.Valid {
+			msg.QualityScore = &qualityScore.Float64
+		}
+
+		results = append(results, result)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan found messages: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,163 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+func (r *Repo) SaveService(ctx context.Context, service *model.Service) error {
+	query := `INSERT INTO service (id, name, translator_routing, monthly_character_quota, version, project_id)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+	name = excluded.name,
+	translator_routing = excluded.translator_routing,
+	monthly_character_quota = excluded.monthly_character_quota,
+	version = excluded.version,
+	project_id = excluded.project_id`
+
+	if service.ID == uuid.Nil {
+		service.ID = uuid.New()
+	}
+
+	if service.ProjectID == uuid.Nil {
+		service.ProjectID = model.DefaultProjectID
+	}
+
+	routing, err := json.Marshal(service.TranslatorRouting)
+	if err != nil {
+		return fmt.Errorf("repo: marshal service translator routing: %w", err)
+	}
+
+	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
+		version, err := r.nextVersion(ctx, service.Version,
+			`SELECT version FROM service WHERE id = ?`, service.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.ExecContext(ctx, query, service.ID, service.Name, string(routing), service.MonthlyCharacterQuota,
+			version, service.ProjectID)
+		if err != nil {
+			return fmt.Errorf("repo: insert service: %w", err)
+		}
+
+		service.Version = version
+
+		return nil
+	})
+}
+
+func (r *Repo) LoadService(ctx context.Context, serviceID uuid.UUID) (*model.Service, error) {
+	query := `SELECT id, name, translator_routing, monthly_character_quota, version, project_id FROM service WHERE id = ?`
+	row := r.db.QueryRowContext(ctx, query, serviceID)
+
+	var (
+		service model.Service
+		routing []byte
+	)
+
+	switch err := row.Scan(&service.ID, &service.Name, &routing, &service.MonthlyCharacterQuota, &service.Version,
+		&service.ProjectID); {
+	default:
+		err = unmarshalTranslatorRouting(routing, &service)
+		if err != nil {
+			return nil, err
+		}
+
+		return &service, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, repo.ErrNotFound
+	case err != nil:
+		return nil, fmt.Errorf("repo: select service: %w", err)
+	}
+}
+
+func (r *Repo) LoadServices(ctx context.Context, opts repo.LoadServicesOpts) ([]model.Service, error) {
+	where := sq.And{}
+
+	if opts.FilterProjectID != uuid.Nil {
+		where = append(where, sq.Eq{"project_id": opts.FilterProjectID})
+	}
+
+	rows, err := sq.
+		Select("id, name, translator_routing, monthly_character_quota, version, project_id").
+		From("service").
+		Where(where).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: select services: %w", err)
+	}
+	defer rows.Close()
+
+	var services []model.Service
+
+	for rows.Next() {
+		var (
+			service model.Service
+			routing []byte
+		)
+
+		err = rows.Scan(&service.ID, &service.Name, &routing, &service.MonthlyCharacterQuota, &service.Version,
+			&service.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan service: %w", err)
+		}
+
+		err = unmarshalTranslatorRouting(routing, &service)
+		if err != nil {
+			return nil, err
+		}
+
+		services = append(services, service)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan services: %w", err)
+	}
+
+	return services, nil
+}
+
+// unmarshalTranslatorRouting sets the service translator routing from the translator_routing column, if set.
+func unmarshalTranslatorRouting(routing []byte, service *model.Service) error {
+	if len(routing) == 0 {
+		return nil
+	}
+
+	err := json.Unmarshal(routing, &service.TranslatorRouting)
+	if err != nil {
+		return fmt.Errorf("repo: unmarshal service translator routing: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteService deletes the service, translations, messages, jobs, usage and webhooks of the service
+// are deleted by the foreign key ON DELETE CASCADE.
+func (r *Repo) DeleteService(ctx context.Context, serviceID uuid.UUID) error {
+	query := `DELETE FROM service WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, serviceID)
+	if err != nil {
+		return fmt.Errorf("repo: delete service: %w", err)
+	}
+
+	switch count, err := result.RowsAffected(); {
+	default:
+		return nil
+	case err != nil:
+		return fmt.Errorf("repo: delete service result: %w", err)
+	case count == 0:
+		return repo.ErrNotFound
+	}
+}
@@ -0,0 +1,143 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/XSAM/otelsql"
+	"github.com/spf13/viper"
+	"go.expect.digital/translate/migrate"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"modernc.org/sqlite" // SQLite driver
+)
+
+// The built-in lower() of SQLite converts ASCII letters only, unicode_lower() lowercases text like the other repos,
+// see repo.LoadTranslationsOpts.FilterText.
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("unicode_lower", 1,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			s, ok := args[0].(string)
+			if !ok {
+				return args[0], nil
+			}
+
+			return strings.ToLower(s), nil
+		})
+}
+
+type Conf struct {
+	Path string // Path to the database file, empty for in-memory storage.
+}
+
+func (c *Conf) ConnectionString() string {
+	file := c.Path
+	if file == "" {
+		file = ":memory:"
+	}
+
+	return "file:" + file + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
+
+func DefaultConf() *Conf {
+	return &Conf{
+		Path: viper.GetString("db.sqlite.path"),
+	}
+}
+
+// NewDB opens the SQLite database and checks that it has the latest schema version, see checkVersion.
+func NewDB(ctx context.Context, conf *Conf) (*sql.DB, error) {
+	db, err := openDB(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkVersion(ctx, migrate.NewSQLMigrator(db, migrate.SQLite, "sqlite"))
+	if err != nil {
+		db.Close() //nolint:errcheck
+		return nil, fmt.Errorf("check schema version: %w", err)
+	}
+
+	return db, nil
+}
+
+// checkVersion checks that the database has the latest schema version,
+// an empty database, e.g. in-memory storage, is migrated to the latest version.
+// Pending migrations of an existing database are applied with 'translate migrate up' or '--auto-migrate'.
+func checkVersion(ctx context.Context, m *migrate.SQLMigrator) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("schema status: %w", err)
+	}
+
+	switch {
+	case status.Dirty:
+		return fmt.Errorf("schema version %d is dirty, fix the schema and run 'translate migrate force'", status.Version)
+	case status.Version == 0:
+		err = m.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("migrate SQLite: %w", err)
+		}
+	case status.Version < status.Latest:
+		return fmt.Errorf("schema version %d is outdated, upgrade it to %d with 'translate migrate up'",
+			status.Version, status.Latest)
+	case status.Version > status.Latest:
+		return fmt.Errorf("schema version %d is newer than the supported version %d", status.Version, status.Latest)
+	}
+
+	return nil
+}
+
+// NewMigrator opens the SQLite database without applying migrations and returns its migrator.
+func NewMigrator(ctx context.Context, conf *Conf) (*migrate.SQLMigrator, error) {
+	db, err := openDB(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.NewSQLMigrator(db, migrate.SQLite, "sqlite"), nil
+}
+
+func openDB(ctx context.Context, conf *Conf) (*sql.DB, error) {
+	if conf.Path == "" {
+		log.Println("INFO: sqlite db path not provided: defaulting to in-memory storage")
+	}
+
+	// https://github.com/XSAM/otelsql
+	db, err := otelsql.Open(
+		"sqlite",
+		conf.ConnectionString(),
+		otelsql.WithAttributes(
+			semconv.DBSystemSqlite,
+			semconv.DBNameKey.String(conf.Path),
+		),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			Ping:                 false,
+			RowsNext:             false,
+			DisableErrSkip:       true,
+			DisableQuery:         false,
+			OmitConnResetSession: true,
+			OmitConnPrepare:      true,
+			OmitConnQuery:        true,
+			OmitRows:             true,
+			OmitConnectorConnect: true,
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("connect to SQLite: %w", err)
+	}
+
+	// SQLite allows a single writer, and an in-memory database exists only within its connection,
+	// so all queries share one connection.
+	db.SetMaxOpenConns(1)
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		db.Close() //nolint:errcheck
+		return nil, fmt.Errorf("ping SQLite: %w", err)
+	}
+
+	return db, nil
+}
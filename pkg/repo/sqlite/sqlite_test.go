@@ -0,0 +1,193 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.expect.digital/translate/migrate"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+)
+
+func Test_NewDB(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	conf := &Conf{Path: filepath.Join(t.TempDir(), "translate.db")}
+
+	r, err := NewRepo(WithConf(ctx, conf))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	service := rand.ModelService()
+
+	err = r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = r.SaveTranslation(ctx, service.ID, &model.Translation{
+		Language: language.English,
+		Messages: []model.Message{{ID: "hello", Message: "{{Hello}}"}},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = r.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Reopen, applied migrations are skipped and data is kept.
+	r, err = NewRepo(WithConf(ctx, conf))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer r.Close()
+
+	_, err = r.LoadService(ctx, service.ID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Translations and messages of the service are deleted with the service.
+	err = r.DeleteService(ctx, service.ID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var count int
+
+	err = r.db.QueryRowContext(ctx,
+		`SELECT (SELECT COUNT(*) FROM translation) + (SELECT COUNT(*) FROM message)`).Scan(&count)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if count != 0 {
+		t.Errorf("want translations and messages deleted, got %d rows", count)
+	}
+}
+
+func Test_NewDBOutdated(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	conf := &Conf{Path: filepath.Join(t.TempDir(), "translate.db")}
+
+	db, err := NewDB(ctx, conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	m, err := NewMigrator(ctx, conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer m.Close()
+
+	err = m.Down(ctx, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Pending migrations are not applied on open.
+	_, err = NewDB(ctx, conf)
+	if err == nil {
+		t.Error("want outdated schema error, got nil")
+		return
+	}
+
+	err = m.Up(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err = NewDB(ctx, conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_Migrator(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	m, err := NewMigrator(ctx, &Conf{Path: filepath.Join(t.TempDir(), "translate.db")})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer m.Close()
+
+	wantStatus := func(want migrate.Status) {
+		t.Helper()
+
+		got, err := m.Status(ctx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if got != want {
+			t.Errorf("want status %+v, got %+v", want, got)
+		}
+	}
+
+	const latest = 8
+
+	wantStatus(migrate.Status{Latest: latest})
+
+	steps := []struct {
+		migrate func() error
+		name    string
+		version int64
+	}{
+		{name: "up", migrate: func() error { return m.Up(ctx) }, version: latest},
+		{name: "up again", migrate: func() error { return m.Up(ctx) }, version: latest},
+		{name: "down", migrate: func() error { return m.Down(ctx, 1) }, version: latest - 1},
+		{name: "down all", migrate: func() error { return m.Down(ctx, latest) }, version: 0},
+		{name: "force", migrate: func() error { return m.Force(ctx, 1) }, version: 1},
+	}
+
+	for _, step := range steps {
+		err = step.migrate()
+		if err != nil {
+			t.Errorf("%s: %v", step.name, err)
+			return
+		}
+
+		wantStatus(migrate.Status{Version: step.version, Latest: latest})
+	}
+}
@@ -0,0 +1,422 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/repo/internal/sqlrepo"
+	"golang.org/x/text/language"
+)
+
+// upsertMessageQuery inserts or updates a message, an updated message keeps its rowid and so its order.
+const upsertMessageQuery = `INSERT INTO message
+	(translation_id, id, message, description, plural_id, positions, status, status_reason, quality_score)
+VALUES
+	(?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (translation_id, id) DO UPDATE SET
+	message = excluded.message,
+	description = excluded.description,
+	plural_id = excluded.plural_id,
+	positions = excluded.positions,
+	status = excluded.status,
+	status_reason = excluded.status_reason,
+	quality_score = excluded.quality_score`
+
+func (r *Repo) SaveTranslation(ctx context.Context, serviceID uuid.UUID, translation *model.Translation) error {
+	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
+		_, err := r.LoadService(ctx, serviceID)
+		if err != nil {
+			return fmt.Errorf("repo: load service: %w", err)
+		}
+
+		// Check if translation already exist
+		var (
+			translationID uuid.UUID
+			version       int
+			original      bool
+			previous      []model.Message // messages replaced by the translation
+		)
+
+		row := r.db.QueryRowContext(
+			ctx,
+			`SELECT id, version, original FROM translation WHERE service_id = ? AND language = ?`,
+			serviceID,
+			translation.Language.String(),
+		)
+
+		// Check if translation already exists, if not, create a new one
+		switch err = row.Scan(&translationID, &version, &original); {
+		// Translation already exists, the translation is replaced
+		default:
+			if translation.Version != 0 && translation.Version != version {
+				return repo.ErrConflict
+			}
+
+			previous, err = sqlrepo.SQLite.LoadMessages(ctx, r.db, translationID)
+			if err != nil {
+				return err
+			}
+
+			// The version is not bumped and nothing is written if the translation is unchanged.
+			if original == translation.Original && translation.EqualMessages(&model.Translation{Messages: previous}) {
+				translation.Version = version
+
+				return nil
+			}
+
+			version++
+
+			_, err = r.db.ExecContext(ctx, `UPDATE translation SET original = ?, version = ? WHERE id = ?`,
+				translation.Original, version, translationID)
+			if err != nil {
+				return fmt.Errorf("repo: update translation: %w", err)
+			}
+
+			_, err = r.db.ExecContext(ctx, `DELETE FROM message WHERE translation_id = ?`, translationID)
+			if err != nil {
+				return fmt.Errorf("repo: delete messages: %w", err)
+			}
+
+		// Translation does not exist
+		case errors.Is(err, sql.ErrNoRows):
+			translationID = uuid.New()
+			version = 1
+
+			_, err = r.db.ExecContext(
+				ctx,
+				`INSERT INTO translation (id, service_id, language, original, version) VALUES (?, ?, ?, ?, ?)`,
+				translationID,
+				serviceID,
+				translation.Language.String(),
+				translation.Original,
+				version,
+			)
+			if err != nil {
+				return fmt.Errorf("repo: insert translation: %w", err)
+			}
+
+		// Error scanning row
+		case err != nil:
+			return fmt.Errorf("repo: scan translation: %w", err)
+		}
+
+		// Insert into message table, the last of the messages with the same id wins.
+		stmt, err := r.db.PrepareContext(ctx, upsertMessageQuery)
+		if err != nil {
+			return fmt.Errorf("repo: prepare stmt to insert message: %w", err)
+		}
+		defer stmt.Close()
+
+		for _, m := range translation.Messages {
+			_, err = stmt.ExecContext(
+				ctx,
+				translationID,
+				m.ID,
+				m.Message,
+				m.Description,
+				m.PluralID,
+				&m.Positions,
+				&m.Status,
+				m.StatusReason,
+				m.QualityScore,
+			)
+			if err != nil {
+				return fmt.Errorf("repo: insert message: %w", err)
+			}
+		}
+
+		revisions := model.NewMessageRevisions(translation.Language, previous, translation.Messages,
+			repo.ActorFromContext(ctx), time.Now().UTC())
+
+		err = sqlrepo.SQLite.InsertMessageRevisions(ctx, r.db, translationID, revisions)
+		if err != nil {
+			return err
+		}
+
+		translation.Version = version
+
+		return nil
+	})
+}
+
+func (r *Repo) SaveMessage(ctx context.Context, serviceID uuid.UUID, lang language.Tag, message *model.Message,
+) error {
+	return r.ensureTx(ctx, func(ctx context.Context, r *Repo) error {
+		var translationID uuid.UUID
+
+		row := r.db.QueryRowContext(ctx, `SELECT id FROM translation WHERE service_id = ? AND language = ?`,
+			serviceID, lang.String())
+
+		switch err := row.Scan(&translationID); {
+		case errors.Is(err, sql.ErrNoRows):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("repo: scan translation: %w", err)
+		}
+
+		_, err := r.db.ExecContext(ctx, `UPDATE translation SET version = version + 1 WHERE id = ?`, translationID)
+		if err != nil {
+			return fmt.Errorf("repo: update translation version: %w", err)
+		}
+
+		var (
+			previous []model.Message
+			old      = model.Message{ID: message.ID}
+		)
+
+		row = r.db.QueryRowContext(ctx, `SELECT message, status FROM message WHERE translation_id = ? AND id = ?`,
+			translationID, message.ID)
+
+		switch err = row.Scan(&old.Message, &old.Status); {
+		default:
+			previous = append(previous, old)
+		case errors.Is(err, sql.ErrNoRows):
+			// New message.
+		case err != nil:
+			return fmt.Errorf("repo: scan message: %w", err)
+		}
+
+		_, err = r.db.ExecContext(ctx, upsertMessageQuery,
+			translationID,
+			message.ID,
+			message.Message,
+			message.Description,
+			message.PluralID,
+			&message.Positions,
+			&message.Status,
+			message.StatusReason,
+			message.QualityScore,
+		)
+		if err != nil {
+			return fmt.Errorf("repo: upsert message: %w", err)
+		}
+
+		revisions := model.NewMessageRevisions(lang, previous, []model.Message{*message},
+			repo.ActorFromContext(ctx), time.Now().UTC())
+
+		return sqlrepo.SQLite.InsertMessageRevisions(ctx, r.db, translationID, revisions) //nolint:wrapcheck
+	})
+}
+
+func (r *Repo) LoadMessage(ctx context.Context, serviceID uuid.UUID, lang language.Tag, messageID string,
+) (*model.Message, error) {
+	translations, err := r.LoadTranslations(ctx, serviceID, repo.LoadTranslationsOpts{
+		FilterLanguages:  []language.Tag{lang},
+		FilterMessageIDs: []string{messageID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(translations) == 0 || len(translations[0].Messages) == 0 {
+		return nil, repo.ErrNotFound
+	}
+
+	return &translations[0].Messages[0], nil
+}
+
+func (r *Repo) LoadTranslations(ctx context.Context, serviceID uuid.UUID, opts repo.LoadTranslationsOpts,
+) (model.Translations, error) {
+	// Translations are loaded separately from messages to include translations without messages.
+	translationsLookup, err := r.loadTranslations(ctx, serviceID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	query := sq.
+		Select("m.id, m.message, m.description, m.plural_id, m.positions, m.status, m.status_reason, "+
+			"m.quality_score, t.language").
+		From("message m").
+		Join("translation t ON t.id = m.translation_id").
+		Where("t.service_id = ?", serviceID).
+		Where(filterTranslations("t", opts)).
+		Where(filterMessages(opts))
+
+	if opts.Limit > 0 {
+		query = query.OrderBy("t.language", "m.id").Limit(uint64(opts.Limit)) //nolint:gosec
+	} else {
+		query = query.OrderBy("m.rowid") // keep the order in which messages were added
+	}
+
+	rows, err := query.RunWith(r.db).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query messages: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			msg          model.Message
+			pluralID     sql.NullString
+			statusReason sql.NullString
+			qualityScore sql.NullFloat64
+			lang         string
+		)
+
+		err = rows.Scan(&msg.ID, &msg.Message, &msg.Description, &pluralID, &msg.Positions, &msg.Status,
+			&statusReason, &qualityScore, &lang)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan message: %w", err)
+		}
+
+		msg.PluralID = pluralID.String
+		msg.StatusReason = statusReason.String
+
+		if qualityScore.Valid {
+			msg.QualityScore = &qualityScore.Float64
+		}
+
+		if translation, ok := translationsLookup[lang]; ok {
+			translation.Messages = append(translation.Messages, msg)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan messages: %w", err)
+	}
+
+	allTranslations := make([]model.Translation, 0, len(translationsLookup))
+	for _, translation := range translationsLookup {
+		allTranslations = append(allTranslations, *translation)
+	}
+
+	return allTranslations, nil
+}
+
+// loadTranslations loads the service translations without messages by language.
+func (r *Repo) loadTranslations(ctx context.Context, serviceID uuid.UUID, opts repo.LoadTranslationsOpts,
+) (map[string]*model.Translation, error) {
+	rows, err := sq.
+		Select("language, original, version").
+		From("translation").
+		Where("service_id = ?", serviceID).
+		Where(filterTranslations("translation", opts)).
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query translations: %w", err)
+	}
+
+	defer rows.Close()
+
+	translationsLookup := make(map[string]*model.Translation)
+
+	for rows.Next() {
+		var (
+			lang     string
+			original bool
+			version  int
+		)
+
+		err = rows.Scan(&lang, &original, &version)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan translation: %w", err)
+		}
+
+		translationsLookup[lang] = &model.Translation{
+			Language: language.MustParse(lang),
+			Original: original,
+			Version:  version,
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan translations: %w", err)
+	}
+
+	return translationsLookup, nil
+}
+
+// DeleteTranslation deletes the translation of the service language,
+// messages are deleted by the foreign key ON DELETE CASCADE.
+func (r *Repo) DeleteTranslation(ctx context.Context, serviceID uuid.UUID, language language.Tag) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM translation WHERE service_id = ? AND language = ?`,
+		serviceID, language.String())
+	if err != nil {
+		return fmt.Errorf("repo: delete translation: %w", err)
+	}
+
+	switch count, err := result.RowsAffected(); {
+	default:
+		return nil
+	case err != nil:
+		return fmt.Errorf("repo: delete translation result: %w", err)
+	case count == 0:
+		return repo.ErrNotFound
+	}
+}
+
+// helpers
+
+func langToStringSlice(languages []language.Tag) []string {
+	lt := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		lt = append(lt, lang.String())
+	}
+
+	return lt
+}
+
+// filterTranslations returns the conditions selecting translations of the table by opts.
+func filterTranslations(table string, opts repo.LoadTranslationsOpts) sq.And {
+	cond := sq.And{eq(table+".language", langToStringSlice(opts.FilterLanguages))}
+
+	if opts.FilterOriginal {
+		cond = append(cond, sq.Eq{table + ".original": true})
+	}
+
+	return cond
+}
+
+// filterMessages returns the conditions selecting messages of the translations joined as m and t by opts.
+func filterMessages(opts repo.LoadTranslationsOpts) sq.And {
+	cond := sq.And{
+		eq("m.id", opts.FilterMessageIDs),
+		eq("m.status", statusToStringSlice(opts.FilterStatuses)),
+	}
+
+	if opts.FilterMessageIDPrefix != "" {
+		cond = append(cond, sq.Expr("substr(m.id, 1, length(?)) = ?",
+			opts.FilterMessageIDPrefix, opts.FilterMessageIDPrefix))
+	}
+
+	if opts.FilterText != "" {
+		cond = append(cond, sq.Expr(`unicode_lower(m.message) LIKE ? ESCAPE '\'`,
+			"%"+escapeLike(strings.ToLower(opts.FilterText))+"%"))
+	}
+
+	if opts.FilterMaxQualityScore != nil {
+		cond = append(cond, sq.LtOrEq{"m.quality_score": *opts.FilterMaxQualityScore})
+	}
+
+	if opts.After != (repo.MessageKey{}) {
+		cond = append(cond, sq.Expr("(t.language, m.id) > (?, ?)", opts.After.Language.String(), opts.After.ID))
+	}
+
+	return cond
+}
+
+func statusToStringSlice(statuses []model.MessageStatus) []string {
+	s := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		s = append(s, status.String())
+	}
+
+	return s
+}
+
+// escapeLike escapes the LIKE wildcards in s with a backslash.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+// AddUsage adds the usage characters and requests to the counters of the service, translator and day.
+func (r *Repo) AddUsage(ctx context.Context, usage *model.Usage) error {
+	query := `INSERT INTO translator_usage
+	(service_id, date, translator, characters, requests)
+VALUES
+	(?, ?, ?, ?, ?)
+ON CONFLICT (service_id, date, translator) DO UPDATE SET
+	characters = characters + excluded.characters,
+	requests = requests + excluded.requests`
+
+	usage.Date = model.UsageDate(usage.Date)
+
+	_, err := r.db.ExecContext(ctx, query,
+		usage.ServiceID,
+		usage.Date.Format(time.DateOnly),
+		usage.Translator,
+		usage.Characters,
+		usage.Requests,
+	)
+	if err != nil {
+		return fmt.Errorf("repo: insert usage: %w", err)
+	}
+
+	return nil
+}
+
+// LoadUsage returns the usage of the service ordered by date and translator.
+func (r *Repo) LoadUsage(ctx context.Context, serviceID uuid.UUID, opts repo.LoadUsageOpts) ([]model.Usage, error) {
+	where := sq.And{sq.Eq{"service_id": serviceID}}
+
+	if !opts.FilterFrom.IsZero() {
+		where = append(where, sq.GtOrEq{"date": model.UsageDate(opts.FilterFrom).Format(time.DateOnly)})
+	}
+
+	// Dates are compared as text, include days up to the day of the last instant before FilterTo.
+	if !opts.FilterTo.IsZero() {
+		where = append(where, sq.LtOrEq{"date": model.UsageDate(opts.FilterTo.Add(-time.Nanosecond)).Format(time.DateOnly)})
+	}
+
+	rows, err := sq.
+		Select("service_id, date, translator, characters, requests").
+		From("translator_usage").
+		Where(where).
+		OrderBy("date", "translator").
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query usage: %w", err)
+	}
+
+	defer rows.Close()
+
+	var usage []model.Usage
+
+	for rows.Next() {
+		var u model.Usage
+
+		err = rows.Scan(&u.ServiceID, &u.Date, &u.Translator, &u.Characters, &u.Requests)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan usage: %w", err)
+		}
+
+		usage = append(usage, u)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+// LockUsage does nothing, the database has a single connection and transactions do not run concurrently.
+func (r *Repo) LockUsage(context.Context, uuid.UUID) error {
+	return nil
+}
@@ -0,0 +1,301 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+)
+
+// SaveWebhook handles both Create and Update.
+func (r *Repo) SaveWebhook(ctx context.Context, webhook *model.Webhook) error {
+	query := `INSERT INTO webhook
+	(id, service_id, url, secret, events, created_at)
+VALUES
+	(?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+	url = excluded.url,
+	secret = excluded.secret,
+	events = excluded.events`
+
+	if webhook.ID == uuid.Nil {
+		webhook.ID = uuid.New()
+	}
+
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now().UTC()
+	}
+
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return fmt.Errorf("repo: marshal webhook events: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		webhook.ID,
+		webhook.ServiceID,
+		webhook.URL,
+		webhook.Secret,
+		events,
+		webhook.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("repo: insert webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) LoadWebhook(ctx context.Context, serviceID, webhookID uuid.UUID) (*model.Webhook, error) {
+	webhooks, err := r.loadWebhooks(ctx, sq.Eq{"service_id": serviceID, "id": webhookID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) == 0 {
+		return nil, repo.ErrNotFound
+	}
+
+	return &webhooks[0], nil
+}
+
+func (r *Repo) LoadWebhooks(ctx context.Context, serviceID uuid.UUID) ([]model.Webhook, error) {
+	return r.loadWebhooks(ctx, sq.Eq{"service_id": serviceID})
+}
+
+// loadWebhooks returns webhooks matching the where clause, oldest first.
+func (r *Repo) loadWebhooks(ctx context.Context, where sq.Sqlizer) ([]model.Webhook, error) {
+	rows, err := sq.
+		Select("id, service_id, url, secret, events, created_at").
+		From("webhook").
+		Where(where).
+		OrderBy("created_at").
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query webhooks: %w", err)
+	}
+
+	defer rows.Close()
+
+	var webhooks []model.Webhook
+
+	for rows.Next() {
+		var (
+			webhook model.Webhook
+			events  []byte
+		)
+
+		err = rows.Scan(&webhook.ID, &webhook.ServiceID, &webhook.URL, &webhook.Secret, &events, &webhook.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan webhook: %w", err)
+		}
+
+		err = json.Unmarshal(events, &webhook.Events)
+		if err != nil {
+			return nil, fmt.Errorf("repo: unmarshal webhook events: %w", err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes the webhook, its deliveries are deleted by the foreign key.
+func (r *Repo) DeleteWebhook(ctx context.Context, serviceID, webhookID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook WHERE service_id = ? AND id = ?`, serviceID, webhookID)
+	if err != nil {
+		return fmt.Errorf("repo: delete webhook: %w", err)
+	}
+
+	switch count, err := result.RowsAffected(); {
+	default:
+		return nil
+	case err != nil:
+		return fmt.Errorf("repo: delete webhook result: %w", err)
+	case count == 0:
+		return repo.ErrNotFound
+	}
+}
+
+// SaveWebhookDelivery handles both Create and Update.
+func (r *Repo) SaveWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	query := `INSERT INTO webhook_delivery
+	(id, webhook_id, service_id, event, payload, status, attempts, response_status, error,
+	next_attempt_at, created_at, updated_at)
+VALUES
+	(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+	status = excluded.status,
+	attempts = excluded.attempts,
+	response_status = excluded.response_status,
+	error = excluded.error,
+	next_attempt_at = excluded.next_attempt_at,
+	updated_at = excluded.updated_at`
+
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.New()
+	}
+
+	now := time.Now().UTC()
+
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = now
+	}
+
+	if delivery.NextAttemptAt.IsZero() {
+		delivery.NextAttemptAt = now
+	}
+
+	delivery.NextAttemptAt = delivery.NextAttemptAt.UTC()
+	delivery.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.ServiceID,
+		&delivery.Event,
+		delivery.Payload,
+		&delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.Error,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("repo: insert webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) LoadWebhookDeliveries(ctx context.Context, opts repo.LoadWebhookDeliveriesOpts,
+) ([]model.WebhookDelivery, error) {
+	where := sq.And{}
+
+	if opts.FilterServiceID != uuid.Nil {
+		where = append(where, sq.Eq{"service_id": opts.FilterServiceID})
+	}
+
+	if opts.FilterWebhookID != uuid.Nil {
+		where = append(where, sq.Eq{"webhook_id": opts.FilterWebhookID})
+	}
+
+	if len(opts.FilterStatuses) > 0 {
+		statuses := make([]string, 0, len(opts.FilterStatuses))
+		for _, s := range opts.FilterStatuses {
+			statuses = append(statuses, s.String())
+		}
+
+		where = append(where, eq("status", statuses))
+	}
+
+	rows, err := sq.
+		Select(`id, webhook_id, service_id, event, payload, status, attempts, response_status, error,
+			next_attempt_at, created_at, updated_at`).
+		From("webhook_delivery").
+		Where(where).
+		OrderBy("created_at").
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: query webhook deliveries: %w", err)
+	}
+
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+
+	for rows.Next() {
+		var (
+			delivery    model.WebhookDelivery
+			deliveryErr sql.NullString
+		)
+
+		err = rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.ServiceID, &delivery.Event, &delivery.Payload,
+			&delivery.Status, &delivery.Attempts, &delivery.ResponseStatus, &deliveryErr,
+			&delivery.NextAttemptAt, &delivery.CreatedAt, &delivery.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repo: scan webhook delivery: %w", err)
+		}
+
+		delivery.Error = deliveryErr.String
+		deliveries = append(deliveries, delivery)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("repo: scan webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ClaimWebhookDelivery moves the next attempt of the delivery if it is pending and due, in one statement.
+func (r *Repo) ClaimWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery, until time.Time) error {
+	query := `UPDATE webhook_delivery SET next_attempt_at = ?, updated_at = ?
+WHERE id = ? AND status = ? AND next_attempt_at <= ?`
+
+	now := time.Now().UTC()
+
+	until = until.UTC()
+
+	pending := model.WebhookDeliveryStatusPending
+
+	result, err := r.db.ExecContext(ctx, query, until, now, delivery.ID, &pending, now)
+	if err != nil {
+		return fmt.Errorf("repo: claim webhook delivery: %w", err)
+	}
+
+	switch count, err := result.RowsAffected(); {
+	case err != nil:
+		return fmt.Errorf("repo: claim webhook delivery result: %w", err)
+	case count == 0:
+		// Tell a missing delivery from a delivery claimed by another server.
+		var id []byte
+
+		err = r.db.QueryRowContext(ctx, `SELECT id FROM webhook_delivery WHERE id = ?`, delivery.ID).Scan(&id)
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return repo.ErrNotFound
+		case err != nil:
+			return fmt.Errorf("repo: query webhook delivery: %w", err)
+		}
+
+		return repo.ErrConflict
+	}
+
+	delivery.NextAttemptAt = until
+	delivery.UpdatedAt = now
+
+	return nil
+}
+
+// DeleteWebhookDeliveries deletes the deliveries of all services created before the time, except pending ones.
+func (r *Repo) DeleteWebhookDeliveries(ctx context.Context, before time.Time) error {
+	succeeded, failed := model.WebhookDeliveryStatusSucceeded, model.WebhookDeliveryStatusFailed
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM webhook_delivery WHERE status IN (?, ?) AND created_at < ?`,
+		&succeeded, &failed, before.UTC())
+	if err != nil {
+		return fmt.Errorf("repo: delete webhook deliveries: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"bufio"
+	"slices"
+
+	"go.expect.digital/translate/pkg/backup"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminServiceServer serves the admin RPCs. It is registered on the gRPC server only if enabled,
+// and not on the REST gateway, as the backup archive includes webhook secrets.
+type AdminServiceServer struct {
+	translatev1.UnimplementedAdminServiceServer
+
+	repo repo.Repo
+}
+
+func NewAdminServiceServer(r repo.Repo) *AdminServiceServer {
+	return &AdminServiceServer{repo: r}
+}
+
+// ----------------------Backup-------------------------------
+
+// backupChunkSize is the max size of the archive chunks sent by Backup, well below the default
+// max gRPC message size of 4MB.
+const backupChunkSize = 1 << 20
+
+// backupStream writes the archive to the Backup stream in chunks of at most backupChunkSize.
+type backupStream struct {
+	stream translatev1.AdminService_BackupServer
+}
+
+func (s backupStream) Write(p []byte) (int, error) {
+	var n int
+
+	for chunk := range slices.Chunk(p, backupChunkSize) {
+		// Send marshals the message before returning, the chunk can be reused by the caller.
+		err := s.stream.Send(&translatev1.BackupResponse{Data: chunk})
+		if err != nil {
+			return n, err //nolint:wrapcheck
+		}
+
+		n += len(chunk)
+	}
+
+	return n, nil
+}
+
+func (a *AdminServiceServer) Backup(
+	_ *translatev1.BackupRequest,
+	stream translatev1.AdminService_BackupServer,
+) error {
+	w := bufio.NewWriterSize(backupStream{stream: stream}, backupChunkSize)
+
+	_, err := backup.Write(stream.Context(), w, a.repo)
+	if err != nil {
+		return status.Error(codes.Internal, "")
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return status.Error(codes.Internal, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/backup"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+)
+
+type mockBackupStream struct {
+	grpc.ServerStream
+
+	ctx context.Context //nolint:containedctx
+	buf bytes.Buffer
+}
+
+func (m *mockBackupStream) Context() context.Context { return m.ctx }
+
+func (m *mockBackupStream) Send(resp *translatev1.BackupResponse) error {
+	if len(resp.GetData()) > backupChunkSize {
+		return fmt.Errorf("chunk of %d bytes exceeds %d bytes", len(resp.GetData()), backupChunkSize)
+	}
+
+	m.buf.Write(resp.GetData())
+
+	return nil
+}
+
+func Test_Backup(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	r := newInMemoryRepo(t)
+	adminSrv := NewAdminServiceServer(r)
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	translation := rand.ModelTranslation(3, nil, rand.WithLanguage(language.English))
+
+	err = r.SaveTranslation(ctx, service.ID, translation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	webhook := &model.Webhook{
+		ServiceID: service.ID,
+		URL:       "https://example.com/hook",
+		Events:    []model.WebhookEvent{model.WebhookEventJobFinished},
+	}
+
+	err = r.SaveWebhook(ctx, webhook)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	delivery := &model.WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: webhook.ID,
+		ServiceID: service.ID,
+		Event:     model.WebhookEventJobFinished,
+		Status:    model.WebhookDeliveryStatusSucceeded,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	err = r.SaveWebhookDelivery(ctx, delivery)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	revisions, err := r.LoadTranslationRevisions(ctx, service.ID, translation.Language)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	stream := &mockBackupStream{ctx: ctx}
+
+	err = adminSrv.Backup(&translatev1.BackupRequest{}, stream)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Restore to an empty repo.
+	restored := newInMemoryRepo(t)
+
+	stats, err := backup.Restore(ctx, bytes.NewReader(stream.buf.Bytes()), restored)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wantStats := backup.Stats{Services: 1, Translations: 1, Messages: 3, Revisions: 3, Webhooks: 1, WebhookDeliveries: 1}
+	if stats != wantStats {
+		t.Errorf("want stats %+v, got %+v", wantStats, stats)
+	}
+
+	gotService, err := restored.LoadService(ctx, service.ID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(service, gotService) {
+		t.Errorf("want service %v, got %v", service, gotService)
+	}
+
+	gotTranslations, err := restored.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if want := (model.Translations{*translation}); !reflect.DeepEqual(want, gotTranslations) {
+		t.Errorf("want translations %v, got %v", want, gotTranslations)
+	}
+
+	// Revisions are restored as recorded, not as added by the restore.
+	gotRevisions, err := restored.LoadTranslationRevisions(ctx, service.ID, translation.Language)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(revisions, gotRevisions) {
+		t.Errorf("want revisions %v, got %v", revisions, gotRevisions)
+	}
+
+	gotDeliveries, err := restored.LoadWebhookDeliveries(ctx, repo.LoadWebhookDeliveriesOpts{FilterWebhookID: webhook.ID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// UpdatedAt is set by the repo on save.
+	for i := range gotDeliveries {
+		gotDeliveries[i].UpdatedAt = delivery.UpdatedAt
+	}
+
+	if want := []model.WebhookDelivery{*delivery}; !reflect.DeepEqual(want, gotDeliveries) {
+		t.Errorf("want webhook deliveries %v, got %v", want, gotDeliveries)
+	}
+
+	// Restore to a non-empty repo is refused.
+	_, err = backup.Restore(ctx, bytes.NewReader(stream.buf.Bytes()), restored)
+	if err == nil {
+		t.Error("want error restoring to a non-empty repo")
+	}
+
+	// A failed restore deletes the restored services.
+	truncated := newInMemoryRepo(t)
+	archive := stream.buf.Bytes()
+
+	_, err = backup.Restore(ctx, bytes.NewReader(archive[:len(archive)-10]), truncated)
+	if err == nil {
+		t.Error("want error restoring a truncated archive")
+	}
+
+	services, err := truncated.LoadServices(ctx, repo.LoadServicesOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(services) != 0 {
+		t.Errorf("want no services after a failed restore, got %d", len(services))
+	}
+}
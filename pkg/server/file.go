@@ -4,15 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
 
 	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/fuzzy"
 	"go.expect.digital/translate/pkg/model"
 	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
 	"go.expect.digital/translate/pkg/repo"
 	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // ----------------------UploadTranslationFile-------------------------------
@@ -91,10 +93,33 @@ func getLanguage(reqParams *uploadParams, translation *model.Translation) (langu
 	return translation.Language, nil
 }
 
+// detectLanguage detects the language of the translation when neither the upload parameters nor the translation
+// set the language. Returns nil if no language detector is configured or the language is already set.
+func (t *TranslateServiceServer) detectLanguage(
+	ctx context.Context,
+	reqParams *uploadParams,
+	translation *model.Translation,
+) (*fuzzy.LanguageDetection, error) {
+	if t.languageDetector == nil || reqParams.languageTag != language.Und || translation.Language != language.Und {
+		return nil, nil //nolint:nilnil
+	}
+
+	detection, err := t.languageDetector.DetectLanguage(ctx, translation)
+	if err != nil {
+		return nil, fmt.Errorf("detect language: %w", err)
+	}
+
+	if detection.Language == language.Und {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &detection, nil
+}
+
 func (t *TranslateServiceServer) UploadTranslationFile(
 	ctx context.Context,
 	req *translatev1.UploadTranslationFileRequest,
-) (*emptypb.Empty, error) {
+) (*translatev1.UploadTranslationFileResponse, error) {
 	params, err := parseUploadTranslationFileRequestParams(req)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -110,6 +135,17 @@ func (t *TranslateServiceServer) UploadTranslationFile(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	detection, err := t.detectLanguage(ctx, params, translation)
+	if err != nil {
+		log.Printf("detect language of upload to service '%s': %v", params.serviceID, err)
+
+		return nil, status.Error(codes.InvalidArgument, "language could not be detected, set 'language'")
+	}
+
+	if detection != nil {
+		translation.Language = detection.Language
+	}
+
 	translation.Language, err = getLanguage(params, translation)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -144,18 +180,17 @@ func (t *TranslateServiceServer) UploadTranslationFile(
 		all.MarkUntranslated(oldOriginal.FindChangedMessageIDs(translation))
 		// Replace original translation with new one.
 		all.Replace(*translation)
+		// Delete or mark obsolete messages removed from the original translation.
+		t.removeMessages(all, &oldOriginal)
 		// Add missing messages for all translations.
 		if params.populateTranslations {
 			all.PopulateTranslations()
 		}
-
-		err = t.fuzzyTranslate(ctx, all)
-		if err != nil {
-			return nil, status.Error(codes.Internal, "")
-		}
 	}
 
-	// Update affected translations
+	var job *model.Job
+
+	// Update affected translations, untranslated messages are fuzzy translated asynchronously by a job.
 	err = t.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
 		for i := range all {
 			err = r.SaveTranslation(ctx, params.serviceID, &all[i])
@@ -164,14 +199,33 @@ func (t *TranslateServiceServer) UploadTranslationFile(
 			}
 		}
 
+		if translation.Original {
+			job, err = enqueueJob(ctx, r, params.serviceID, all)
+			if err != nil {
+				return fmt.Errorf("enqueue job: %w", err)
+			}
+		}
+
 		return nil
 	})
 
 	switch {
 	default:
-		return &emptypb.Empty{}, nil
+		if job != nil {
+			t.notifyJobs()
+		}
+
+		resp := &translatev1.UploadTranslationFileResponse{Language: translation.Language.String()}
+
+		if detection != nil {
+			resp.LanguageConfidence = new(float32(detection.Confidence))
+		}
+
+		return resp, nil
 	case errors.Is(err, repo.ErrNotFound):
 		return nil, status.Error(codes.NotFound, "service not found")
+	case errors.Is(err, repo.ErrConflict):
+		return nil, status.Error(codes.Aborted, "translations were changed concurrently")
 	case err != nil:
 		return nil, status.Error(codes.Internal, "")
 	}
@@ -180,16 +234,17 @@ func (t *TranslateServiceServer) UploadTranslationFile(
 // ----------------------DownloadTranslationFile-------------------------------
 
 type downloadParams struct {
-	languageTag language.Tag
-	schema      translatev1.Schema
-	serviceID   uuid.UUID
+	languageTag     language.Tag
+	schema          translatev1.Schema
+	serviceID       uuid.UUID
+	includeObsolete bool
 }
 
 func parseDownloadTranslationFileRequestParams(
 	req *translatev1.DownloadTranslationFileRequest,
 ) (*downloadParams, error) {
 	var (
-		params = &downloadParams{schema: req.GetSchema()}
+		params = &downloadParams{schema: req.GetSchema(), includeObsolete: req.GetIncludeObsolete()}
 		err    error
 	)
 
@@ -247,6 +302,12 @@ func (t *TranslateServiceServer) DownloadTranslationFile(
 		translations = append(translations, model.Translation{Language: params.languageTag})
 	}
 
+	if !params.includeObsolete {
+		translations[0].Messages = slices.DeleteFunc(translations[0].Messages, func(m model.Message) bool {
+			return m.Status == model.MessageStatusObsolete
+		})
+	}
+
 	data, err := TranslationToData(params.schema, &translations[0])
 	if err != nil {
 		return nil, status.Error(codes.Internal, "")
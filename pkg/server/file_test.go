@@ -1,14 +1,22 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/fuzzy"
 	"go.expect.digital/translate/pkg/model"
 	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
 	"go.expect.digital/translate/pkg/testutil/rand"
 	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // -------------------Upload-----------------------
@@ -250,6 +258,211 @@ func Test_GetLanguage(t *testing.T) {
 	}
 }
 
+func Test_UploadTranslationFileDetectLanguage(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{}, WithLanguageDetector(fuzzy.NewNgramDetect()))
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// ngx-translate JSON does not contain the language.
+	data := []byte(`{"welcome":"Willkommen zurück!","saved":"Ihre Einstellungen wurden erfolgreich gespeichert."}`)
+
+	resp, err := translateSrv.UploadTranslationFile(ctx, &translatev1.UploadTranslationFileRequest{
+		ServiceId: service.ID.String(),
+		Data:      data,
+		Schema:    translatev1.Schema_JSON_NGX_TRANSLATE,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if resp.GetLanguage() != language.German.String() {
+		t.Errorf("want detected language '%s', got '%s'", language.German, resp.GetLanguage())
+	}
+
+	if resp.LanguageConfidence == nil || resp.GetLanguageConfidence() <= 0 {
+		t.Errorf("want language confidence, got %v", resp.LanguageConfidence)
+	}
+
+	// Language set by the request is not detected.
+	resp, err = translateSrv.UploadTranslationFile(ctx, &translatev1.UploadTranslationFileRequest{
+		ServiceId: service.ID.String(),
+		Language:  language.French.String(),
+		Data:      data,
+		Schema:    translatev1.Schema_JSON_NGX_TRANSLATE,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if resp.GetLanguage() != language.French.String() || resp.LanguageConfidence != nil {
+		t.Errorf("want language '%s' without confidence, got %v", language.French, resp)
+	}
+}
+
+func Test_UploadTranslationFileDetectLanguageError(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{}, WithLanguageDetector(&mockFailingDetector{}))
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	req := &translatev1.UploadTranslationFileRequest{
+		ServiceId: service.ID.String(),
+		Data:      []byte(`{"welcome":"Willkommen zurück!"}`),
+		Schema:    translatev1.Schema_JSON_NGX_TRANSLATE,
+	}
+
+	// An upload without a language fails even if the service has the original translation.
+	err = r.SaveTranslation(ctx, service.ID, randOriginalTranslation(1))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = translateSrv.UploadTranslationFile(ctx, req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want status '%s', got '%s'", codes.InvalidArgument, status.Code(err))
+	}
+
+	// Translations of the upload are not saved.
+	translations, err := r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(translations) != 1 {
+		t.Errorf("want 1 translation, got %d", len(translations))
+	}
+}
+
+// mockFailingDetector is a language detector that always fails.
+type mockFailingDetector struct{}
+
+func (m *mockFailingDetector) DetectLanguage(context.Context, *model.Translation) (fuzzy.LanguageDetection, error) {
+	return fuzzy.LanguageDetection{}, errors.New("detector unavailable")
+}
+
+func Test_UploadTranslationFileRemovedMessages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		wantMessages []model.Message
+		markObsolete bool
+	}{
+		{
+			name:         "delete removed messages",
+			wantMessages: []model.Message{{ID: "a", Message: "A", Status: model.MessageStatusTranslated}},
+		},
+		{
+			name:         "mark removed messages obsolete",
+			markObsolete: true,
+			wantMessages: []model.Message{
+				{ID: "a", Message: "A", Status: model.MessageStatusTranslated},
+				{ID: "b", Message: "B", Status: model.MessageStatusObsolete},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := newInMemoryRepo(t)
+			translateSrv := NewTranslateServiceServer(r, &mockTranslator{}, WithMarkObsolete(test.markObsolete))
+			ctx := t.Context()
+
+			service := rand.ModelService()
+
+			err := r.SaveService(ctx, service)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			messages := []model.Message{
+				{ID: "a", Message: "A", Status: model.MessageStatusTranslated},
+				{ID: "b", Message: "B", Status: model.MessageStatusTranslated},
+			}
+
+			for _, translation := range []model.Translation{
+				{Language: language.English, Original: true, Messages: messages},
+				{Language: language.German, Messages: messages},
+			} {
+				err = r.SaveTranslation(ctx, service.ID, &translation)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+
+			// Message "b" is removed from the original translation.
+			_, err = translateSrv.UploadTranslationFile(ctx, &translatev1.UploadTranslationFileRequest{
+				ServiceId: service.ID.String(),
+				Language:  language.English.String(),
+				Data:      []byte(`{"a":"A"}`),
+				Schema:    translatev1.Schema_JSON_NGX_TRANSLATE,
+				Original:  new(true),
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			all, err := r.LoadTranslations(ctx, service.ID,
+				repo.LoadTranslationsOpts{FilterLanguages: []language.Tag{language.German}})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if len(all) != 1 || !reflect.DeepEqual(test.wantMessages, all[0].Messages) {
+				t.Errorf("want messages %v, got %v", test.wantMessages, all)
+			}
+
+			// Obsolete messages are downloaded only if requested.
+			for _, includeObsolete := range []bool{false, true} {
+				resp, err := translateSrv.DownloadTranslationFile(ctx, &translatev1.DownloadTranslationFileRequest{
+					ServiceId:       service.ID.String(),
+					Language:        language.German.String(),
+					Schema:          translatev1.Schema_JSON_NGX_TRANSLATE,
+					IncludeObsolete: includeObsolete,
+				})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				wantB := test.markObsolete && includeObsolete
+				if gotB := strings.Contains(string(resp.GetData()), `"b"`); gotB != wantB {
+					t.Errorf("include obsolete %t: want message 'b' %t, got %t", includeObsolete, wantB, gotB)
+				}
+			}
+		})
+	}
+}
+
 // -------------------Download-----------------------
 
 func Test_ParseDownloadParams(t *testing.T) {
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/google/uuid"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ----------------------GetJob-------------------------------
+
+type getJobParams struct {
+	id uuid.UUID
+}
+
+func parseGetJobRequestParams(req *translatev1.GetJobRequest) (*getJobParams, error) {
+	// Accept both the job ID and the operation name e.g. "jobs/{id}".
+	id, err := uuidFromProto(strings.TrimPrefix(req.GetId(), jobOperationPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("parse id: %w", err)
+	}
+
+	return &getJobParams{id: id}, nil
+}
+
+func (g *getJobParams) validate() error {
+	if g.id == uuid.Nil {
+		return errors.New("'id' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) GetJob(
+	ctx context.Context,
+	req *translatev1.GetJobRequest,
+) (*longrunningpb.Operation, error) {
+	params, err := parseGetJobRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	job, err := t.repo.LoadJob(ctx, params.id)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "job not found")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	op, err := jobToProto(job)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return op, nil
+}
+
+// ----------------------ListJobs-------------------------------
+
+// defaultJobsPageSize is the number of jobs returned by ListJobs without a page size.
+const defaultJobsPageSize = 100
+
+type listJobsParams struct {
+	after     repo.JobKey
+	serviceID uuid.UUID
+	pageSize  int
+}
+
+func parseListJobsRequestParams(req *translatev1.ListJobsRequest) (*listJobsParams, error) {
+	serviceID, err := uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	after, err := jobPageTokenFromProto(req.GetPageToken())
+	if err != nil {
+		return nil, fmt.Errorf("parse page_token: %w", err)
+	}
+
+	return &listJobsParams{serviceID: serviceID, pageSize: int(req.GetPageSize()), after: after}, nil
+}
+
+func (l *listJobsParams) validate() error {
+	if l.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if l.pageSize < 0 {
+		return errors.New("'page_size' must not be negative")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) ListJobs(
+	ctx context.Context,
+	req *translatev1.ListJobsRequest,
+) (*translatev1.ListJobsResponse, error) {
+	params, err := parseListJobsRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pageSize := min(params.pageSize, maxPageSize)
+	if pageSize == 0 {
+		pageSize = defaultJobsPageSize
+	}
+
+	// Load a job more than the page size to know if there is a next page.
+	jobs, err := t.repo.LoadJobs(ctx, repo.LoadJobsOpts{
+		FilterServiceID: params.serviceID,
+		After:           params.after,
+		Limit:           pageSize + 1,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	var nextPageToken string
+
+	if len(jobs) > pageSize {
+		jobs = jobs[:pageSize]
+		last := jobs[len(jobs)-1]
+		nextPageToken = jobPageTokenToProto(repo.JobKey{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	ops, err := jobsToProto(jobs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &translatev1.ListJobsResponse{Operations: ops, NextPageToken: nextPageToken}, nil
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ----------------------GetMessage-------------------------------
+
+type getMessageParams struct {
+	language  language.Tag
+	messageID string
+	serviceID uuid.UUID
+}
+
+func parseGetMessageRequestParams(req *translatev1.GetMessageRequest) (*getMessageParams, error) {
+	var (
+		params = getMessageParams{messageID: req.GetMessageId()}
+		err    error
+	)
+
+	params.serviceID, err = uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	params.language, err = languageFromProto(req.GetLanguage())
+	if err != nil {
+		return nil, fmt.Errorf("parse language: %w", err)
+	}
+
+	return &params, nil
+}
+
+func (g *getMessageParams) validate() error {
+	if g.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if g.language == language.Und {
+		return errors.New("'language' is required")
+	}
+
+	if g.messageID == "" {
+		return errors.New("'message_id' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) GetMessage(
+	ctx context.Context,
+	req *translatev1.GetMessageRequest,
+) (*translatev1.Message, error) {
+	params, err := parseGetMessageRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	message, err := t.repo.LoadMessage(ctx, params.serviceID, params.language, params.messageID)
+
+	switch {
+	default:
+		return messageToProto(message), nil
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound,
+			"no message '%s' for language: '%s'", params.messageID, params.language)
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+}
+
+// ----------------------UpdateMessage-------------------------------
+
+type updateMessageParams struct {
+	message   *model.Message
+	language  language.Tag
+	serviceID uuid.UUID
+}
+
+func parseUpdateMessageRequestParams(req *translatev1.UpdateMessageRequest) (*updateMessageParams, error) {
+	var (
+		params = updateMessageParams{}
+		err    error
+	)
+
+	params.serviceID, err = uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	params.language, err = languageFromProto(req.GetLanguage())
+	if err != nil {
+		return nil, fmt.Errorf("parse language: %w", err)
+	}
+
+	params.message, err = messageFromProto(req.GetMessage())
+	if err != nil {
+		return nil, fmt.Errorf("parse message: %w", err)
+	}
+
+	return &params, nil
+}
+
+func (u *updateMessageParams) validate() error {
+	if u.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if u.language == language.Und {
+		return errors.New("'language' is required")
+	}
+
+	if u.message == nil {
+		return errors.New("'message' is nil")
+	}
+
+	if u.message.ID == "" {
+		return errors.New("'message.id' is required")
+	}
+
+	return nil
+}
+
+// UpdateMessage saves a single message without loading or saving the other messages of the translation.
+// A message of the original translation might affect the other translations,
+// it is saved as UpdateTranslation of the original translation with only the message.
+func (t *TranslateServiceServer) UpdateMessage(
+	ctx context.Context,
+	req *translatev1.UpdateMessageRequest,
+) (*translatev1.Message, error) {
+	params, err := parseUpdateMessageRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Only the message is loaded to find out whether the translation is original.
+	translations, err := t.repo.LoadTranslations(ctx, params.serviceID, repo.LoadTranslationsOpts{
+		FilterLanguages:  []language.Tag{params.language},
+		FilterMessageIDs: []string{params.message.ID},
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	if len(translations) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no translation for language: '%s'", params.language)
+	}
+
+	if translations[0].Original {
+		translation, err := t.updateTranslation(ctx, &updateTranslationParams{
+			serviceID: params.serviceID,
+			translation: &model.Translation{
+				Language: params.language,
+				Original: true,
+				Messages: []model.Message{*params.message},
+			},
+			mask: model.Mask{"Messages"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		idx := slices.IndexFunc(translation.Messages, func(m model.Message) bool { return m.ID == params.message.ID })
+
+		return messageToProto(&translation.Messages[idx]), nil
+	}
+
+	err = t.repo.SaveMessage(ctx, params.serviceID, params.language, params.message)
+
+	switch {
+	default:
+		return messageToProto(params.message), nil
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "no translation for language: '%s'", params.language)
+	case errors.Is(err, repo.ErrConflict):
+		return nil, status.Error(codes.Aborted, "translations were changed concurrently")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+}
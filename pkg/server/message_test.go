@@ -0,0 +1,168 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_UpdateMessage(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	original := &model.Translation{
+		Language: language.English,
+		Original: true,
+		Messages: []model.Message{
+			{ID: "greeting", Message: "Hello", Status: model.MessageStatusTranslated},
+			{ID: "farewell", Message: "Bye", Status: model.MessageStatusTranslated},
+		},
+	}
+
+	translation := &model.Translation{
+		Language: language.Latvian,
+		Messages: []model.Message{
+			{ID: "greeting", Message: "Sveiki", Status: model.MessageStatusFuzzy},
+			{ID: "farewell", Message: "Atā", Status: model.MessageStatusTranslated},
+		},
+	}
+
+	for _, translation := range []*model.Translation{original, translation} {
+		err = r.SaveTranslation(ctx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	updateMessage := func(lang language.Tag, message *translatev1.Message) (*translatev1.Message, error) {
+		return translateSrv.UpdateMessage(ctx, &translatev1.UpdateMessageRequest{
+			ServiceId: service.ID.String(),
+			Language:  lang.String(),
+			Message:   message,
+		})
+	}
+
+	loadIDs := func(lang language.Tag) []string {
+		translations, err := r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{
+			FilterLanguages: []language.Tag{lang},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := make([]string, 0, len(translations[0].Messages))
+		for _, msg := range translations[0].Messages {
+			ids = append(ids, msg.ID)
+		}
+
+		return ids
+	}
+
+	// Update a message of a translation, the message keeps its place.
+	_, err = updateMessage(language.Latvian, &translatev1.Message{
+		Id: "greeting", Message: "Labdien", Status: translatev1.Message_TRANSLATED,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	msg, err := translateSrv.GetMessage(ctx, &translatev1.GetMessageRequest{
+		ServiceId: service.ID.String(),
+		Language:  language.Latvian.String(),
+		MessageId: "greeting",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if msg.GetMessage() != "Labdien" || msg.GetStatus() != translatev1.Message_TRANSLATED {
+		t.Errorf("want translated message 'Labdien', got %v", msg)
+	}
+
+	// Add a message to a translation, the message is added last.
+	_, err = updateMessage(language.Latvian, &translatev1.Message{Id: "thanks", Message: "Paldies"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := loadIDs(language.Latvian), []string{"greeting", "farewell", "thanks"}; !slices.Equal(got, want) {
+		t.Errorf("want messages %v, got %v", want, got)
+	}
+
+	// Change a message of the original translation, the translated message is marked untranslated.
+	msg, err = updateMessage(language.English, &translatev1.Message{Id: "farewell", Message: "Goodbye"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if msg.GetMessage() != "Goodbye" {
+		t.Errorf("want original message 'Goodbye', got '%s'", msg.GetMessage())
+	}
+
+	lv, err := r.LoadMessage(ctx, service.ID, language.Latvian, "farewell")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if lv.Status != model.MessageStatusUntranslated {
+		t.Errorf("want translation of the changed message untranslated, got %s", lv.Status.String())
+	}
+
+	if got, want := loadIDs(language.English), []string{"greeting", "farewell"}; !slices.Equal(got, want) {
+		t.Errorf("want original messages %v, got %v", want, got)
+	}
+
+	jobs, err := r.LoadJobs(ctx, repo.LoadJobsOpts{FilterServiceID: service.ID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(jobs) != 1 {
+		t.Errorf("want a job to fuzzy translate the changed message, got %d jobs", len(jobs))
+	}
+
+	// Missing translation and message.
+	_, err = updateMessage(language.German, &translatev1.Message{Id: "greeting", Message: "Hallo"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("want NotFound for a missing translation, got %v", err)
+	}
+
+	_, err = translateSrv.GetMessage(ctx, &translatev1.GetMessageRequest{
+		ServiceId: service.ID.String(),
+		Language:  language.Latvian.String(),
+		MessageId: "missing",
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("want NotFound for a missing message, got %v", err)
+	}
+
+	_, err = updateMessage(language.Latvian, &translatev1.Message{Message: "No ID"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want InvalidArgument for a message without ID, got %v", err)
+	}
+}
@@ -0,0 +1,305 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ------------------------GetProject-------------------------------
+
+type getProjectParams struct {
+	id uuid.UUID
+}
+
+func parseGetProjectRequestParams(req *translatev1.GetProjectRequest) (*getProjectParams, error) {
+	id, err := uuidFromProto(req.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("parse id: %w", err)
+	}
+
+	return &getProjectParams{id: id}, nil
+}
+
+func (g *getProjectParams) validate() error {
+	if g.id == uuid.Nil {
+		return errors.New("'id' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) GetProject(
+	ctx context.Context,
+	req *translatev1.GetProjectRequest,
+) (*translatev1.Project, error) {
+	params, err := parseGetProjectRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	switch project, err := t.repo.LoadProject(ctx, params.id); {
+	default:
+		return projectToProto(project), nil
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "project not found")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+}
+
+// ----------------------ListProjects-------------------------------
+
+func (t *TranslateServiceServer) ListProjects(
+	ctx context.Context,
+	_ *translatev1.ListProjectsRequest,
+) (*translatev1.ListProjectsResponse, error) {
+	projects, err := t.repo.LoadProjects(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &translatev1.ListProjectsResponse{Projects: projectsToProto(projects)}, nil
+}
+
+// ---------------------CreateProject-------------------------------
+
+type createProjectParams struct {
+	project *model.Project
+}
+
+func parseCreateProjectParams(req *translatev1.CreateProjectRequest) (*createProjectParams, error) {
+	project, err := projectFromProto(req.GetProject())
+	if err != nil {
+		return nil, fmt.Errorf("parse project: %w", err)
+	}
+
+	return &createProjectParams{project: project}, nil
+}
+
+func (c *createProjectParams) validate() error {
+	if c.project == nil {
+		return errors.New("'project' is required")
+	}
+
+	if c.project.Name == "" {
+		return errors.New("'project.name' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) CreateProject(
+	ctx context.Context,
+	req *translatev1.CreateProjectRequest,
+) (*translatev1.Project, error) {
+	params, err := parseCreateProjectParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = t.repo.SaveProject(ctx, params.project)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return projectToProto(params.project), nil
+}
+
+// ---------------------UpdateProject-------------------------------
+
+type updateProjectParams struct {
+	project *model.Project
+}
+
+func parseUpdateProjectParams(req *translatev1.UpdateProjectRequest) (*updateProjectParams, error) {
+	project, err := projectFromProto(req.GetProject())
+	if err != nil {
+		return nil, fmt.Errorf("parse project: %w", err)
+	}
+
+	return &updateProjectParams{project: project}, nil
+}
+
+func (u *updateProjectParams) validate() error {
+	if u.project == nil {
+		return errors.New("'project' is required")
+	}
+
+	if u.project.ID == uuid.Nil {
+		return errors.New("'project.id' is required")
+	}
+
+	if u.project.Name == "" {
+		return errors.New("'project.name' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) UpdateProject(
+	ctx context.Context,
+	req *translatev1.UpdateProjectRequest,
+) (*translatev1.Project, error) {
+	params, err := parseUpdateProjectParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = t.repo.LoadProject(ctx, params.project.ID)
+
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "project not found")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	err = t.repo.SaveProject(ctx, params.project)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return projectToProto(params.project), nil
+}
+
+// ----------------------DeleteProject------------------------------
+
+var (
+	// errProjectHasServices is returned when deleting a project with services.
+	errProjectHasServices = errors.New("project has services")
+	// errProjectNotFound is returned when saving a service to a project that does not exist.
+	errProjectNotFound = errors.New("project not found")
+)
+
+type deleteProjectParams struct {
+	id uuid.UUID
+}
+
+func parseDeleteProjectRequest(req *translatev1.DeleteProjectRequest) (*deleteProjectParams, error) {
+	id, err := uuidFromProto(req.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("parse id: %w", err)
+	}
+
+	return &deleteProjectParams{id: id}, nil
+}
+
+func (d *deleteProjectParams) validate() error {
+	if d.id == uuid.Nil {
+		return errors.New("'id' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) DeleteProject(
+	ctx context.Context,
+	req *translatev1.DeleteProjectRequest,
+) (*emptypb.Empty, error) {
+	params, err := parseDeleteProjectRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if params.id == model.DefaultProjectID {
+		return nil, status.Error(codes.FailedPrecondition, "default project cannot be deleted")
+	}
+
+	var count int
+
+	// The services are checked in the same transaction, so that no service is added to the project
+	// while it is deleted.
+	err = t.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		services, inErr := r.LoadServices(ctx, repo.LoadServicesOpts{FilterProjectID: params.id})
+		if inErr != nil {
+			return fmt.Errorf("load services: %w", inErr)
+		}
+
+		if count = len(services); count > 0 {
+			return errProjectHasServices
+		}
+
+		inErr = r.DeleteProject(ctx, params.id)
+		if inErr != nil {
+			return fmt.Errorf("delete project: %w", inErr)
+		}
+
+		return nil
+	})
+
+	switch {
+	default:
+		return &emptypb.Empty{}, nil
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "project not found")
+	case errors.Is(err, errProjectHasServices):
+		return nil, status.Errorf(codes.FailedPrecondition, "project has %d services", count)
+	case errors.Is(err, repo.ErrConflict):
+		return nil, status.Error(codes.Aborted, "project services were changed concurrently")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+}
+
+// helpers
+
+// saveService saves the service, the project of the service is checked in the same transaction if checkProject
+// is set, so that the project is not deleted while the service is added to it.
+// It returns errProjectNotFound if the project does not exist.
+func (t *TranslateServiceServer) saveService(ctx context.Context, service *model.Service, checkProject bool) error {
+	return t.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error { //nolint:wrapcheck
+		if checkProject {
+			project, err := r.LoadProject(ctx, service.ProjectID)
+
+			switch {
+			case errors.Is(err, repo.ErrNotFound):
+				return errProjectNotFound
+			case err != nil:
+				return fmt.Errorf("load project: %w", err)
+			}
+
+			// The unchanged project is saved, so that a concurrent DeleteProject conflicts
+			// even if the database has no foreign keys, e.g. BadgerDB.
+			err = r.SaveProject(ctx, project)
+			if err != nil {
+				return fmt.Errorf("save project: %w", err)
+			}
+		}
+
+		err := r.SaveService(ctx, service)
+		if err != nil {
+			return fmt.Errorf("save service: %w", err)
+		}
+
+		return nil
+	})
+}
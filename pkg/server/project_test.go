@@ -0,0 +1,163 @@
+package server
+
+import (
+	"sync"
+	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_Projects(t *testing.T) {
+	t.Parallel()
+
+	translateSrv := NewTranslateServiceServer(newInMemoryRepo(t), &mockTranslator{})
+	ctx := t.Context()
+
+	project, err := translateSrv.CreateProject(ctx, &translatev1.CreateProjectRequest{
+		Project: &translatev1.Project{Name: "Team"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	service, err := translateSrv.CreateService(ctx, &translatev1.CreateServiceRequest{
+		Service:   &translatev1.Service{Name: "Web"},
+		ProjectId: project.GetId(),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defaultService, err := translateSrv.CreateService(ctx, &translatev1.CreateServiceRequest{
+		Service: &translatev1.Service{Name: "App"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if defaultService.GetProjectId() != model.DefaultProjectID.String() {
+		t.Errorf("want service in the default project, got '%s'", defaultService.GetProjectId())
+	}
+
+	// Services of the project
+	resp, err := translateSrv.ListServices(ctx, &translatev1.ListServicesRequest{ProjectId: project.GetId()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(resp.GetServices()) != 1 || resp.GetServices()[0].GetId() != service.GetId() {
+		t.Errorf("want service '%s' in project, got %v", service.GetId(), resp.GetServices())
+	}
+
+	tests := []struct {
+		run      func() error
+		name     string
+		wantCode codes.Code
+	}{
+		{
+			name: "Create service in nonexistent project",
+			run: func() error {
+				_, inErr := translateSrv.CreateService(ctx, &translatev1.CreateServiceRequest{
+					Service:   &translatev1.Service{Name: "Web"},
+					ProjectId: "00000000-0000-0000-0000-00000000ffff",
+				})
+
+				return inErr //nolint:wrapcheck
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Delete default project",
+			run: func() error {
+				_, inErr := translateSrv.DeleteProject(ctx,
+					&translatev1.DeleteProjectRequest{Id: model.DefaultProjectID.String()})
+
+				return inErr //nolint:wrapcheck
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "Delete project with services",
+			run: func() error {
+				_, inErr := translateSrv.DeleteProject(ctx, &translatev1.DeleteProjectRequest{Id: project.GetId()})
+				return inErr //nolint:wrapcheck
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "Move service to the default project",
+			run: func() error {
+				_, inErr := translateSrv.UpdateService(ctx, &translatev1.UpdateServiceRequest{
+					Service: &translatev1.Service{Id: service.GetId(), ProjectId: model.DefaultProjectID.String()},
+				})
+
+				return inErr //nolint:wrapcheck
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Delete empty project",
+			run: func() error {
+				_, inErr := translateSrv.DeleteProject(ctx, &translatev1.DeleteProjectRequest{Id: project.GetId()})
+				return inErr //nolint:wrapcheck
+			},
+			wantCode: codes.OK,
+		},
+	}
+
+	// Steps depend on the previous ones, run sequentially.
+	for _, test := range tests {
+		err = test.run()
+		if status.Code(err) != test.wantCode {
+			t.Errorf("%s: want status '%s', got '%s'", test.name, test.wantCode, status.Code(err))
+		}
+	}
+}
+
+func Test_DeleteProjectConcurrentCreateService(t *testing.T) {
+	t.Parallel()
+
+	translateSrv := NewTranslateServiceServer(newInMemoryRepo(t), &mockTranslator{})
+	ctx := t.Context()
+
+	for range 20 {
+		project, err := translateSrv.CreateProject(ctx, &translatev1.CreateProjectRequest{
+			Project: &translatev1.Project{Name: "Team"},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		var (
+			wg                   sync.WaitGroup
+			createErr, deleteErr error
+		)
+
+		wg.Go(func() {
+			_, createErr = translateSrv.CreateService(ctx, &translatev1.CreateServiceRequest{
+				Service:   &translatev1.Service{Name: "Web"},
+				ProjectId: project.GetId(),
+			})
+		})
+
+		wg.Go(func() {
+			_, deleteErr = translateSrv.DeleteProject(ctx, &translatev1.DeleteProjectRequest{Id: project.GetId()})
+		})
+
+		wg.Wait()
+
+		// The service is either added to the project, or the project is deleted first.
+		if createErr == nil && deleteErr == nil {
+			t.Errorf("want a service not added to the deleted project '%s'", project.GetId())
+			return
+		}
+	}
+}
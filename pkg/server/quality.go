@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"go.expect.digital/translate/pkg/fuzzy"
+	"go.expect.digital/translate/pkg/model"
+)
+
+// scoreQuality back-translates the fuzzy messages of the translated translation to the source language
+// with the same translator, and sets their quality score by comparing them with the source messages.
+// Does nothing if back-translation is disabled, or for pseudo-localization.
+func (t *TranslateServiceServer) scoreQuality(
+	ctx context.Context,
+	service *model.Service,
+	translator fuzzy.Translator,
+	translatorName string,
+	source, translated *model.Translation,
+) error {
+	if _, pseudo := translator.(*fuzzy.PseudoTranslate); !t.backTranslate || pseudo {
+		return nil
+	}
+
+	toBeBackTranslated := &model.Translation{Language: translated.Language}
+
+	for _, msg := range translated.Messages {
+		if msg.Status == model.MessageStatusFuzzy {
+			toBeBackTranslated.Messages = append(toBeBackTranslated.Messages, msg)
+		}
+	}
+
+	if len(toBeBackTranslated.Messages) == 0 {
+		return nil
+	}
+
+	backTranslated, err := t.meteredTranslate(ctx, service, translator, translatorName,
+		toBeBackTranslated, source.Language)
+	if err != nil {
+		return fmt.Errorf("back-translate messages to '%s': %w", source.Language, err)
+	}
+
+	err = fuzzy.ScoreQuality(source, translated, backTranslated, t.qualityThreshold)
+	if err != nil {
+		return fmt.Errorf("score quality: %w", err)
+	}
+
+	return nil
+}
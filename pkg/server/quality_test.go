@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fixedTranslator translates every message to the same message.
+type fixedTranslator struct {
+	message string
+}
+
+func (f *fixedTranslator) Translate(_ context.Context,
+	translation *model.Translation,
+	targetLanguage language.Tag,
+) (*model.Translation, error) {
+	translated := &model.Translation{Language: targetLanguage, Messages: make([]model.Message, len(translation.Messages))}
+
+	for i, msg := range translation.Messages {
+		msg.Message = f.message
+		msg.Status = model.MessageStatusFuzzy
+		translated.Messages[i] = msg
+	}
+
+	return translated, nil
+}
+
+func Test_scoreQuality(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		backTranslated string
+		wantFlagged    bool
+	}{
+		{
+			name:           "Accurate back-translation",
+			backTranslated: "{{Save the file}}",
+		},
+		{
+			name:           "Inaccurate back-translation",
+			backTranslated: "{{Cancel my order}}",
+			wantFlagged:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			translator := &fixedTranslator{message: test.backTranslated}
+			translateSrv := NewTranslateServiceServer(nil, translator, WithBackTranslation(0))
+
+			source := &model.Translation{
+				Language: language.English,
+				Messages: []model.Message{{ID: "save", Message: "{{Save the file}}"}},
+			}
+
+			translated := &model.Translation{
+				Language: language.Latvian,
+				Messages: []model.Message{{ID: "save", Message: "{{Saglabāt failu}}", Status: model.MessageStatusFuzzy}},
+			}
+
+			err := translateSrv.scoreQuality(t.Context(), nil, translator, "", source, translated)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			msg := translated.Messages[0]
+
+			if msg.QualityScore == nil {
+				t.Error("want quality score, got nil")
+				return
+			}
+
+			if flagged := msg.StatusReason != ""; flagged != test.wantFlagged {
+				t.Errorf("want flagged %t, got score %.2f, status reason '%s'", test.wantFlagged, *msg.QualityScore,
+					msg.StatusReason)
+			}
+		})
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		translator := &fixedTranslator{message: "{{Cancel my order}}"}
+		translateSrv := NewTranslateServiceServer(nil, translator)
+
+		translated := &model.Translation{
+			Language: language.Latvian,
+			Messages: []model.Message{{ID: "save", Message: "{{Saglabāt failu}}", Status: model.MessageStatusFuzzy}},
+		}
+
+		err := translateSrv.scoreQuality(t.Context(), nil, translator, "", randOriginalTranslation(1), translated)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if translated.Messages[0].QualityScore != nil {
+			t.Errorf("want no quality score, got %.2f", *translated.Messages[0].QualityScore)
+		}
+	})
+}
+
+func Test_ListTranslationsMaxQualityScore(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	low, high := 0.2, 0.9
+
+	translation := &model.Translation{
+		Language: language.Latvian,
+		Messages: []model.Message{
+			{ID: "low", Message: "{{Zems}}", Status: model.MessageStatusFuzzy, QualityScore: &low},
+			{ID: "high", Message: "{{Augsts}}", Status: model.MessageStatusFuzzy, QualityScore: &high},
+			{ID: "unscored", Message: "{{Nav}}", Status: model.MessageStatusTranslated},
+		},
+	}
+
+	err = r.SaveTranslation(ctx, service.ID, translation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("Filter", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := translateSrv.ListTranslations(ctx, &translatev1.ListTranslationsRequest{
+			ServiceId:       service.ID.String(),
+			MaxQualityScore: proto.Float64(0.5),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		messages := resp.GetTranslations()[0].GetMessages()
+		if len(messages) != 1 || messages[0].GetId() != "low" || messages[0].GetQualityScore() != low {
+			t.Errorf("want only message 'low', got %v", messages)
+		}
+	})
+
+	t.Run("No filter", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := translateSrv.ListTranslations(ctx, &translatev1.ListTranslationsRequest{
+			ServiceId: service.ID.String(),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if messages := resp.GetTranslations()[0].GetMessages(); len(messages) != len(translation.Messages) {
+			t.Errorf("want %d messages, got %d", len(translation.Messages), len(messages))
+		}
+	})
+
+	t.Run("Out of range", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := translateSrv.ListTranslations(ctx, &translatev1.ListTranslationsRequest{
+			ServiceId:       service.ID.String(),
+			MaxQualityScore: proto.Float64(1.5),
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("want code %s, got %v", codes.InvalidArgument, err)
+		}
+	})
+}
@@ -0,0 +1,290 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// actorMetadataKey is the request metadata key of the actor recorded in message revisions,
+// REST requests set it with the "Grpc-Metadata-Actor" header.
+const actorMetadataKey = "actor"
+
+// ActorInterceptor adds the actor of the request metadata to the request context,
+// the actor is recorded in the message revisions of the request.
+func ActorInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if actors := metadata.ValueFromIncomingContext(ctx, actorMetadataKey); len(actors) > 0 {
+		ctx = repo.WithActor(ctx, actors[0])
+	}
+
+	return handler(ctx, req)
+}
+
+// RunRevisionPruning deletes the message revisions older than the revision retention
+// on start and at every prune interval until ctx is done. It returns immediately if revisions are kept forever.
+func (t *TranslateServiceServer) RunRevisionPruning(ctx context.Context) {
+	if t.revisionRetention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(revisionPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		err := t.pruneRevisions(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("prune message revisions: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pruneRevisions deletes the message revisions created more than the revision retention before now.
+func (t *TranslateServiceServer) pruneRevisions(ctx context.Context, now time.Time) error {
+	err := t.repo.DeleteMessageRevisions(ctx, now.Add(-t.revisionRetention).UTC())
+	if err != nil {
+		return fmt.Errorf("delete message revisions: %w", err)
+	}
+
+	return nil
+}
+
+// ----------------------ListMessageRevisions-------------------------------
+
+type listMessageRevisionsParams struct {
+	language  language.Tag
+	messageID string
+	serviceID uuid.UUID
+}
+
+func parseListMessageRevisionsRequestParams(
+	req *translatev1.ListMessageRevisionsRequest,
+) (*listMessageRevisionsParams, error) {
+	var (
+		params = listMessageRevisionsParams{messageID: req.GetMessageId()}
+		err    error
+	)
+
+	params.serviceID, err = uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	params.language, err = languageFromProto(req.GetLanguage())
+	if err != nil {
+		return nil, fmt.Errorf("parse language: %w", err)
+	}
+
+	return &params, nil
+}
+
+func (l *listMessageRevisionsParams) validate() error {
+	if l.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if l.language == language.Und {
+		return errors.New("'language' is required")
+	}
+
+	if l.messageID == "" {
+		return errors.New("'message_id' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) ListMessageRevisions(
+	ctx context.Context,
+	req *translatev1.ListMessageRevisionsRequest,
+) (*translatev1.ListMessageRevisionsResponse, error) {
+	params, err := parseListMessageRevisionsRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	revisions, err := t.repo.LoadMessageRevisions(ctx, params.serviceID, params.language, params.messageID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &translatev1.ListMessageRevisionsResponse{Revisions: messageRevisionsToProto(revisions)}, nil
+}
+
+// ----------------------RevertMessage-------------------------------
+
+type revertMessageParams struct {
+	language   language.Tag
+	messageID  string
+	serviceID  uuid.UUID
+	revisionID uuid.UUID
+}
+
+func parseRevertMessageRequestParams(req *translatev1.RevertMessageRequest) (*revertMessageParams, error) {
+	var (
+		params = revertMessageParams{messageID: req.GetMessageId()}
+		err    error
+	)
+
+	params.serviceID, err = uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	params.language, err = languageFromProto(req.GetLanguage())
+	if err != nil {
+		return nil, fmt.Errorf("parse language: %w", err)
+	}
+
+	params.revisionID, err = uuidFromProto(req.GetRevisionId())
+	if err != nil {
+		return nil, fmt.Errorf("parse revision_id: %w", err)
+	}
+
+	return &params, nil
+}
+
+func (r *revertMessageParams) validate() error {
+	if r.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if r.language == language.Und {
+		return errors.New("'language' is required")
+	}
+
+	if r.messageID == "" {
+		return errors.New("'message_id' is required")
+	}
+
+	if r.revisionID == uuid.Nil {
+		return errors.New("'revision_id' is required")
+	}
+
+	return nil
+}
+
+// RevertMessage sets the text and status of the message to the new text and status of the revision.
+// A changed message of the original translation is marked untranslated in the other translations
+// and fuzzy translated by a job, as in UpdateTranslation.
+func (t *TranslateServiceServer) RevertMessage(
+	ctx context.Context,
+	req *translatev1.RevertMessageRequest,
+) (*translatev1.Message, error) {
+	params, err := parseRevertMessageRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	revisions, err := t.repo.LoadMessageRevisions(ctx, params.serviceID, params.language, params.messageID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	idx := slices.IndexFunc(revisions, func(r model.MessageRevision) bool { return r.ID == params.revisionID })
+	if idx == -1 {
+		return nil, status.Errorf(codes.NotFound,
+			"no revision '%s' for message: '%s'", params.revisionID, params.messageID)
+	}
+
+	all, err := t.repo.LoadTranslations(ctx, params.serviceID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	langIdx := all.LanguageIndex(params.language)
+	if langIdx == -1 {
+		return nil, status.Errorf(codes.NotFound, "no translation for language: '%s'", params.language)
+	}
+
+	translation := &all[langIdx]
+
+	msgIdx := slices.IndexFunc(translation.Messages, func(m model.Message) bool { return m.ID == params.messageID })
+	if msgIdx == -1 {
+		return nil, status.Errorf(codes.NotFound,
+			"no message '%s' for language: '%s'", params.messageID, params.language)
+	}
+
+	previous := model.Translation{Messages: []model.Message{translation.Messages[msgIdx]}}
+
+	translation.Messages[msgIdx].Message = revisions[idx].New.Message
+	translation.Messages[msgIdx].Status = revisions[idx].New.Status
+
+	// Only the translation of the message is saved, unless it is the original translation.
+	affected := all[langIdx : langIdx+1]
+
+	if translation.Original {
+		all.MarkUntranslated(previous.FindChangedMessageIDs(
+			&model.Translation{Messages: []model.Message{translation.Messages[msgIdx]}}))
+
+		affected = all
+	}
+
+	var job *model.Job
+
+	err = t.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		for i := range affected {
+			inErr := r.SaveTranslation(ctx, params.serviceID, &affected[i])
+			if inErr != nil {
+				return fmt.Errorf("save translation: %w", inErr)
+			}
+		}
+
+		if translation.Original {
+			var inErr error
+
+			job, inErr = enqueueJob(ctx, r, params.serviceID, all)
+			if inErr != nil {
+				return fmt.Errorf("enqueue job: %w", inErr)
+			}
+		}
+
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, repo.ErrConflict):
+		return nil, status.Error(codes.Aborted, "translations were changed concurrently")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	if job != nil {
+		t.notifyJobs()
+	}
+
+	return messageToProto(&translation.Messages[msgIdx]), nil
+}
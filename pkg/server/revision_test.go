@@ -0,0 +1,214 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_RevertMessage(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	original := &model.Translation{
+		Language: language.English,
+		Original: true,
+		Messages: []model.Message{{ID: "greeting", Message: "Hello", Status: model.MessageStatusTranslated}},
+	}
+
+	translation := &model.Translation{
+		Language: language.Latvian,
+		Messages: []model.Message{{ID: "greeting", Message: "Sveiki", Status: model.MessageStatusTranslated}},
+	}
+
+	for _, translation := range []*model.Translation{original, translation} {
+		err = r.SaveTranslation(ctx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// Change the original message with an actor set by the request metadata.
+	actorCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("actor", "alice"))
+
+	_, err = ActorInterceptor(actorCtx, nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, _ any) (any, error) {
+			original.Messages[0].Message = "Hi"
+			return nil, r.SaveTranslation(ctx, service.ID, original) //nolint:wrapcheck
+		})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	resp, err := translateSrv.ListMessageRevisions(ctx, &translatev1.ListMessageRevisionsRequest{
+		ServiceId: service.ID.String(),
+		Language:  language.English.String(),
+		MessageId: "greeting",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	revisions := resp.GetRevisions()
+	if len(revisions) != 2 {
+		t.Errorf("want 2 revisions, got %d", len(revisions))
+		return
+	}
+
+	if revisions[0].GetNewMessage() != "Hi" || revisions[0].GetOldMessage() != "Hello" ||
+		revisions[0].GetActor() != "alice" {
+		t.Errorf("want latest revision 'Hello' -> 'Hi' by alice, got %v", revisions[0])
+	}
+
+	if revisions[1].OldMessage != nil || revisions[1].GetNewMessage() != "Hello" || revisions[1].GetActor() != "" {
+		t.Errorf("want first revision adding 'Hello' without actor, got %v", revisions[1])
+	}
+
+	// Revert the original message to the first revision.
+	msg, err := translateSrv.RevertMessage(ctx, &translatev1.RevertMessageRequest{
+		ServiceId:  service.ID.String(),
+		Language:   language.English.String(),
+		MessageId:  "greeting",
+		RevisionId: revisions[1].GetId(),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if msg.GetMessage() != "Hello" {
+		t.Errorf("want reverted message 'Hello', got '%s'", msg.GetMessage())
+	}
+
+	translations, err := r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{
+		FilterLanguages: []language.Tag{language.Latvian},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got := translations[0].Messages[0].Status; got != model.MessageStatusUntranslated {
+		t.Errorf("want translation of the reverted message untranslated, got %s", got.String())
+	}
+
+	jobs, err := r.LoadJobs(ctx, repo.LoadJobsOpts{FilterServiceID: service.ID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(jobs) != 1 {
+		t.Errorf("want a job to fuzzy translate the reverted message, got %d jobs", len(jobs))
+	}
+
+	// The revert is recorded as a new revision.
+	resp, err = translateSrv.ListMessageRevisions(ctx, &translatev1.ListMessageRevisionsRequest{
+		ServiceId: service.ID.String(),
+		Language:  language.English.String(),
+		MessageId: "greeting",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(resp.GetRevisions()) != 3 || resp.GetRevisions()[0].GetNewMessage() != "Hello" {
+		t.Errorf("want the revert as the latest of 3 revisions, got %v", resp.GetRevisions())
+	}
+
+	_, err = translateSrv.RevertMessage(ctx, &translatev1.RevertMessageRequest{
+		ServiceId:  service.ID.String(),
+		Language:   language.Latvian.String(),
+		MessageId:  "greeting",
+		RevisionId: revisions[1].GetId(), // revision of another language
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("want status '%s', got '%s'", codes.NotFound, status.Code(err))
+	}
+}
+
+func Test_pruneRevisions(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{}, WithRevisionRetention(time.Hour))
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	translation := &model.Translation{
+		Language: language.Latvian,
+		Messages: []model.Message{{ID: "greeting", Message: "Sveiki", Status: model.MessageStatusTranslated}},
+	}
+
+	err = r.SaveTranslation(ctx, service.ID, translation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Revisions within the retention are kept.
+	err = translateSrv.pruneRevisions(ctx, time.Now())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	revisions, err := r.LoadMessageRevisions(ctx, service.ID, language.Latvian, "greeting")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(revisions) != 1 {
+		t.Errorf("want 1 revision within the retention, got %d", len(revisions))
+	}
+
+	// Revisions older than the retention are deleted.
+	err = translateSrv.pruneRevisions(ctx, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	revisions, err = r.LoadMessageRevisions(ctx, service.ID, language.Latvian, "greeting")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(revisions) != 0 {
+		t.Errorf("want no revisions older than the retention, got %d", len(revisions))
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ----------------------SearchMessages-------------------------------
+
+// defaultSearchPageSize is the number of messages found by SearchMessages without a page size.
+const defaultSearchPageSize = 100
+
+type searchMessagesParams struct {
+	terms    []string
+	opts     repo.SearchMessagesOpts
+	pageSize int
+}
+
+func parseSearchMessagesRequestParams(req *translatev1.SearchMessagesRequest) (*searchMessagesParams, error) {
+	params := &searchMessagesParams{
+		opts:     repo.SearchMessagesOpts{Query: req.GetQuery()},
+		terms:    model.SearchTerms(req.GetQuery()),
+		pageSize: int(req.GetPageSize()),
+	}
+
+	for _, s := range req.GetLanguages() {
+		lang, err := languageFromProto(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse languages: %w", err)
+		}
+
+		params.opts.FilterLanguages = append(params.opts.FilterLanguages, lang)
+	}
+
+	for _, s := range req.GetStatuses() {
+		params.opts.FilterStatuses = append(params.opts.FilterStatuses, model.MessageStatus(s))
+	}
+
+	return params, nil
+}
+
+func (s *searchMessagesParams) validate() error {
+	if len(s.terms) == 0 {
+		return errors.New("'query' must contain a word")
+	}
+
+	if s.pageSize < 0 {
+		return errors.New("'page_size' must not be negative")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) SearchMessages(
+	ctx context.Context,
+	req *translatev1.SearchMessagesRequest,
+) (*translatev1.SearchMessagesResponse, error) {
+	params, err := parseSearchMessagesRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	params.opts.Limit = min(params.pageSize, maxPageSize)
+	if params.opts.Limit == 0 {
+		params.opts.Limit = defaultSearchPageSize
+	}
+
+	results, err := t.repo.SearchMessages(ctx, params.opts)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &translatev1.SearchMessagesResponse{Results: searchResultsToProto(results, params.terms)}, nil
+}
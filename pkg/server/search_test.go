@@ -0,0 +1,140 @@
+package server
+
+import (
+	"testing"
+
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_SearchMessages(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, translation := range []*model.Translation{
+		{
+			Language: language.English,
+			Original: true,
+			Messages: []model.Message{{ID: "cart.checkout", Message: "Proceed to checkout"}},
+		},
+		{
+			Language: language.Latvian,
+			Messages: []model.Message{
+				{ID: "cart.checkout", Message: "Doties uz apmaksu", Status: model.MessageStatusTranslated},
+				{ID: "cart.empty", Message: "Grozs ir tukšs", Status: model.MessageStatusTranslated},
+			},
+		},
+	} {
+		err = r.SaveTranslation(ctx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	resp, err := translateSrv.SearchMessages(ctx, &translatev1.SearchMessagesRequest{Query: "Checkout"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*translatev1.SearchMessagesResponse_Result{
+		{
+			ServiceId:         service.ID.String(),
+			Language:          "en",
+			Message:           &translatev1.Message{Id: "cart.checkout", Message: "Proceed to checkout"},
+			IdHighlights:      []*translatev1.Highlight{{Start: 5, End: 13}},
+			MessageHighlights: []*translatev1.Highlight{{Start: 11, End: 19}},
+		},
+		{
+			ServiceId: service.ID.String(),
+			Language:  "lv",
+			Message: &translatev1.Message{
+				Id: "cart.checkout", Message: "Doties uz apmaksu", Status: translatev1.Message_TRANSLATED,
+			},
+			IdHighlights:      []*translatev1.Highlight{{Start: 5, End: 13}},
+			MessageHighlights: []*translatev1.Highlight{},
+		},
+	}
+
+	if len(resp.GetResults()) != len(want) {
+		t.Errorf("want %d results, got %v", len(want), resp.GetResults())
+		return
+	}
+
+	for i := range want {
+		if !proto.Equal(want[i], resp.GetResults()[i]) {
+			t.Errorf("want result %v, got %v", want[i], resp.GetResults()[i])
+		}
+	}
+
+	// Highlights are in code points.
+	resp, err = translateSrv.SearchMessages(ctx, &translatev1.SearchMessagesRequest{
+		Query:     "tukš",
+		Languages: []string{"lv"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(resp.GetResults()) != 1 ||
+		!proto.Equal(&translatev1.Highlight{Start: 9, End: 14}, resp.GetResults()[0].GetMessageHighlights()[0]) {
+		t.Errorf("want 'tukšs' highlighted, got %v", resp.GetResults())
+	}
+}
+
+func Test_SearchMessagesError(t *testing.T) {
+	t.Parallel()
+
+	translateSrv := NewTranslateServiceServer(newInMemoryRepo(t), &mockTranslator{})
+
+	tests := []struct {
+		req  *translatev1.SearchMessagesRequest
+		name string
+	}{
+		{
+			name: "Missing query",
+			req:  &translatev1.SearchMessagesRequest{},
+		},
+		{
+			name: "Query without words",
+			req:  &translatev1.SearchMessagesRequest{Query: "..."},
+		},
+		{
+			name: "Invalid language",
+			req:  &translatev1.SearchMessagesRequest{Query: "checkout", Languages: []string{"xyz_invalid"}},
+		},
+		{
+			name: "Negative page size",
+			req:  &translatev1.SearchMessagesRequest{Query: "checkout", PageSize: -1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := translateSrv.SearchMessages(t.Context(), test.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("want status '%s', got '%s'", codes.InvalidArgument, status.Code(err))
+			}
+		})
+	}
+}
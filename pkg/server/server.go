@@ -1,18 +1,236 @@
 package server
 
 import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
 	"go.expect.digital/translate/pkg/fuzzy"
+	"go.expect.digital/translate/pkg/model"
 	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
 	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+)
+
+const (
+	// defaultTranslateConcurrency is the default number of languages fuzzy translated at once.
+	defaultTranslateConcurrency = 4
+	// defaultJobPollInterval is the default interval at which the job worker checks for pending jobs.
+	defaultJobPollInterval = 10 * time.Second
+	// jobLease is how long a job claimed by the server stays claimed, the lease is extended while the job runs.
+	jobLease = 5 * time.Minute
+	// revisionPruneInterval is the interval at which message revisions older than the retention are deleted.
+	revisionPruneInterval = time.Hour
+	// webhookDeliveryPruneInterval is the interval at which webhook deliveries older than the retention are deleted.
+	webhookDeliveryPruneInterval = time.Hour
+	// jobPruneInterval is the interval at which finished jobs older than the retention are deleted.
+	jobPruneInterval = time.Hour
 )
 
 type TranslateServiceServer struct {
 	translatev1.UnimplementedTranslateServiceServer
 
-	repo       repo.Repo
-	translator fuzzy.Translator
+	repo repo.Repo
+	// instanceID identifies the server among the servers sharing the repo, e.g. as the owner of claimed jobs.
+	instanceID       uuid.UUID
+	translator       fuzzy.Translator
+	pseudoTranslator fuzzy.Translator
+	languageDetector fuzzy.LanguageDetector
+	translators      map[string]fuzzy.Translator // translators available to service translator routing
+	// events are the message changes streamed by WatchTranslations.
+	events *eventBus
+	// jobs wakes up the job worker when a new job is enqueued.
+	jobs chan struct{}
+	// webhooks wakes up the webhook worker when a delivery is enqueued.
+	webhooks             chan struct{}
+	webhookClient        *http.Client
+	translatorName       string // name of the default translator, empty if usage is not metered
+	translateConcurrency int
+	jobPollInterval      time.Duration
+	// qualityThreshold is the back-translation quality score below which fuzzy messages are flagged.
+	qualityThreshold float64
+	backTranslate    bool
+	// markObsolete keeps messages removed from the original translation as obsolete instead of deleting them.
+	markObsolete bool
+	// revisionRetention is how long message revisions are kept, zero keeps them forever.
+	revisionRetention time.Duration
+	// webhookDeliveryRetention is how long finished webhook deliveries are kept, zero keeps them forever.
+	webhookDeliveryRetention time.Duration
+	// jobRetention is how long finished jobs are kept, zero keeps them forever.
+	jobRetention time.Duration
+}
+
+// TranslateServiceServerOption configures optional TranslateServiceServer properties.
+type TranslateServiceServerOption func(*TranslateServiceServer)
+
+// WithTranslateConcurrency limits the number of languages that are fuzzy translated concurrently.
+// If n is not positive, the default limit is used.
+func WithTranslateConcurrency(n int) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		if n > 0 {
+			t.translateConcurrency = n
+		}
+	}
+}
+
+// WithPseudoTranslator sets the translator used for pseudo-locales (e.g. en-XA, ar-XB),
+// so that pseudo translations can be requested regardless of the configured translator.
+func WithPseudoTranslator(translator fuzzy.Translator) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		t.pseudoTranslator = translator
+	}
+}
+
+// WithTranslators sets the translators by name, e.g. "GoogleTranslate",
+// that services can select per language pair with translator routing.
+func WithTranslators(translators map[string]fuzzy.Translator) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		t.translators = translators
+	}
 }
 
-func NewTranslateServiceServer(r repo.Repo, translator fuzzy.Translator) *TranslateServiceServer {
-	return &TranslateServiceServer{repo: r, translator: translator}
+// WithLanguageDetector sets the language detector used for uploads
+// when neither the request nor the file sets a language.
+func WithLanguageDetector(detector fuzzy.LanguageDetector) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		t.languageDetector = detector
+	}
+}
+
+// WithJobPollInterval sets the interval at which the job worker checks for pending jobs,
+// e.g. jobs enqueued by another instance sharing the same database.
+// If d is not positive, the default interval is used.
+func WithJobPollInterval(d time.Duration) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		if d > 0 {
+			t.jobPollInterval = d
+		}
+	}
+}
+
+// WithBackTranslation enables the quality check of machine translations - fuzzy messages are
+// back-translated to the original language and scored against the source,
+// messages scoring below the threshold are flagged for review.
+// If threshold is not positive, fuzzy.DefaultQualityThreshold is used.
+func WithBackTranslation(threshold float64) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		t.backTranslate = true
+		t.qualityThreshold = fuzzy.DefaultQualityThreshold
+
+		if threshold > 0 {
+			t.qualityThreshold = threshold
+		}
+	}
+}
+
+// WithMarkObsolete controls messages removed from the original translation on upload or update.
+// If enabled, the messages are kept in all translations with the OBSOLETE status,
+// otherwise they are deleted from all translations.
+func WithMarkObsolete(enabled bool) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		t.markObsolete = enabled
+	}
+}
+
+// WithRevisionRetention sets how long message revisions are kept, older revisions are deleted by RunRevisionPruning.
+// If d is not positive, revisions are kept forever.
+func WithRevisionRetention(d time.Duration) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		if d > 0 {
+			t.revisionRetention = d
+		}
+	}
+}
+
+// WithWebhookDeliveryRetention sets how long webhook deliveries are kept, older deliveries are deleted
+// by RunWebhookDeliveryPruning unless pending. If d is not positive, deliveries are kept forever.
+func WithWebhookDeliveryRetention(d time.Duration) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		if d > 0 {
+			t.webhookDeliveryRetention = d
+		}
+	}
+}
+
+// WithJobRetention sets how long finished jobs are kept, older jobs are deleted by RunJobPruning.
+// If d is not positive, jobs are kept forever.
+func WithJobRetention(d time.Duration) TranslateServiceServerOption {
+	return func(t *TranslateServiceServer) {
+		if d > 0 {
+			t.jobRetention = d
+		}
+	}
+}
+
+func NewTranslateServiceServer(
+	r repo.Repo,
+	translator fuzzy.Translator,
+	opts ...TranslateServiceServerOption,
+) *TranslateServiceServer {
+	events := newEventBus()
+
+	t := &TranslateServiceServer{
+		instanceID:           uuid.New(),
+		events:               events,
+		translator:           translator,
+		translatorName:       fuzzy.Name(translator),
+		translateConcurrency: defaultTranslateConcurrency,
+		jobs:                 make(chan struct{}, 1),
+		webhooks:             make(chan struct{}, 1),
+		webhookClient:        &http.Client{Timeout: webhookTimeout},
+		jobPollInterval:      defaultJobPollInterval,
+	}
+
+	t.repo = &eventRepo{Repo: r, bus: events, notifyWebhooks: t.notifyWebhooks}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// translatorFor returns the translator and its name to use for the service translations
+// from the source to the target language.
+// The first matching route of the service translator routing is used, otherwise the default translator.
+// Returns nil if machine translation is disabled for the language pair.
+func (t *TranslateServiceServer) translatorFor( //nolint:ireturn
+	service *model.Service,
+	source, target language.Tag,
+) (fuzzy.Translator, string, error) {
+	if t.pseudoTranslator != nil && fuzzy.IsPseudoLocale(target) {
+		return t.pseudoTranslator, fuzzy.Name(t.pseudoTranslator), nil
+	}
+
+	if service == nil {
+		return t.translator, t.translatorName, nil
+	}
+
+	name, ok := service.TranslatorRouting.Translator(source, target)
+
+	switch {
+	case !ok:
+		return t.translator, t.translatorName, nil
+	case name == model.TranslatorNone:
+		return nil, "", nil
+	}
+
+	translator, ok := t.translators[name]
+	if !ok {
+		return nil, "", fmt.Errorf("translator '%s' is not available", name)
+	}
+
+	return translator, name, nil
+}
+
+// validateTranslatorRouting checks that the routes select available translators.
+func (t *TranslateServiceServer) validateTranslatorRouting(routing *model.TranslatorRouting) error {
+	for i, route := range routing.Routes {
+		if _, ok := t.translators[route.Translator]; !ok && route.Translator != model.TranslatorNone {
+			return fmt.Errorf("translator routing: route #%d: translator '%s' is not available", i, route.Translator)
+		}
+	}
+
+	return nil
 }
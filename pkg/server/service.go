@@ -63,11 +63,29 @@ func (t *TranslateServiceServer) GetService(
 
 // ----------------------ListServices-------------------------------
 
+type listServicesParams struct {
+	projectID uuid.UUID
+}
+
+func parseListServicesRequestParams(req *translatev1.ListServicesRequest) (*listServicesParams, error) {
+	projectID, err := uuidFromProto(req.GetProjectId())
+	if err != nil {
+		return nil, fmt.Errorf("parse project_id: %w", err)
+	}
+
+	return &listServicesParams{projectID: projectID}, nil
+}
+
 func (t *TranslateServiceServer) ListServices(
 	ctx context.Context,
-	_ *translatev1.ListServicesRequest,
+	req *translatev1.ListServicesRequest,
 ) (*translatev1.ListServicesResponse, error) {
-	services, err := t.repo.LoadServices(ctx)
+	params, err := parseListServicesRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	services, err := t.repo.LoadServices(ctx, repo.LoadServicesOpts{FilterProjectID: params.projectID})
 	if err != nil {
 		return nil, status.Error(codes.Internal, "")
 	}
@@ -87,6 +105,16 @@ func parseCreateServiceParams(req *translatev1.CreateServiceRequest) (*createSer
 		return nil, fmt.Errorf("parse service: %w", err)
 	}
 
+	projectID, err := uuidFromProto(req.GetProjectId())
+	if err != nil {
+		return nil, fmt.Errorf("parse project_id: %w", err)
+	}
+
+	// The project of the route, e.g. /v1/projects/{project_id}/services, overrides the project of the service.
+	if service != nil && projectID != uuid.Nil {
+		service.ProjectID = projectID
+	}
+
 	return &createServiceParams{service: service}, nil
 }
 
@@ -95,6 +123,10 @@ func (c *createServiceParams) validate() error {
 		return errors.New("'service' is required")
 	}
 
+	if c.service.MonthlyCharacterQuota < 0 {
+		return errors.New("'service.monthly_character_quota' must not be negative")
+	}
+
 	return nil
 }
 
@@ -112,8 +144,19 @@ func (t *TranslateServiceServer) CreateService(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err = t.repo.SaveService(ctx, params.service)
+	err = t.validateTranslatorRouting(&params.service.TranslatorRouting)
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = t.saveService(ctx, params.service, params.service.ProjectID != uuid.Nil)
+
+	switch {
+	case errors.Is(err, errProjectNotFound):
+		return nil, status.Errorf(codes.NotFound, "project '%s' not found", params.service.ProjectID)
+	case errors.Is(err, repo.ErrConflict):
+		return nil, status.Error(codes.Aborted, "project was changed concurrently")
+	case err != nil:
 		return nil, status.Error(codes.Internal, "")
 	}
 
@@ -159,6 +202,10 @@ func (u *updateServiceParams) validate() error {
 		return errors.New("'service.id' is required")
 	}
 
+	if u.service.MonthlyCharacterQuota < 0 {
+		return errors.New("'service.monthly_character_quota' must not be negative")
+	}
+
 	return nil
 }
 
@@ -185,13 +232,33 @@ func (t *TranslateServiceServer) UpdateService(
 		return nil, status.Error(codes.Internal, "")
 	}
 
+	// The service is saved with the loaded version, so that concurrent updates are detected on save.
+	if params.service.Version != 0 && params.service.Version != loadedService.Version {
+		return nil, status.Error(codes.Aborted, "etag mismatch, service was changed")
+	}
+
+	params.service.Version = loadedService.Version
+	projectID := loadedService.ProjectID
+
 	err = model.UpdateService(params.service, loadedService, params.mask)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err = t.repo.SaveService(ctx, loadedService)
+	err = t.validateTranslatorRouting(&loadedService.TranslatorRouting)
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// The project is checked if the service is moved to another project.
+	err = t.saveService(ctx, loadedService, loadedService.ProjectID != projectID)
+
+	switch {
+	case errors.Is(err, errProjectNotFound):
+		return nil, status.Errorf(codes.NotFound, "project '%s' not found", loadedService.ProjectID)
+	case errors.Is(err, repo.ErrConflict):
+		return nil, status.Error(codes.Aborted, "service was changed concurrently")
+	case err != nil:
 		return nil, status.Error(codes.Internal, "")
 	}
 
@@ -272,6 +272,9 @@ func Test_ValidateUpdateServiceParams(t *testing.T) {
 	missingServiceIDParams := randParams()
 	missingServiceIDParams.service.ID = uuid.Nil
 
+	negativeQuotaParams := randParams()
+	negativeQuotaParams.service.MonthlyCharacterQuota = -1
+
 	tests := []struct {
 		params  *updateServiceParams
 		wantErr string
@@ -295,6 +298,11 @@ func Test_ValidateUpdateServiceParams(t *testing.T) {
 			params:  missingServiceIDParams,
 			wantErr: "'service.id' is required",
 		},
+		{
+			name:    "Negative Monthly Character Quota",
+			params:  negativeQuotaParams,
+			wantErr: "'service.monthly_character_quota' must not be negative",
+		},
 	}
 
 	for _, test := range tests {
@@ -457,6 +465,13 @@ func Test_ParseCreateServiceParams(t *testing.T) {
 	malformedServiceIDReq := randReq()
 	malformedServiceIDReq.Service.Id += "_FAIL"
 
+	happyReqWithProjectID := randReq()
+	happyReqWithProjectID.Service.ProjectId = gofakeit.UUID()
+	happyReqWithProjectID.ProjectId = gofakeit.UUID()
+
+	malformedProjectIDReq := randReq()
+	malformedProjectIDReq.ProjectId = "_FAIL"
+
 	tests := []struct {
 		request *translatev1.CreateServiceRequest
 		wantErr string
@@ -490,11 +505,27 @@ func Test_ParseCreateServiceParams(t *testing.T) {
 				service: nil,
 			},
 		},
+		{
+			name:    "Happy Path Project ID overrides service project ID",
+			request: happyReqWithProjectID,
+			want: &createServiceParams{
+				service: &model.Service{
+					ID:        uuid.MustParse(happyReqWithProjectID.GetService().GetId()),
+					Name:      happyReqWithProjectID.GetService().GetName(),
+					ProjectID: uuid.MustParse(happyReqWithProjectID.GetProjectId()),
+				},
+			},
+		},
 		{
 			name:    "Malformed Service ID",
 			request: malformedServiceIDReq,
 			wantErr: "parse service: transform id: parse uuid: invalid UUID length: 41",
 		},
+		{
+			name:    "Malformed Project ID",
+			request: malformedProjectIDReq,
+			wantErr: "parse project_id: parse uuid: invalid UUID length: 5",
+		},
 	}
 
 	for _, test := range tests {
@@ -539,6 +570,9 @@ func Test_ValidateCreateServiceParams(t *testing.T) {
 	emptyServiceParams := randParams()
 	emptyServiceParams.service = nil
 
+	negativeQuotaParams := randParams()
+	negativeQuotaParams.service.MonthlyCharacterQuota = -1
+
 	tests := []struct {
 		params  *createServiceParams
 		wantErr string
@@ -557,6 +591,11 @@ func Test_ValidateCreateServiceParams(t *testing.T) {
 			params:  emptyServiceParams,
 			wantErr: "'service' is required",
 		},
+		{
+			name:    "Negative Monthly Character Quota",
+			params:  negativeQuotaParams,
+			wantErr: "'service.monthly_character_quota' must not be negative",
+		},
 	}
 
 	for _, test := range tests {
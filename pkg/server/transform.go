@@ -1,17 +1,30 @@
 package server
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	"github.com/google/uuid"
 	"go.expect.digital/translate/pkg/model"
 	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
 
 	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ----------------------Common types----------------------
@@ -58,6 +71,29 @@ func languageFromProto(s string) (language.Tag, error) {
 	return l, nil
 }
 
+// etagToProto converts the version of an entity to an etag, empty if the entity was never saved.
+func etagToProto(version int) string {
+	if version == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(version)
+}
+
+// etagFromProto converts an etag to the version of an entity, 0 if the etag is empty.
+func etagFromProto(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(s)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid etag '%s'", s)
+	}
+
+	return version, nil
+}
+
 // sliceToProto converts a slice of type T to a slice of type *R using the provided elementToProto function.
 func sliceToProto[T any, R any](slice []T, elementToProto func(*T) *R) []*R {
 	if len(slice) == 0 {
@@ -102,7 +138,14 @@ func serviceToProto(s *model.Service) *translatev1.Service {
 		return nil
 	}
 
-	return &translatev1.Service{Id: uuidToProto(s.ID), Name: s.Name}
+	return &translatev1.Service{
+		Id:                    uuidToProto(s.ID),
+		Name:                  s.Name,
+		TranslatorRouting:     translatorRoutingToProto(&s.TranslatorRouting),
+		MonthlyCharacterQuota: s.MonthlyCharacterQuota,
+		Etag:                  etagToProto(s.Version),
+		ProjectId:             uuidToProto(s.ProjectID),
+	}
 }
 
 // serviceFromProto converts translatev1.Service to model.Service.
@@ -112,7 +155,7 @@ func serviceFromProto(s *translatev1.Service) (*model.Service, error) {
 	}
 
 	var (
-		service = &model.Service{Name: s.GetName()}
+		service = &model.Service{Name: s.GetName(), MonthlyCharacterQuota: s.GetMonthlyCharacterQuota()}
 		err     error
 	)
 
@@ -121,6 +164,21 @@ func serviceFromProto(s *translatev1.Service) (*model.Service, error) {
 		return nil, fmt.Errorf("transform id: %w", err)
 	}
 
+	service.TranslatorRouting, err = translatorRoutingFromProto(s.GetTranslatorRouting())
+	if err != nil {
+		return nil, fmt.Errorf("transform translator routing: %w", err)
+	}
+
+	service.Version, err = etagFromProto(s.GetEtag())
+	if err != nil {
+		return nil, fmt.Errorf("transform etag: %w", err)
+	}
+
+	service.ProjectID, err = uuidFromProto(s.GetProjectId())
+	if err != nil {
+		return nil, fmt.Errorf("transform project id: %w", err)
+	}
+
 	return service, nil
 }
 
@@ -134,6 +192,87 @@ func servicesFromProto(s []*translatev1.Service) ([]model.Service, error) {
 	return sliceFromProto(s, serviceFromProto)
 }
 
+// projectToProto converts *model.Project to *translatev1.Project.
+func projectToProto(p *model.Project) *translatev1.Project {
+	if p == nil {
+		return nil
+	}
+
+	return &translatev1.Project{Id: uuidToProto(p.ID), Name: p.Name}
+}
+
+// projectFromProto converts *translatev1.Project to *model.Project.
+func projectFromProto(p *translatev1.Project) (*model.Project, error) {
+	if p == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	id, err := uuidFromProto(p.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("transform id: %w", err)
+	}
+
+	return &model.Project{ID: id, Name: p.GetName()}, nil
+}
+
+// projectsToProto converts []model.Project to []*translatev1.Project.
+func projectsToProto(p []model.Project) []*translatev1.Project {
+	return sliceToProto(p, projectToProto)
+}
+
+// translatorRoutingToProto converts *model.TranslatorRouting to *translatev1.TranslatorRouting.
+func translatorRoutingToProto(r *model.TranslatorRouting) *translatev1.TranslatorRouting {
+	if r == nil || len(r.Routes) == 0 {
+		return nil
+	}
+
+	// Any language is represented by an empty string.
+	routeLanguage := func(l language.Tag) string {
+		if l == language.Und {
+			return ""
+		}
+
+		return languageToProto(l)
+	}
+
+	routes := make([]*translatev1.TranslatorRoute, 0, len(r.Routes))
+
+	for _, route := range r.Routes {
+		routes = append(routes, &translatev1.TranslatorRoute{
+			Source:     routeLanguage(route.Source),
+			Target:     routeLanguage(route.Target),
+			Translator: route.Translator,
+		})
+	}
+
+	return &translatev1.TranslatorRouting{Routes: routes}
+}
+
+// translatorRoutingFromProto converts *translatev1.TranslatorRouting to model.TranslatorRouting.
+func translatorRoutingFromProto(r *translatev1.TranslatorRouting) (model.TranslatorRouting, error) {
+	var routing model.TranslatorRouting
+
+	for i, route := range r.GetRoutes() {
+		source, err := languageFromProto(route.GetSource())
+		if err != nil {
+			return model.TranslatorRouting{}, fmt.Errorf("route #%d: source: %w", i, err)
+		}
+
+		target, err := languageFromProto(route.GetTarget())
+		if err != nil {
+			return model.TranslatorRouting{}, fmt.Errorf("route #%d: target: %w", i, err)
+		}
+
+		routing.Routes = append(routing.Routes, model.TranslatorRoute{
+			Source:     source,
+			Target:     target,
+			Translator: route.GetTranslator(),
+		})
+	}
+
+	return routing, nil
+}
+
 // ----------------------Message----------------------
 
 // messageToProto converts *model.Message to *translatev1.Message.
@@ -143,11 +282,13 @@ func messageToProto(m *model.Message) *translatev1.Message {
 	}
 
 	return &translatev1.Message{
-		Id:          m.ID,
-		Message:     m.Message,
-		Description: m.Description,
-		Status:      translatev1.Message_Status(m.Status),
-		Positions:   m.Positions,
+		Id:           m.ID,
+		Message:      m.Message,
+		Description:  m.Description,
+		Status:       translatev1.Message_Status(m.Status),
+		Positions:    m.Positions,
+		StatusReason: m.StatusReason,
+		QualityScore: m.QualityScore,
 	}
 }
 
@@ -158,11 +299,13 @@ func messageFromProto(m *translatev1.Message) (*model.Message, error) {
 	}
 
 	return &model.Message{
-		ID:          m.GetId(),
-		Message:     m.GetMessage(),
-		Description: m.GetDescription(),
-		Status:      model.MessageStatus(m.GetStatus()),
-		Positions:   m.GetPositions(),
+		ID:           m.GetId(),
+		Message:      m.GetMessage(),
+		Description:  m.GetDescription(),
+		Status:       model.MessageStatus(m.GetStatus()),
+		StatusReason: m.GetStatusReason(),
+		Positions:    m.GetPositions(),
+		QualityScore: m.QualityScore,
 	}, nil
 }
 
@@ -176,6 +319,73 @@ func messagesFromProto(m []*translatev1.Message) ([]model.Message, error) {
 	return sliceFromProto(m, messageFromProto)
 }
 
+// ----------------------MessageRevision----------------------
+
+// messageRevisionToProto converts *model.MessageRevision to *translatev1.MessageRevision.
+func messageRevisionToProto(r *model.MessageRevision) *translatev1.MessageRevision {
+	if r == nil {
+		return nil
+	}
+
+	revision := &translatev1.MessageRevision{
+		Id:         uuidToProto(r.ID),
+		NewMessage: r.New.Message,
+		NewStatus:  translatev1.Message_Status(r.New.Status),
+		Actor:      r.Actor,
+		CreateTime: timestamppb.New(r.CreatedAt),
+	}
+
+	if r.Old != nil {
+		revision.OldMessage = &r.Old.Message
+		revision.OldStatus = translatev1.Message_Status(r.Old.Status).Enum()
+	}
+
+	return revision
+}
+
+// messageRevisionsToProto converts []model.MessageRevision to []*translatev1.MessageRevision.
+func messageRevisionsToProto(r []model.MessageRevision) []*translatev1.MessageRevision {
+	return sliceToProto(r, messageRevisionToProto)
+}
+
+// ----------------------Search----------------------
+
+// highlightsToProto converts the byte spans of the text to []*translatev1.Highlight in Unicode code points.
+func highlightsToProto(text string, spans []model.TextSpan) []*translatev1.Highlight {
+	highlights := make([]*translatev1.Highlight, 0, len(spans))
+
+	for _, span := range spans {
+		start := utf8.RuneCountInString(text[:span.Start])
+
+		highlights = append(highlights, &translatev1.Highlight{
+			Start: int32(start),                                                     //nolint:gosec
+			End:   int32(start + utf8.RuneCountInString(text[span.Start:span.End])), //nolint:gosec
+		})
+	}
+
+	return highlights
+}
+
+// searchResultsToProto converts []model.SearchResult to []*translatev1.SearchMessagesResponse_Result,
+// the words starting with the lowercase terms are highlighted.
+func searchResultsToProto(results []model.SearchResult, terms []string) []*translatev1.SearchMessagesResponse_Result {
+	v := make([]*translatev1.SearchMessagesResponse_Result, 0, len(results))
+
+	for i := range results {
+		msg := &results[i].Message
+
+		v = append(v, &translatev1.SearchMessagesResponse_Result{
+			ServiceId:         uuidToProto(results[i].ServiceID),
+			Language:          languageToProto(results[i].Language),
+			Message:           messageToProto(msg),
+			IdHighlights:      highlightsToProto(msg.ID, model.SearchHighlights(msg.ID, terms)),
+			MessageHighlights: highlightsToProto(msg.Message, model.SearchHighlights(msg.Message, terms)),
+		})
+	}
+
+	return v
+}
+
 // ----------------------Translation----------------------
 
 // translationToProto converts *model.Translation to *translatev1.Translation.
@@ -188,6 +398,7 @@ func translationToProto(t *model.Translation) *translatev1.Translation {
 		Language: languageToProto(t.Language),
 		Original: t.Original,
 		Messages: messagesToProto(t.Messages),
+		Etag:     etagToProto(t.Version),
 	}
 }
 
@@ -212,6 +423,11 @@ func translationFromProto(t *translatev1.Translation) (*model.Translation, error
 		return nil, fmt.Errorf("transform translation: %w", err)
 	}
 
+	translation.Version, err = etagFromProto(t.GetEtag())
+	if err != nil {
+		return nil, fmt.Errorf("transform etag: %w", err)
+	}
+
 	return translation, nil
 }
 
@@ -220,6 +436,184 @@ func translationsToProto(m []model.Translation) []*translatev1.Translation {
 	return sliceToProto(m, translationToProto)
 }
 
+// ----------------------Job----------------------
+
+// jobOperationPrefix prefixes the job ID in google.longrunning.Operation name.
+const jobOperationPrefix = "jobs/"
+
+// jobStatusToProto converts model.JobStatus to translatev1.JobMetadata_State.
+func jobStatusToProto(s model.JobStatus) translatev1.JobMetadata_State {
+	switch s {
+	default:
+		return translatev1.JobMetadata_PENDING
+	case model.JobStatusRunning:
+		return translatev1.JobMetadata_RUNNING
+	case model.JobStatusSucceeded:
+		return translatev1.JobMetadata_SUCCEEDED
+	case model.JobStatusFailed:
+		return translatev1.JobMetadata_FAILED
+	}
+}
+
+// jobToProto converts *model.Job to *longrunningpb.Operation with translatev1.JobMetadata as metadata.
+func jobToProto(j *model.Job) (*longrunningpb.Operation, error) {
+	if j == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	languages := make([]string, 0, len(j.Languages))
+	for _, lang := range j.Languages {
+		languages = append(languages, languageToProto(lang))
+	}
+
+	metadata, err := anypb.New(&translatev1.JobMetadata{
+		ServiceId:  uuidToProto(j.ServiceID),
+		Languages:  languages,
+		State:      jobStatusToProto(j.Status),
+		Total:      int32(j.Total), //nolint:gosec
+		Done:       int32(j.Done),  //nolint:gosec
+		CreateTime: timestamppb.New(j.CreatedAt),
+		UpdateTime: timestamppb.New(j.UpdatedAt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal job metadata: %w", err)
+	}
+
+	op := &longrunningpb.Operation{
+		Name:     jobOperationPrefix + uuidToProto(j.ID),
+		Metadata: metadata,
+		Done:     j.Finished(),
+	}
+
+	switch j.Status { //nolint:exhaustive
+	case model.JobStatusSucceeded:
+		response, anyErr := anypb.New(&emptypb.Empty{})
+		if anyErr != nil {
+			return nil, fmt.Errorf("marshal job response: %w", anyErr)
+		}
+
+		op.Result = &longrunningpb.Operation_Response{Response: response}
+	case model.JobStatusFailed:
+		op.Result = &longrunningpb.Operation_Error{Error: status.New(codes.Unknown, j.Error).Proto()}
+	}
+
+	return op, nil
+}
+
+// jobsToProto converts []model.Job to []*longrunningpb.Operation.
+func jobsToProto(j []model.Job) ([]*longrunningpb.Operation, error) {
+	ops := make([]*longrunningpb.Operation, 0, len(j))
+
+	for i := range j {
+		op, err := jobToProto(&j[i])
+		if err != nil {
+			return nil, err
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
+// ----------------------Usage----------------------
+
+// usageToProto converts []model.Usage to *translatev1.GetUsageResponse with usage totals.
+func usageToProto(u []model.Usage) *translatev1.GetUsageResponse {
+	resp := &translatev1.GetUsageResponse{Usage: make([]*translatev1.Usage, 0, len(u))}
+
+	for _, usage := range u {
+		resp.Usage = append(resp.Usage, &translatev1.Usage{
+			Date:       timestamppb.New(usage.Date),
+			Translator: usage.Translator,
+			Characters: usage.Characters,
+			Requests:   usage.Requests,
+		})
+
+		resp.TotalCharacters += usage.Characters
+		resp.TotalRequests += usage.Requests
+	}
+
+	return resp
+}
+
+// ----------------------Webhook----------------------
+
+// webhookToProto converts *model.Webhook to *translatev1.Webhook, the secret is never returned.
+func webhookToProto(w *model.Webhook) *translatev1.Webhook {
+	if w == nil {
+		return nil
+	}
+
+	webhook := &translatev1.Webhook{
+		Id:         uuidToProto(w.ID),
+		Url:        w.URL,
+		Events:     make([]translatev1.Webhook_Event, 0, len(w.Events)),
+		CreateTime: timestamppb.New(w.CreatedAt),
+	}
+
+	for _, event := range w.Events {
+		webhook.Events = append(webhook.Events, translatev1.Webhook_Event(event))
+	}
+
+	return webhook
+}
+
+// webhookFromProto converts *translatev1.Webhook to *model.Webhook.
+func webhookFromProto(w *translatev1.Webhook) (*model.Webhook, error) {
+	if w == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	webhook := &model.Webhook{
+		URL:    w.GetUrl(),
+		Secret: w.GetSecret(),
+		Events: make([]model.WebhookEvent, 0, len(w.GetEvents())),
+	}
+
+	for _, event := range w.GetEvents() {
+		if _, ok := translatev1.Webhook_Event_name[int32(event)]; !ok {
+			return nil, fmt.Errorf("transform event: unknown event %d", event)
+		}
+
+		if !slices.Contains(webhook.Events, model.WebhookEvent(event)) {
+			webhook.Events = append(webhook.Events, model.WebhookEvent(event))
+		}
+	}
+
+	return webhook, nil
+}
+
+// webhooksToProto converts []model.Webhook to []*translatev1.Webhook.
+func webhooksToProto(w []model.Webhook) []*translatev1.Webhook {
+	return sliceToProto(w, webhookToProto)
+}
+
+// webhookDeliveryToProto converts *model.WebhookDelivery to *translatev1.WebhookDelivery.
+func webhookDeliveryToProto(d *model.WebhookDelivery) *translatev1.WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+
+	return &translatev1.WebhookDelivery{
+		Id:             uuidToProto(d.ID),
+		WebhookId:      uuidToProto(d.WebhookID),
+		Event:          translatev1.Webhook_Event(d.Event),
+		Payload:        d.Payload,
+		State:          translatev1.WebhookDelivery_State(d.Status),
+		Attempts:       int32(d.Attempts),       //nolint:gosec
+		ResponseStatus: int32(d.ResponseStatus), //nolint:gosec
+		Error:          d.Error,
+		CreateTime:     timestamppb.New(d.CreatedAt),
+		UpdateTime:     timestamppb.New(d.UpdatedAt),
+	}
+}
+
+// webhookDeliveriesToProto converts []model.WebhookDelivery to []*translatev1.WebhookDelivery.
+func webhookDeliveriesToProto(d []model.WebhookDelivery) []*translatev1.WebhookDelivery {
+	return sliceToProto(d, webhookDeliveryToProto)
+}
+
 // ----------------------Mask----------------------
 
 // maskFromProto parses the field mask from the request and
@@ -262,3 +656,158 @@ func maskFromProto(message proto.Message, mask *fieldmaskpb.FieldMask) (model.Ma
 
 	return model.Mask(protoPaths), nil
 }
+
+// readMask is a tree of the fields kept in a response, a nil subtree keeps the whole field.
+type readMask map[protoreflect.Name]readMask
+
+// readMaskFromProto parses the read mask of the message from the request.
+// Unlike update masks, paths may select fields of repeated messages, e.g. "messages.id".
+// It fails on following scenarios:
+//   - mask is not nil, but empty (0 paths)
+//   - mask contains paths, that does not exist in the proto.message
+func readMaskFromProto(message proto.Message, mask *fieldmaskpb.FieldMask) (readMask, error) {
+	if mask == nil {
+		return nil, nil
+	}
+
+	if len(mask.GetPaths()) == 0 {
+		return nil, errors.New("field mask must contain at least 1 path")
+	}
+
+	m := readMask{}
+
+	for _, path := range mask.GetPaths() {
+		err := m.add(message.ProtoReflect().Descriptor(), strings.Split(path, "."))
+		if err != nil {
+			return nil, fmt.Errorf("invalid path '%s': %w", path, err)
+		}
+	}
+
+	return m, nil
+}
+
+// add adds the path of field names of the message to the mask.
+func (m readMask) add(md protoreflect.MessageDescriptor, names []string) error {
+	fd := md.Fields().ByName(protoreflect.Name(names[0]))
+	if fd == nil {
+		return fmt.Errorf("no field '%s' in '%s'", names[0], md.FullName())
+	}
+
+	if len(names) == 1 {
+		m[fd.Name()] = nil // keep the whole field, e.g. "messages" covers "messages.id"
+		return nil
+	}
+
+	if fd.Message() == nil || fd.IsMap() {
+		return fmt.Errorf("field '%s' in '%s' has no fields", fd.Name(), md.FullName())
+	}
+
+	sub, ok := m[fd.Name()]
+
+	switch {
+	case !ok:
+		sub = readMask{}
+		m[fd.Name()] = sub
+	case sub == nil: // the whole field is kept, only validate the rest of the path
+		sub = readMask{}
+	}
+
+	return sub.add(fd.Message(), names[1:])
+}
+
+// apply clears the fields of the message not kept by the mask.
+func (m readMask) apply(msg protoreflect.Message) {
+	var cleared []protoreflect.FieldDescriptor
+
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		sub, ok := m[fd.Name()]
+
+		switch {
+		case !ok:
+			cleared = append(cleared, fd)
+		case sub == nil: // keep the whole field
+		case fd.IsList():
+			for i := range v.List().Len() {
+				sub.apply(v.List().Get(i).Message())
+			}
+		default:
+			sub.apply(v.Message())
+		}
+
+		return true
+	})
+
+	for _, fd := range cleared {
+		msg.Clear(fd)
+	}
+}
+
+// ----------------------Page token----------------------
+
+// pageTokenToProto encodes the key of the last message on a page as an opaque page token.
+func pageTokenToProto(key repo.MessageKey) string {
+	// Language tags never contain a colon, message IDs may.
+	return base64.RawURLEncoding.EncodeToString([]byte(key.Language.String() + ":" + key.ID))
+}
+
+// pageTokenFromProto decodes the key of the last message on the previous page from a page token.
+func pageTokenFromProto(token string) (repo.MessageKey, error) {
+	if token == "" {
+		return repo.MessageKey{}, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return repo.MessageKey{}, fmt.Errorf("decode page token: %w", err)
+	}
+
+	lang, id, ok := strings.Cut(string(b), ":")
+	if !ok {
+		return repo.MessageKey{}, errors.New("invalid page token")
+	}
+
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return repo.MessageKey{}, fmt.Errorf("parse page token language: %w", err)
+	}
+
+	return repo.MessageKey{Language: tag, ID: id}, nil
+}
+
+// jobPageTokenToProto encodes the key of the last job on a page as an opaque page token.
+func jobPageTokenToProto(key repo.JobKey) string {
+	token := key.CreatedAt.UTC().Format(time.RFC3339Nano) + " " + key.ID.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+// jobPageTokenFromProto decodes the key of the last job on the previous page from a page token.
+func jobPageTokenFromProto(token string) (repo.JobKey, error) {
+	if token == "" {
+		return repo.JobKey{}, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return repo.JobKey{}, fmt.Errorf("decode page token: %w", err)
+	}
+
+	createdAt, id, ok := strings.Cut(string(b), " ")
+	if !ok {
+		return repo.JobKey{}, errors.New("invalid page token")
+	}
+
+	var key repo.JobKey
+
+	key.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return repo.JobKey{}, fmt.Errorf("parse page token time: %w", err)
+	}
+
+	key.ID, err = uuid.Parse(id)
+	if err != nil {
+		return repo.JobKey{}, fmt.Errorf("parse page token job ID: %w", err)
+	}
+
+	return key, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"go.expect.digital/translate/pkg/model"
 	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	testutilrand "go.expect.digital/translate/pkg/testutil/rand"
 	"golang.org/x/text/language"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
@@ -91,9 +92,28 @@ func Test_TransformLanguage(t *testing.T) {
 func Test_TransformService(t *testing.T) {
 	t.Parallel()
 
+	// Generate valid services, language.Tag can not be generated by testing/quick.
+	randService := func() model.Service {
+		if gofakeit.Bool() {
+			return *testutilrand.ModelService()
+		}
+
+		return *testutilrand.ModelService(
+			testutilrand.WithTranslatorRouting("GoogleTranslate", model.TranslatorNone),
+			testutilrand.WithProjectID(uuid.New()),
+		)
+	}
+
 	t.Run("Service to proto to Service", func(t *testing.T) {
 		t.Parallel()
 
+		conf := &quick.Config{
+			MaxCount: 1000,
+			Values: func(values []reflect.Value, _ *rand.Rand) {
+				values[0] = reflect.ValueOf(randService())
+			},
+		}
+
 		f := func(wantService model.Service) bool {
 			restoredService, err := serviceFromProto(serviceToProto(&wantService))
 			if err != nil {
@@ -101,10 +121,10 @@ func Test_TransformService(t *testing.T) {
 				return false
 			}
 
-			return wantService == *restoredService
+			return reflect.DeepEqual(wantService, *restoredService)
 		}
 
-		err := quick.Check(f, &quick.Config{MaxCount: 1000})
+		err := quick.Check(f, conf)
 		if err != nil {
 			t.Error(err)
 		}
@@ -113,6 +133,18 @@ func Test_TransformService(t *testing.T) {
 	t.Run("Services to proto to services", func(t *testing.T) {
 		t.Parallel()
 
+		conf := &quick.Config{
+			MaxCount: 100,
+			Values: func(values []reflect.Value, r *rand.Rand) {
+				services := make([]model.Service, r.Intn(10)) //nolint:mnd
+				for i := range services {
+					services[i] = randService()
+				}
+
+				values[0] = reflect.ValueOf(services)
+			},
+		}
+
 		f := func(wantServices []model.Service) bool {
 			restoredServices, err := servicesFromProto(servicesToProto(wantServices))
 			if err != nil {
@@ -128,7 +160,7 @@ func Test_TransformService(t *testing.T) {
 			return true
 		}
 
-		err := quick.Check(f, &quick.Config{MaxCount: 100})
+		err := quick.Check(f, conf)
 		if err != nil {
 			t.Error(err)
 		}
@@ -210,3 +242,82 @@ func Test_maskFromProto(t *testing.T) {
 		})
 	}
 }
+
+func Test_readMaskFromProto(t *testing.T) {
+	t.Parallel()
+
+	translation := func() *translatev1.Translation {
+		return &translatev1.Translation{
+			Language: "lv",
+			Original: true,
+			Messages: []*translatev1.Message{{Id: "1", Message: "Viens", Description: "One"}},
+		}
+	}
+
+	tests := []struct {
+		want    *translatev1.Translation
+		name    string
+		paths   []string
+		wantErr bool
+	}{
+		{
+			name:  "Fields",
+			paths: []string{"language", "original"},
+			want:  &translatev1.Translation{Language: "lv", Original: true},
+		},
+		{
+			name:  "Fields of repeated messages",
+			paths: []string{"language", "messages.id", "messages.message"},
+			want: &translatev1.Translation{
+				Language: "lv",
+				Messages: []*translatev1.Message{{Id: "1", Message: "Viens"}},
+			},
+		},
+		{
+			name:  "Whole field covers its fields",
+			paths: []string{"messages.id", "messages"},
+			want:  &translatev1.Translation{Messages: translation().GetMessages()},
+		},
+		{
+			name:    "Unknown field",
+			paths:   []string{"messages.unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "Field of scalar",
+			paths:   []string{"language.id"},
+			wantErr: true,
+		},
+		{
+			name:    "Empty mask",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mask, err := readMaskFromProto(&translatev1.Translation{}, &fieldmaskpb.FieldMask{Paths: test.paths})
+			if test.wantErr {
+				if err == nil {
+					t.Error("want error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			got := translation()
+			mask.apply(got.ProtoReflect())
+
+			if !proto.Equal(test.want, got) {
+				t.Errorf("\nwant %v\ngot  %v", test.want, got)
+			}
+		})
+	}
+}
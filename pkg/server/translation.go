@@ -4,26 +4,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/fuzzy"
 	"go.expect.digital/translate/pkg/model"
 	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
 	"go.expect.digital/translate/pkg/repo"
 	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // ----------------------CreateTranslation-------------------------------
 
 type createTranslationParams struct {
-	translation *model.Translation
-	serviceID   uuid.UUID
+	translation     *model.Translation
+	serviceID       uuid.UUID
+	pseudoTranslate bool
 }
 
 func parseCreateTranslationRequestParams(req *translatev1.CreateTranslationRequest) (*createTranslationParams, error) {
 	var (
-		p   = &createTranslationParams{}
+		p   = &createTranslationParams{pseudoTranslate: req.GetPseudoTranslate()}
 		err error
 	)
 
@@ -88,19 +94,64 @@ func (t *TranslateServiceServer) CreateTranslation(
 		}
 	default: // Translate messages when translation is not original and original language is known.
 		if origIdx := all.OriginalIndex(); origIdx != -1 {
-			targetLanguage := params.translation.Language
-			params.translation.Language = all[origIdx].Language
+			service, loadErr := t.repo.LoadService(ctx, params.serviceID)
+
+			switch {
+			case errors.Is(loadErr, repo.ErrNotFound):
+				return nil, status.Error(codes.NotFound, "service not found")
+			case loadErr != nil:
+				return nil, status.Error(codes.Internal, "")
+			}
+
+			translator, translatorName, translatorErr := t.translatorFor(
+				service, all[origIdx].Language, params.translation.Language)
+
+			// The request selects the pseudo translator regardless of the service translator routing.
+			if params.pseudoTranslate {
+				translator, translatorName, translatorErr = t.pseudoTranslator, fuzzy.Name(t.pseudoTranslator), nil
+				if translator == nil {
+					translatorErr = errors.New("pseudo translator is not available")
+				}
+			}
+
+			if translatorErr != nil {
+				return nil, status.Error(codes.FailedPrecondition, translatorErr.Error())
+			}
 
 			// if incoming translation is empty populate with original translation.
 			if params.translation.Messages == nil {
 				params.translation.Messages = all[origIdx].Messages
 			}
 
-			// Translate messages -
+			// Translate messages, unless machine translation is disabled for the language pair -
 			// untranslated text in incoming translation will be translated from original to target language.
-			params.translation, err = t.translator.Translate(ctx, params.translation, targetLanguage)
-			if err != nil {
-				return nil, status.Error(codes.Unknown, err.Error()) // TODO(Darja): For now we don't know the cause of the error.
+			if translator != nil {
+				targetLanguage := params.translation.Language
+				params.translation.Language = all[origIdx].Language
+
+				// Keep the source messages for the quality check, translators may modify the input.
+				source := &model.Translation{
+					Language: params.translation.Language,
+					Messages: slices.Clone(params.translation.Messages),
+				}
+
+				params.translation, err = t.meteredTranslate(ctx, service, translator, translatorName,
+					params.translation, targetLanguage)
+
+				switch {
+				case errors.Is(err, errQuotaExceeded):
+					return nil, status.Error(codes.ResourceExhausted, err.Error())
+				case errors.Is(err, errUsageConflict):
+					return nil, status.Error(codes.Aborted, err.Error())
+				case err != nil:
+					return nil, status.Error(codes.Unknown, err.Error()) // TODO(Darja): For now we don't know the cause of the error.
+				}
+
+				// The quality check is optional, failing it must not fail the translation.
+				if qualityErr := t.scoreQuality(ctx, service, translator, translatorName,
+					source, params.translation); qualityErr != nil {
+					log.Printf("score quality of '%s' translation: %v", targetLanguage, qualityErr)
+				}
 			}
 		}
 	}
@@ -117,7 +168,13 @@ func (t *TranslateServiceServer) CreateTranslation(
 
 // ----------------------ListTranslations-------------------------------
 
+// maxPageSize is the max number of messages returned on a page, larger page sizes are coerced to it.
+const maxPageSize = 1000
+
 type listTranslationsParams struct {
+	readMask  readMask
+	opts      repo.LoadTranslationsOpts
+	pageSize  int
 	serviceID uuid.UUID
 }
 
@@ -127,7 +184,46 @@ func parseListTranslationsRequestParams(req *translatev1.ListTranslationsRequest
 		return nil, fmt.Errorf("parse service_id: %w", err)
 	}
 
-	return &listTranslationsParams{serviceID: serviceID}, nil
+	params := &listTranslationsParams{
+		serviceID: serviceID,
+		pageSize:  int(req.GetPageSize()),
+		opts: repo.LoadTranslationsOpts{
+			FilterOriginal:        req.GetOriginalOnly(),
+			FilterMessageIDs:      req.GetMessageIds(),
+			FilterMessageIDPrefix: req.GetMessageIdPrefix(),
+			FilterText:            req.GetText(),
+		},
+	}
+
+	if req.MaxQualityScore != nil {
+		maxQualityScore := req.GetMaxQualityScore()
+		params.opts.FilterMaxQualityScore = &maxQualityScore
+	}
+
+	for _, s := range req.GetLanguages() {
+		lang, langErr := languageFromProto(s)
+		if langErr != nil {
+			return nil, fmt.Errorf("parse languages: %w", langErr)
+		}
+
+		params.opts.FilterLanguages = append(params.opts.FilterLanguages, lang)
+	}
+
+	for _, s := range req.GetStatuses() {
+		params.opts.FilterStatuses = append(params.opts.FilterStatuses, model.MessageStatus(s))
+	}
+
+	params.opts.After, err = pageTokenFromProto(req.GetPageToken())
+	if err != nil {
+		return nil, fmt.Errorf("parse page_token: %w", err)
+	}
+
+	params.readMask, err = readMaskFromProto(&translatev1.Translation{}, req.GetReadMask())
+	if err != nil {
+		return nil, fmt.Errorf("parse read_mask: %w", err)
+	}
+
+	return params, nil
 }
 
 func (l *listTranslationsParams) validate() error {
@@ -135,6 +231,19 @@ func (l *listTranslationsParams) validate() error {
 		return errors.New("'service_id' is required")
 	}
 
+	if l.opts.FilterMaxQualityScore != nil &&
+		(*l.opts.FilterMaxQualityScore < 0 || *l.opts.FilterMaxQualityScore > 1) {
+		return errors.New("'max_quality_score' must be in range [0, 1]")
+	}
+
+	if l.pageSize < 0 {
+		return errors.New("'page_size' must not be negative")
+	}
+
+	if l.pageSize == 0 && l.opts.After != (repo.MessageKey{}) {
+		return errors.New("'page_size' is required with 'page_token'")
+	}
+
 	return nil
 }
 
@@ -152,12 +261,72 @@ func (t *TranslateServiceServer) ListTranslations(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	translations, err := t.repo.LoadTranslations(ctx, params.serviceID, repo.LoadTranslationsOpts{})
+	pageSize := min(params.pageSize, maxPageSize)
+
+	// Load a message more than the page size to know if there is a next page.
+	if pageSize > 0 {
+		params.opts.Limit = pageSize + 1
+	}
+
+	translations, err := t.repo.LoadTranslations(ctx, params.serviceID, params.opts)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "")
 	}
 
-	return &translatev1.ListTranslationsResponse{Translations: translationsToProto(translations)}, nil
+	var nextPageToken string
+
+	if pageSize > 0 {
+		nextPageToken = paginate(translations, pageSize)
+	}
+
+	resp := &translatev1.ListTranslationsResponse{
+		Translations:  translationsToProto(translations),
+		NextPageToken: nextPageToken,
+	}
+
+	if params.readMask != nil {
+		for _, translation := range resp.GetTranslations() {
+			params.readMask.apply(translation.ProtoReflect())
+		}
+	}
+
+	return resp, nil
+}
+
+// paginate sorts the translations by language and drops the message loaded beyond the page size.
+// Returns the token of the next page, empty if all messages fit on the page.
+func paginate(translations model.Translations, pageSize int) string {
+	slices.SortFunc(translations, func(a, b model.Translation) int {
+		return strings.Compare(a.Language.String(), b.Language.String())
+	})
+
+	var count int
+
+	for _, translation := range translations {
+		count += len(translation.Messages)
+	}
+
+	if count <= pageSize {
+		return ""
+	}
+
+	dropped := false
+
+	for i := len(translations) - 1; i >= 0; i-- {
+		messages := translations[i].Messages
+
+		if !dropped && len(messages) > 0 {
+			messages = messages[:len(messages)-1]
+			translations[i].Messages = messages
+			dropped = true
+		}
+
+		if len(messages) > 0 {
+			return pageTokenToProto(repo.MessageKey{Language: translations[i].Language, ID: messages[len(messages)-1].ID})
+		}
+	}
+
+	return ""
 }
 
 // ----------------------UpdateTranslation-------------------------------
@@ -227,6 +396,17 @@ func (t *TranslateServiceServer) UpdateTranslation(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	translation, err := t.updateTranslation(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return translationToProto(translation), nil
+}
+
+// updateTranslation updates the translation as UpdateTranslation, the returned error is a gRPC status error.
+func (t *TranslateServiceServer) updateTranslation(ctx context.Context, params *updateTranslationParams,
+) (*model.Translation, error) {
 	all, err := t.repo.LoadTranslations(ctx, params.serviceID, repo.LoadTranslationsOpts{})
 	if err != nil {
 		return nil, status.Error(codes.Internal, "")
@@ -236,8 +416,22 @@ func (t *TranslateServiceServer) UpdateTranslation(
 		return nil, status.Errorf(codes.NotFound, "no translation for language: '%s'", params.translation.Language)
 	}
 
+	// The translations are saved with the loaded versions, so that concurrent updates are detected on save.
+	langIdx := all.LanguageIndex(params.translation.Language)
+	version := all[langIdx].Version
+
+	if params.translation.Version != 0 && params.translation.Version != version {
+		return nil, status.Errorf(codes.Aborted, "etag mismatch, translation for language '%s' was changed",
+			params.translation.Language)
+	}
+
+	params.translation.Version = version
+
 	origIdx := all.OriginalIndex()
 
+	// Only the incoming translation is saved, unless changes to the original translation affect the others.
+	affected := all[langIdx : langIdx+1]
+
 	switch {
 	default:
 		// Original translation is not affected, changes will not affect other translations - update incoming translation.
@@ -264,96 +458,237 @@ func (t *TranslateServiceServer) UpdateTranslation(
 			params.mask,
 		)
 
+		// Delete or mark obsolete messages removed from the original translation.
+		t.removeMessages(all, &oldOriginal)
+
 		// Add missing messages for all translations.
 		if params.populateTranslations {
 			all.PopulateTranslations()
 		}
 
-		err = t.fuzzyTranslate(ctx, all)
-		if err != nil {
-			return nil, status.Error(codes.Internal, "")
-		}
+		affected = all
 	}
 
-	// Update affected translations
+	var job *model.Job
+
+	// Update affected translations, untranslated messages are fuzzy translated asynchronously by a job.
 	err = t.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
-		for i := range all {
-			inErr := r.SaveTranslation(ctx, params.serviceID, &all[i])
+		for i := range affected {
+			inErr := r.SaveTranslation(ctx, params.serviceID, &affected[i])
 			if inErr != nil {
 				return fmt.Errorf("save translation: %w", inErr)
 			}
 		}
 
+		if params.translation.Original {
+			var inErr error
+
+			job, inErr = enqueueJob(ctx, r, params.serviceID, all)
+			if inErr != nil {
+				return fmt.Errorf("enqueue job: %w", inErr)
+			}
+		}
+
 		return nil
 	})
-	if err != nil {
+
+	switch {
+	case errors.Is(err, repo.ErrConflict):
+		return nil, status.Error(codes.Aborted, "translations were changed concurrently")
+	case err != nil:
 		return nil, status.Error(codes.Internal, "")
 	}
 
-	return translationToProto(&all[all.LanguageIndex(params.translation.Language)]), nil
+	if job != nil {
+		t.notifyJobs()
+	}
+
+	return &all[langIdx], nil
 }
 
-// helpers
+// ----------------------DeleteTranslation-------------------------------
 
-// fuzzyTranslate fuzzy translates any untranslated messages,
-// returns translations containing refreshed messages.
-//
-// TODO: This logic should be moved to fuzzy pkg.
-func (t *TranslateServiceServer) fuzzyTranslate(
+// errOriginalHasTranslations is returned when deleting the original translation while other translations exist.
+var errOriginalHasTranslations = errors.New("original translation has other translations")
+
+type deleteTranslationParams struct {
+	language  language.Tag
+	serviceID uuid.UUID
+}
+
+func parseDeleteTranslationRequestParams(req *translatev1.DeleteTranslationRequest) (*deleteTranslationParams, error) {
+	var (
+		params = deleteTranslationParams{}
+		err    error
+	)
+
+	params.serviceID, err = uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	params.language, err = languageFromProto(req.GetLanguage())
+	if err != nil {
+		return nil, fmt.Errorf("parse language: %w", err)
+	}
+
+	return &params, nil
+}
+
+func (d *deleteTranslationParams) validate() error {
+	if d.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if d.language == language.Und {
+		return errors.New("'language' is required")
+	}
+
+	return nil
+}
+
+// DeleteTranslation deletes the translation with its messages.
+// The original translation is the source of the other translations,
+// it can be deleted only when no other translations exist.
+func (t *TranslateServiceServer) DeleteTranslation(
 	ctx context.Context,
-	all model.Translations,
-) error {
-	origIdx := all.OriginalIndex()
-	if origIdx == -1 {
-		return errors.New("original translation not found")
+	req *translatev1.DeleteTranslationRequest,
+) (*emptypb.Empty, error) {
+	params, err := parseDeleteTranslationRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	origMsgLookup := make(map[string]string, len(all[origIdx].Messages))
-	for _, msg := range all[origIdx].Messages {
-		origMsgLookup[msg.ID] = msg.Message
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	for i := range all {
-		// Skip original translation
-		if i == origIdx {
-			continue
+	// The translations are checked in the same transaction, so that no translation is added to the service
+	// while its original translation is deleted.
+	err = t.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		all, inErr := r.LoadTranslations(ctx, params.serviceID, repo.LoadTranslationsOpts{})
+		if inErr != nil {
+			return fmt.Errorf("load translations: %w", inErr)
 		}
 
-		// Create a map to store pointers to untranslated messages
-		untranslatedMessagesLookup := make(map[string]*model.Message)
-
-		// Iterate over the messages and add any untranslated message to the untranslated messages lookup
-		for j := range all[i].Messages {
-			if all[i].Messages[j].Status == model.MessageStatusUntranslated {
-				all[i].Messages[j].Message = origMsgLookup[all[i].Messages[j].ID]
-				untranslatedMessagesLookup[all[i].Messages[j].ID] = &all[i].Messages[j]
-			}
+		idx := all.LanguageIndex(params.language)
+		if idx == -1 {
+			return repo.ErrNotFound
 		}
 
-		// Create a new translation to store the messages that need to be translated
-		toBeTranslated := &model.Translation{
-			Language: all[origIdx].Language,
-			Messages: make([]model.Message, 0, len(untranslatedMessagesLookup)),
+		if all[idx].Original && len(all) > 1 {
+			return errOriginalHasTranslations
 		}
 
-		for _, msg := range untranslatedMessagesLookup {
-			toBeTranslated.Messages = append(toBeTranslated.Messages, *msg)
+		inErr = r.DeleteTranslation(ctx, params.serviceID, params.language)
+		if inErr != nil {
+			return fmt.Errorf("delete translation: %w", inErr)
 		}
 
-		// Translate messages -
-		// untranslated messages in toBeTranslated will be translated from original to target language.
-		targetLanguage := all[i].Language
+		return nil
+	})
+
+	switch {
+	default:
+		return &emptypb.Empty{}, nil
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "no translation for language: '%s'", params.language)
+	case errors.Is(err, errOriginalHasTranslations):
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"original translation cannot be deleted while other translations exist for service: '%s'", params.serviceID)
+	case errors.Is(err, repo.ErrConflict):
+		return nil, status.Error(codes.Aborted, "translations were changed concurrently")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+}
+
+// helpers
+
+// removeMessages deletes messages of the previous original translation missing in the updated original translation
+// from all translations, or marks them as obsolete, see WithMarkObsolete.
+func (t *TranslateServiceServer) removeMessages(all model.Translations, previousOriginal *model.Translation) {
+	removed := previousOriginal.FindRemovedMessages(&all[all.OriginalIndex()])
+
+	if t.markObsolete {
+		all.MarkObsolete(removed)
+		return
+	}
+
+	ids := make([]string, 0, len(removed))
+	for _, msg := range removed {
+		ids = append(ids, msg.ID)
+	}
+
+	all.DeleteMessages(ids)
+}
+
+// fuzzyTranslateLanguage fuzzy translates untranslated messages of a single translation
+// from the original language, overwriting them in place.
+// The translator is selected by the service translator routing.
+func (t *TranslateServiceServer) fuzzyTranslateLanguage(
+	ctx context.Context,
+	service *model.Service,
+	originalLanguage language.Tag,
+	origMsgLookup map[string]string,
+	translation *model.Translation,
+) error {
+	translator, translatorName, err := t.translatorFor(service, originalLanguage, translation.Language)
+	if err != nil {
+		return fmt.Errorf("translator for '%s': %w", translation.Language, err)
+	}
+
+	// Machine translation is disabled for the language pair, messages stay untranslated.
+	if translator == nil {
+		return nil
+	}
+
+	// Create a new translation to store the messages that need to be translated,
+	// keeping the order of the messages in the translation so that results are deterministic.
+	toBeTranslated := &model.Translation{Language: originalLanguage}
 
-		translated, err := t.translator.Translate(ctx, toBeTranslated, targetLanguage)
-		if err != nil {
-			return fmt.Errorf("translator translate messages: %w", err)
+	// Store indexes of untranslated messages
+	var untranslatedIndexes []int
+
+	// Iterate over the messages and add any untranslated message to the translation to be translated
+	for j := range translation.Messages {
+		if translation.Messages[j].Status == model.MessageStatusUntranslated {
+			translation.Messages[j].Message = origMsgLookup[translation.Messages[j].ID]
+			toBeTranslated.Messages = append(toBeTranslated.Messages, translation.Messages[j])
+			untranslatedIndexes = append(untranslatedIndexes, j)
 		}
+	}
 
-		// Overwrite untranslated messages with translated messages
-		for _, translatedMessage := range translated.Messages {
-			if untranslatedMessage, ok := untranslatedMessagesLookup[translatedMessage.ID]; ok {
-				*untranslatedMessage = translatedMessage
-			}
+	if len(untranslatedIndexes) == 0 {
+		return nil
+	}
+
+	// Keep the source messages for the quality check, translators may modify the input.
+	source := &model.Translation{Language: originalLanguage, Messages: slices.Clone(toBeTranslated.Messages)}
+
+	// Translate messages -
+	// untranslated messages in toBeTranslated will be translated from original to target language.
+	translated, err := t.meteredTranslate(ctx, service, translator, translatorName, toBeTranslated, translation.Language)
+	if err != nil {
+		return fmt.Errorf("translator translate messages to '%s': %w", translation.Language, err)
+	}
+
+	// The quality check is optional, failing it must not fail the translation.
+	err = t.scoreQuality(ctx, service, translator, translatorName, source, translated)
+	if err != nil {
+		log.Printf("score quality of '%s' translation: %v", translation.Language, err)
+	}
+
+	// Overwrite untranslated messages with translated messages
+	translatedLookup := make(map[string]model.Message, len(translated.Messages))
+	for _, msg := range translated.Messages {
+		translatedLookup[msg.ID] = msg
+	}
+
+	for _, j := range untranslatedIndexes {
+		if msg, ok := translatedLookup[translation.Messages[j].ID]; ok {
+			translation.Messages[j] = msg
 		}
 	}
 
@@ -2,23 +2,37 @@ package server
 
 import (
 	"context"
-	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"go.expect.digital/translate/pkg/fuzzy"
 	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
 	"go.expect.digital/translate/pkg/testutil/rand"
 	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 const mockTranslation = "{Translated}"
 
+// mockTranslator translates each message in a separate request to the provider, see fuzzy.MeterRequest.
 type mockTranslator struct{}
 
-func (m *mockTranslator) Translate(_ context.Context,
+func (m *mockTranslator) Translate(ctx context.Context,
 	translation *model.Translation,
 	targetLanguage language.Tag,
 ) (*model.Translation, error) {
+	for _, msg := range translation.Messages {
+		err := fuzzy.MeterRequest(ctx, msg.Message)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
 	newTranslation := &model.Translation{
 		Language: targetLanguage,
 		Messages: make([]model.Message, 0, len(translation.Messages)),
@@ -35,72 +49,6 @@ func (m *mockTranslator) Translate(_ context.Context,
 	return newTranslation, nil
 }
 
-//nolint:gocognit
-func Test_fuzzyTranslate(t *testing.T) {
-	t.Parallel()
-
-	translateSrv := NewTranslateServiceServer(nil, &mockTranslator{})
-	originalTranslation1 := randOriginalTranslation(3)
-	originalTranslation2 := randOriginalTranslation(10)
-
-	tests := []struct {
-		name                string
-		originalTranslation *model.Translation
-		translations        []model.Translation
-	}{
-		{
-			name:                "Fuzzy translate untranslated messages for one translation",
-			originalTranslation: originalTranslation1,
-			translations:        randTranslations(1, 3, originalTranslation1),
-		},
-		{
-			name:                "Fuzzy translate untranslated messages for five translations",
-			originalTranslation: originalTranslation2,
-			translations:        randTranslations(5, 5, originalTranslation2),
-		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			t.Parallel()
-
-			allTranslations := append(model.Translations{*test.originalTranslation}, test.translations...)
-			untranslatedMessageIDLookup := randomUntranslatedMessageStatus(t, allTranslations)
-
-			err := translateSrv.fuzzyTranslate(t.Context(), allTranslations)
-			if err != nil {
-				t.Error(err)
-				return
-			}
-
-			// Check that untranslated messages have been translated and marked as fuzzy for all translations.
-			for _, translation := range allTranslations {
-				if translation.Original {
-					if !reflect.DeepEqual(*test.originalTranslation, translation) {
-						t.Errorf("\nwant %v\ngot  %v", *test.originalTranslation, translation)
-					}
-
-					continue
-				}
-
-				for _, message := range translation.Messages {
-					if _, ok := untranslatedMessageIDLookup[message.ID]; ok {
-						if mockTranslation != message.Message {
-							t.Errorf("want message '%s', got '%s'", mockTranslation, message.Message)
-						}
-
-						if model.MessageStatusFuzzy != message.Status {
-							t.Errorf("want message status '%d', got '%d'", model.MessageStatusFuzzy, message.Status)
-						}
-					} else if model.MessageStatusTranslated != message.Status {
-						t.Errorf("want message status '%d', got '%d'", model.MessageStatusTranslated, message.Status)
-					}
-				}
-			}
-		})
-	}
-}
-
 // helpers
 
 // randOriginalTranslation creates a random translation with the original flag set to true.
@@ -167,3 +115,307 @@ func randomUntranslatedMessageStatus(t *testing.T, translations model.Translatio
 
 	return untranslatedMessageIDLookup
 }
+
+func Test_CreateTranslationPseudoTranslate(t *testing.T) {
+	t.Parallel()
+
+	pseudoTranslator, err := fuzzy.NewPseudoTranslate()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	r := newInMemoryRepo(t)
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err = r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = r.SaveTranslation(ctx, service.ID, &model.Translation{
+		Language: language.English,
+		Original: true,
+		Messages: []model.Message{{ID: "greeting", Message: "Hello", Status: model.MessageStatusTranslated}},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// The translation is created by the last step, run sequentially.
+	tests := []struct {
+		opts     []TranslateServiceServerOption
+		name     string
+		wantCode codes.Code
+	}{
+		{
+			name:     "No pseudo translator",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "Pseudo translator",
+			opts:     []TranslateServiceServerOption{WithPseudoTranslator(pseudoTranslator)},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, test := range tests {
+		translateSrv := NewTranslateServiceServer(r, &mockTranslator{}, test.opts...)
+
+		// A regular locale is pseudo translated instead of using the service translator.
+		translation, err := translateSrv.CreateTranslation(ctx, &translatev1.CreateTranslationRequest{
+			ServiceId:       service.ID.String(),
+			Translation:     &translatev1.Translation{Language: language.German.String()},
+			PseudoTranslate: true,
+		})
+		if status.Code(err) != test.wantCode {
+			t.Errorf("%s: want status '%s', got '%s'", test.name, test.wantCode, status.Code(err))
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		msg := translation.GetMessages()[0]
+		if msg.GetMessage() == "Hello" || msg.GetMessage() == mockTranslation ||
+			msg.GetStatus() != translatev1.Message_FUZZY {
+			t.Errorf("%s: want pseudo translated fuzzy message, got %v", test.name, msg)
+		}
+	}
+}
+
+func Test_UpdateTranslationVersions(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	original := &model.Translation{
+		Language: language.English,
+		Original: true,
+		Messages: []model.Message{{ID: "greeting", Message: "Hello", Status: model.MessageStatusTranslated}},
+	}
+
+	translation := &model.Translation{
+		Language: language.Latvian,
+		Messages: []model.Message{{ID: "greeting", Message: "Sveiki", Status: model.MessageStatusTranslated}},
+	}
+
+	for _, translation := range []*model.Translation{original, translation} {
+		err = r.SaveTranslation(ctx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// Updating a translation other than the original does not change the other translations.
+	translation.Messages[0].Message = "Labdien"
+
+	resp, err := translateSrv.UpdateTranslation(ctx, &translatev1.UpdateTranslationRequest{
+		ServiceId:   service.ID.String(),
+		Translation: translationToProto(translation),
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"messages"}},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if resp.GetEtag() != "2" {
+		t.Errorf("want updated translation etag '2', got '%s'", resp.GetEtag())
+	}
+
+	translations, err := r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{
+		FilterLanguages: []language.Tag{language.English},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if translations[0].Version != 1 {
+		t.Errorf("want original translation version 1, got %d", translations[0].Version)
+	}
+}
+
+func Test_DeleteTranslation(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, translation := range []*model.Translation{
+		{Language: language.English, Original: true},
+		{Language: language.Latvian},
+	} {
+		err = r.SaveTranslation(ctx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// Steps depend on the previous ones, run sequentially.
+	tests := []struct {
+		language language.Tag
+		name     string
+		wantCode codes.Code
+	}{
+		{
+			name:     "Original with other translations",
+			language: language.English,
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "Translation",
+			language: language.Latvian,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "Deleted translation",
+			language: language.Latvian,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Last original",
+			language: language.English,
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := translateSrv.DeleteTranslation(ctx, &translatev1.DeleteTranslationRequest{
+			ServiceId: service.ID.String(),
+			Language:  test.language.String(),
+		})
+		if status.Code(err) != test.wantCode {
+			t.Errorf("%s: want status '%s', got '%s'", test.name, test.wantCode, status.Code(err))
+		}
+	}
+
+	translations, err := r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(translations) != 0 {
+		t.Errorf("want no translations, got %d", len(translations))
+	}
+}
+
+func Test_ListTranslationsPagination(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var want []string // language and message ID of all messages in page order
+
+	for _, translation := range []*model.Translation{
+		rand.ModelTranslation(5, nil, rand.WithLanguage(language.Latvian), rand.WithOriginal(true)),
+		rand.ModelTranslation(4, nil, rand.WithLanguage(language.German), rand.WithOriginal(false)),
+	} {
+		err = r.SaveTranslation(ctx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		for _, msg := range translation.Messages {
+			want = append(want, translation.Language.String()+":"+msg.ID)
+		}
+	}
+
+	slices.Sort(want)
+
+	var (
+		got       []string
+		pageToken string
+		pages     int
+	)
+
+	for {
+		resp, err := translateSrv.ListTranslations(ctx, &translatev1.ListTranslationsRequest{
+			ServiceId: service.ID.String(),
+			PageSize:  4,
+			PageToken: pageToken,
+			ReadMask:  &fieldmaskpb.FieldMask{Paths: []string{"language", "messages.id"}},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		pages++
+
+		if len(resp.GetTranslations()) != 2 {
+			t.Errorf("page %d: want all translations, got %d", pages, len(resp.GetTranslations()))
+		}
+
+		for _, translation := range resp.GetTranslations() {
+			for _, msg := range translation.GetMessages() {
+				if msg.GetMessage() != "" {
+					t.Errorf("want message text masked, got '%s'", msg.GetMessage())
+				}
+
+				got = append(got, translation.GetLanguage()+":"+msg.GetId())
+			}
+		}
+
+		pageToken = resp.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	if pages != 3 {
+		t.Errorf("want 3 pages, got %d", pages)
+	}
+
+	if !slices.Equal(want, got) {
+		t.Errorf("\nwant %v\ngot  %v", want, got)
+	}
+
+	_, err = translateSrv.ListTranslations(ctx, &translatev1.ListTranslationsRequest{
+		ServiceId: service.ID.String(),
+		PageSize:  4,
+		PageToken: "invalid",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want code %s for invalid page token, got %v", codes.InvalidArgument, err)
+	}
+}
@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/fuzzy"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// usageMaxAttempts is the number of transactions tried before adding the usage fails with errUsageConflict.
+	usageMaxAttempts = 5
+	// usageRetryBackoff is the delay before the first retry of adding the usage, doubled for every next retry,
+	// with up to the same random jitter.
+	usageRetryBackoff = 10 * time.Millisecond
+)
+
+var (
+	// errQuotaExceeded is returned when machine translation would exceed the monthly character quota of a service.
+	errQuotaExceeded = errors.New("monthly character quota exceeded")
+	// errUsageConflict is returned when the usage could not be added as it was changed concurrently by every attempt.
+	errUsageConflict = errors.New("usage changed concurrently, try again")
+)
+
+// meteredTranslate translates the translation to the target language, recording the characters
+// and the requests sent to the translation provider as the service usage, see fuzzy.WithMeter.
+// A request is not sent, and errQuotaExceeded is returned, if it would exceed the service monthly character quota.
+// Usage is not metered without a service, or for unnamed translators and pseudo-localization, which are free.
+func (t *TranslateServiceServer) meteredTranslate(
+	ctx context.Context,
+	service *model.Service,
+	translator fuzzy.Translator,
+	translatorName string,
+	translation *model.Translation,
+	targetLanguage language.Tag,
+) (*model.Translation, error) {
+	if _, pseudo := translator.(*fuzzy.PseudoTranslate); service == nil || translatorName == "" || pseudo {
+		return translator.Translate(ctx, translation, targetLanguage) //nolint:wrapcheck
+	}
+
+	ctx = fuzzy.WithMeter(ctx, func(ctx context.Context, characters int64) error {
+		return t.addUsage(ctx, service, translatorName, characters)
+	})
+
+	translated, err := translator.Translate(ctx, translation, targetLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("translate: %w", err)
+	}
+
+	return translated, nil
+}
+
+// addUsage records a request to the translator with the characters as the service usage.
+// The quota is checked in the same transaction, so that concurrent requests can not exceed it together.
+// The transaction is retried with backoff if the usage was added concurrently, errUsageConflict is returned
+// after usageMaxAttempts.
+func (t *TranslateServiceServer) addUsage(
+	ctx context.Context,
+	service *model.Service,
+	translatorName string,
+	characters int64,
+) error {
+	for attempt := range usageMaxAttempts {
+		if attempt > 0 {
+			backoff := usageRetryBackoff << (attempt - 1)
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("add usage: %w", ctx.Err())
+			case <-time.After(backoff + rand.N(backoff)):
+			}
+		}
+
+		err := t.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+			err := checkQuota(ctx, r, service, characters)
+			if err != nil {
+				return err
+			}
+
+			err = r.AddUsage(ctx, &model.Usage{
+				ServiceID:  service.ID,
+				Date:       time.Now(),
+				Translator: translatorName,
+				Characters: characters,
+				Requests:   1,
+			})
+			if err != nil {
+				return fmt.Errorf("add usage: %w", err)
+			}
+
+			return nil
+		})
+
+		switch {
+		case errors.Is(err, repo.ErrConflict):
+			continue // Usage was added concurrently, check the quota again.
+		case err != nil:
+			return fmt.Errorf("repo tx: %w", err)
+		default:
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w for service '%s'", errUsageConflict, service.ID)
+}
+
+// checkQuota returns errQuotaExceeded if translating the characters would exceed
+// the service character quota for the current month.
+func checkQuota(ctx context.Context, r repo.Repo, service *model.Service, characters int64) error {
+	if service.MonthlyCharacterQuota <= 0 {
+		return nil
+	}
+
+	err := r.LockUsage(ctx, service.ID)
+	if err != nil {
+		return fmt.Errorf("lock usage: %w", err)
+	}
+
+	usage, err := r.LoadUsage(ctx, service.ID, repo.LoadUsageOpts{FilterFrom: model.MonthStart(time.Now())})
+	if err != nil {
+		return fmt.Errorf("load usage: %w", err)
+	}
+
+	var used int64
+
+	for _, u := range usage {
+		used += u.Characters
+	}
+
+	if used+characters > service.MonthlyCharacterQuota {
+		return fmt.Errorf("%w for service '%s': %d of %d characters used, %d more requested",
+			errQuotaExceeded, service.ID, used, service.MonthlyCharacterQuota, characters)
+	}
+
+	return nil
+}
+
+// ----------------------GetUsage-------------------------------
+
+type getUsageParams struct {
+	startTime time.Time
+	endTime   time.Time
+	serviceID uuid.UUID
+}
+
+func parseGetUsageRequestParams(req *translatev1.GetUsageRequest) (*getUsageParams, error) {
+	serviceID, err := uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	params := &getUsageParams{serviceID: serviceID}
+
+	if req.GetStartTime() != nil {
+		params.startTime = req.GetStartTime().AsTime()
+	}
+
+	if req.GetEndTime() != nil {
+		params.endTime = req.GetEndTime().AsTime()
+	}
+
+	return params, nil
+}
+
+func (g *getUsageParams) validate() error {
+	if g.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if !g.startTime.IsZero() && !g.endTime.IsZero() && !g.startTime.Before(g.endTime) {
+		return errors.New("'start_time' must be before 'end_time'")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) GetUsage(
+	ctx context.Context,
+	req *translatev1.GetUsageRequest,
+) (*translatev1.GetUsageResponse, error) {
+	params, err := parseGetUsageRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = t.repo.LoadService(ctx, params.serviceID)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "service not found")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	// Default to the current month, as monthly quotas.
+	if params.startTime.IsZero() {
+		params.startTime = model.MonthStart(time.Now())
+	}
+
+	usage, err := t.repo.LoadUsage(ctx, params.serviceID,
+		repo.LoadUsageOpts{FilterFrom: params.startTime, FilterTo: params.endTime})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return usageToProto(usage), nil
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"unicode/utf8"
+
+	"go.expect.digital/translate/pkg/fuzzy"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_meteredTranslate(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{},
+		WithTranslators(map[string]fuzzy.Translator{"Mock": &mockTranslator{}}))
+	ctx := t.Context()
+
+	service := rand.ModelService()
+	service.TranslatorRouting = model.TranslatorRouting{
+		Routes: []model.TranslatorRoute{{Source: language.Und, Target: language.Und, Translator: "Mock"}},
+	}
+
+	translation := randOriginalTranslation(3)
+
+	var wantCharacters int64
+
+	for _, msg := range translation.Messages {
+		wantCharacters += int64(utf8.RuneCountInString(msg.Message))
+	}
+
+	// Two translations fit into the quota.
+	service.MonthlyCharacterQuota = 2 * wantCharacters
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for range 2 {
+		_, err = translateSrv.meteredTranslate(ctx, service, &mockTranslator{}, "Mock", translation, language.German)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	resp, err := translateSrv.GetUsage(ctx, &translatev1.GetUsageRequest{ServiceId: service.ID.String()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(resp.GetUsage()) != 1 || resp.GetUsage()[0].GetTranslator() != "Mock" {
+		t.Errorf("want usage of translator 'Mock', got %v", resp.GetUsage())
+	}
+
+	// A request per message.
+	wantRequests := int64(2 * len(translation.Messages))
+
+	if resp.GetTotalCharacters() != 2*wantCharacters || resp.GetTotalRequests() != wantRequests {
+		t.Errorf("want %d characters in %d requests, got %d characters in %d requests",
+			2*wantCharacters, wantRequests, resp.GetTotalCharacters(), resp.GetTotalRequests())
+	}
+
+	// Third translation exceeds the quota, nothing is sent to the provider.
+	_, err = translateSrv.meteredTranslate(ctx, service, &mockTranslator{}, "Mock", translation, language.German)
+	if !errors.Is(err, errQuotaExceeded) {
+		t.Errorf("want error '%v', got '%v'", errQuotaExceeded, err)
+	}
+
+	resp, err = translateSrv.GetUsage(ctx, &translatev1.GetUsageRequest{ServiceId: service.ID.String()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if resp.GetTotalRequests() != wantRequests {
+		t.Errorf("want %d requests after exceeding the quota, got %d", wantRequests, resp.GetTotalRequests())
+	}
+
+	// Quota is enforced on machine translation when creating a translation.
+	err = r.SaveTranslation(ctx, service.ID, translation)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = translateSrv.CreateTranslation(ctx, &translatev1.CreateTranslationRequest{
+		ServiceId:   service.ID.String(),
+		Translation: &translatev1.Translation{Language: language.French.String()},
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("want status '%s', got '%s'", codes.ResourceExhausted, status.Code(err))
+	}
+}
+
+func Test_meteredTranslateNotMetered(t *testing.T) {
+	t.Parallel()
+
+	pseudoTranslator, err := fuzzy.NewPseudoTranslate()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{}, WithPseudoTranslator(pseudoTranslator))
+	ctx := t.Context()
+
+	service := rand.ModelService()
+	service.MonthlyCharacterQuota = 1
+
+	tests := []struct {
+		targetLanguage language.Tag
+		translator     fuzzy.Translator
+		name           string
+		translatorName string
+	}{
+		{
+			name:           "Unnamed translator",
+			translator:     &mockTranslator{},
+			targetLanguage: language.German,
+		},
+		{
+			name:           "Pseudo translator",
+			translator:     pseudoTranslator,
+			translatorName: "PseudoTranslate",
+			targetLanguage: language.MustParse("en-XA"),
+		},
+	}
+
+	for _, test := range tests {
+		_, err = translateSrv.meteredTranslate(ctx, service, test.translator, test.translatorName,
+			randOriginalTranslation(3), test.targetLanguage)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+	}
+
+	usage, err := r.LoadUsage(ctx, service.ID, repo.LoadUsageOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(usage) != 0 {
+		t.Errorf("want no usage, got %v", usage)
+	}
+}
+
+// conflictRepo is a repository whose transactions always conflict.
+type conflictRepo struct {
+	repo.Repo
+}
+
+func (r *conflictRepo) Tx(context.Context, func(context.Context, repo.Repo) error) error {
+	return fmt.Errorf("repo: commit: %w", repo.ErrConflict)
+}
+
+func Test_addUsageConflict(t *testing.T) {
+	t.Parallel()
+
+	translateSrv := NewTranslateServiceServer(&conflictRepo{Repo: newInMemoryRepo(t)}, &mockTranslator{})
+
+	service := rand.ModelService()
+	service.MonthlyCharacterQuota = 100
+
+	err := translateSrv.addUsage(t.Context(), service, "Mock", 10)
+	if !errors.Is(err, errUsageConflict) {
+		t.Errorf("want error '%v', got '%v'", errUsageConflict, err)
+	}
+}
@@ -0,0 +1,561 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventBufferSize is the number of recent events kept for resuming watches.
+const eventBufferSize = 1000
+
+// errEventsExpired is returned when the events after a resume token are no longer kept.
+var errEventsExpired = errors.New("events expired")
+
+// messageEvent is a change of a message of a service translation.
+type messageEvent struct {
+	language  language.Tag
+	message   model.Message
+	seq       uint64 // sequence number assigned by eventBus, starting at 1
+	batch     uint64 // sequence number of the first event published with the event, see eventBus.publish
+	serviceID uuid.UUID
+	kind      translatev1.TranslationEvent_Type
+	// oldStatus is the status of the message before an UPDATED or STATUS_CHANGED event.
+	oldStatus model.MessageStatus
+	original  bool // original reports whether the message is of the original translation
+	// completed reports whether the change completed the translation, see translationCompleted.
+	completed bool
+}
+
+// eventBus keeps the recent message events and notifies the watchers of new events.
+type eventBus struct {
+	// watchers are notified of new events, a notification is dropped if the watcher is already notified.
+	watchers map[chan struct{}]struct{}
+	// epoch identifies the bus in resume tokens, tokens of a restarted server are rejected.
+	epoch  string
+	events []messageEvent // recent events, oldest first
+	mu     sync.Mutex
+	seq    uint64 // sequence number of the last event
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		watchers: make(map[chan struct{}]struct{}),
+		epoch:    strconv.FormatUint(uint64(uuid.New().ID()), 36),
+	}
+}
+
+// publish assigns sequence numbers to the events of a transaction and notifies the watchers.
+func (b *eventBus) publish(events []messageEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch := b.seq + 1
+
+	for _, event := range events {
+		b.seq++
+		event.seq = b.seq
+		event.batch = batch
+		b.events = append(b.events, event)
+	}
+
+	if n := len(b.events) - eventBufferSize; n > 0 {
+		b.events = append(b.events[:0:0], b.events[n:]...)
+	}
+
+	for watcher := range b.watchers {
+		select {
+		case watcher <- struct{}{}:
+		default: // watcher is already notified
+		}
+	}
+}
+
+// watch returns the channel notified of new events, the sequence number of the last event,
+// and the function to stop watching.
+func (b *eventBus) watch() (<-chan struct{}, uint64, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	watcher := make(chan struct{}, 1)
+	b.watchers[watcher] = struct{}{}
+
+	return watcher, b.seq, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.watchers, watcher)
+	}
+}
+
+// after returns the events after the sequence number,
+// errEventsExpired if some of the events are no longer kept.
+func (b *eventBus) after(seq uint64) ([]messageEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if seq > b.seq {
+		return nil, errEventsExpired
+	}
+
+	// Events are numbered sequentially, the events after seq are the last b.seq-seq events.
+	n := int(b.seq - seq) //nolint:gosec
+	if n > len(b.events) {
+		return nil, errEventsExpired
+	}
+
+	return append([]messageEvent(nil), b.events[len(b.events)-n:]...), nil
+}
+
+// resumeToken returns the token to resume watching after the event.
+func (b *eventBus) resumeToken(event *messageEvent) string {
+	return b.epoch + "." + strconv.FormatUint(event.seq, 10)
+}
+
+// parseResumeToken returns the sequence number of the resume token.
+func (b *eventBus) parseResumeToken(token string) (uint64, error) {
+	epoch, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, fmt.Errorf("invalid resume token '%s'", token)
+	}
+
+	seq, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid resume token '%s'", token)
+	}
+
+	if epoch != b.epoch {
+		return 0, errEventsExpired
+	}
+
+	return seq, nil
+}
+
+// diffMessages returns the events of the messages changed from the previous to the current translation,
+// either of them is nil if the translation is created or deleted.
+// Of the current messages with the same ID, the last one is compared, as the last one is stored.
+func diffMessages(serviceID uuid.UUID, previous, current *model.Translation) []messageEvent {
+	var (
+		events            []messageEvent
+		prevMsgs, curMsgs []model.Message
+		translation       = current
+		completed         = current != nil && translationCompleted(current) &&
+			(previous == nil || !translationCompleted(previous))
+	)
+
+	if previous != nil {
+		prevMsgs = previous.Messages
+	}
+
+	if current != nil {
+		curMsgs = current.Messages
+	} else {
+		translation = previous
+	}
+
+	event := func(kind translatev1.TranslationEvent_Type, message model.Message, oldStatus model.MessageStatus) {
+		events = append(events, messageEvent{
+			serviceID: serviceID,
+			language:  translation.Language,
+			original:  translation.Original,
+			completed: completed,
+			kind:      kind,
+			message:   message,
+			oldStatus: oldStatus,
+		})
+	}
+
+	lookup := make(map[string]model.Message, len(prevMsgs))
+	for _, message := range prevMsgs {
+		lookup[message.ID] = message
+	}
+
+	last := make(map[string]int, len(curMsgs))
+	for i, message := range curMsgs {
+		last[message.ID] = i
+	}
+
+	for i, message := range curMsgs {
+		if last[message.ID] != i {
+			continue
+		}
+
+		old, ok := lookup[message.ID]
+
+		switch {
+		case !ok:
+			event(translatev1.TranslationEvent_CREATED, message, message.Status)
+		case !equalMessages(old, message):
+			event(translatev1.TranslationEvent_UPDATED, message, old.Status)
+		case old.Status != message.Status:
+			event(translatev1.TranslationEvent_STATUS_CHANGED, message, old.Status)
+		}
+	}
+
+	for _, message := range prevMsgs {
+		if _, ok := last[message.ID]; !ok {
+			event(translatev1.TranslationEvent_DELETED, message, message.Status)
+		}
+	}
+
+	return events
+}
+
+// equalMessages reports whether the messages are equal, ignoring the status.
+func equalMessages(a, b model.Message) bool {
+	return a.ID == b.ID &&
+		a.PluralID == b.PluralID &&
+		a.Message == b.Message &&
+		a.Description == b.Description &&
+		a.StatusReason == b.StatusReason &&
+		slices.Equal(a.Positions, b.Positions) &&
+		(a.QualityScore == nil) == (b.QualityScore == nil) &&
+		(a.QualityScore == nil || *a.QualityScore == *b.QualityScore)
+}
+
+// eventRepo publishes the message events of the saved and deleted translations after each successful transaction.
+// The webhook deliveries of the events are saved in the transaction, see enqueueEventWebhooks.
+type eventRepo struct {
+	repo.Repo
+
+	bus *eventBus
+	// events are the events of the current transaction, nil outside a transaction.
+	events *[]messageEvent
+	// notifyWebhooks wakes up the webhook worker after deliveries are saved.
+	notifyWebhooks func()
+}
+
+func (r *eventRepo) Tx(ctx context.Context, fn func(ctx context.Context, r repo.Repo) error) error {
+	// Nested transactions are part of the outer transaction, the events are published with it.
+	if r.events != nil {
+		return r.Repo.Tx(ctx, func(ctx context.Context, tx repo.Repo) error {
+			return fn(ctx, &eventRepo{Repo: tx, bus: r.bus, events: r.events, notifyWebhooks: r.notifyWebhooks})
+		})
+	}
+
+	var (
+		events   []messageEvent
+		enqueued bool
+	)
+
+	err := r.Repo.Tx(ctx, func(ctx context.Context, tx repo.Repo) error {
+		events = nil // the transaction might be retried
+
+		err := fn(ctx, &eventRepo{Repo: tx, bus: r.bus, events: &events, notifyWebhooks: r.notifyWebhooks})
+		if err != nil {
+			return err
+		}
+
+		// The deliveries are saved before the commit, as the events are kept in memory only.
+		enqueued, err = enqueueEventWebhooks(ctx, tx, events)
+		if err != nil {
+			return fmt.Errorf("enqueue webhooks: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	r.bus.publish(events)
+
+	if enqueued {
+		r.notifyWebhooks()
+	}
+
+	return nil
+}
+
+func (r *eventRepo) SaveTranslation(ctx context.Context, serviceID uuid.UUID, translation *model.Translation) error {
+	if r.events == nil {
+		return r.Tx(ctx, func(ctx context.Context, tx repo.Repo) error {
+			return tx.SaveTranslation(ctx, serviceID, translation)
+		})
+	}
+
+	previous, err := r.loadTranslation(ctx, serviceID, translation.Language)
+	if err != nil {
+		return err
+	}
+
+	err = r.Repo.SaveTranslation(ctx, serviceID, translation)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	*r.events = append(*r.events, diffMessages(serviceID, previous, translation)...)
+
+	return nil
+}
+
+func (r *eventRepo) SaveMessage(ctx context.Context, serviceID uuid.UUID, lang language.Tag, message *model.Message,
+) error {
+	if r.events == nil {
+		return r.Tx(ctx, func(ctx context.Context, tx repo.Repo) error {
+			return tx.SaveMessage(ctx, serviceID, lang, message)
+		})
+	}
+
+	// Only the saved message is loaded, the other messages are not changed.
+	translations, err := r.Repo.LoadTranslations(ctx, serviceID, repo.LoadTranslationsOpts{
+		FilterLanguages:  []language.Tag{lang},
+		FilterMessageIDs: []string{message.ID},
+	})
+	if err != nil {
+		return fmt.Errorf("load message: %w", err)
+	}
+
+	err = r.Repo.SaveMessage(ctx, serviceID, lang, message)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if len(translations) == 0 {
+		return nil // not reached, SaveMessage returns ErrNotFound for a missing translation
+	}
+
+	previous := translations[0]
+	current := previous
+	current.Messages = []model.Message{*message}
+
+	events := diffMessages(serviceID, &previous, &current)
+
+	// The whole translation is loaded only if the message might have completed it.
+	completed := !current.Original && message.Status == model.MessageStatusTranslated &&
+		(len(previous.Messages) == 0 || previous.Messages[0].Status != model.MessageStatusTranslated)
+
+	if completed {
+		translation, err := r.loadTranslation(ctx, serviceID, lang)
+		if err != nil {
+			return err
+		}
+
+		completed = translation != nil && translationCompleted(translation)
+	}
+
+	for i := range events {
+		events[i].completed = completed
+	}
+
+	*r.events = append(*r.events, events...)
+
+	return nil
+}
+
+func (r *eventRepo) DeleteTranslation(ctx context.Context, serviceID uuid.UUID, lang language.Tag) error {
+	if r.events == nil {
+		return r.Tx(ctx, func(ctx context.Context, tx repo.Repo) error {
+			return tx.DeleteTranslation(ctx, serviceID, lang)
+		})
+	}
+
+	previous, err := r.loadTranslation(ctx, serviceID, lang)
+	if err != nil {
+		return err
+	}
+
+	err = r.Repo.DeleteTranslation(ctx, serviceID, lang)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if previous != nil {
+		*r.events = append(*r.events, diffMessages(serviceID, previous, nil)...)
+	}
+
+	return nil
+}
+
+func (r *eventRepo) DeleteService(ctx context.Context, serviceID uuid.UUID) error {
+	if r.events == nil {
+		return r.Tx(ctx, func(ctx context.Context, tx repo.Repo) error {
+			return tx.DeleteService(ctx, serviceID)
+		})
+	}
+
+	translations, err := r.Repo.LoadTranslations(ctx, serviceID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		return fmt.Errorf("load translations: %w", err)
+	}
+
+	err = r.Repo.DeleteService(ctx, serviceID)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for i := range translations {
+		*r.events = append(*r.events, diffMessages(serviceID, &translations[i], nil)...)
+	}
+
+	return nil
+}
+
+// loadTranslation returns the service translation, nil if the translation does not exist.
+func (r *eventRepo) loadTranslation(ctx context.Context, serviceID uuid.UUID, lang language.Tag,
+) (*model.Translation, error) {
+	translations, err := r.Repo.LoadTranslations(ctx, serviceID,
+		repo.LoadTranslationsOpts{FilterLanguages: []language.Tag{lang}})
+	if err != nil {
+		return nil, fmt.Errorf("load translation: %w", err)
+	}
+
+	if len(translations) == 0 {
+		return nil, nil
+	}
+
+	return &translations[0], nil
+}
+
+// ----------------------WatchTranslations-------------------------------
+
+type watchTranslationsParams struct {
+	resumeToken string
+	languages   []language.Tag
+	serviceID   uuid.UUID
+}
+
+func parseWatchTranslationsRequestParams(req *translatev1.WatchTranslationsRequest,
+) (*watchTranslationsParams, error) {
+	var (
+		params = watchTranslationsParams{resumeToken: req.GetResumeToken()}
+		err    error
+	)
+
+	params.serviceID, err = uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	for _, s := range req.GetLanguages() {
+		lang, err := languageFromProto(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse languages: %w", err)
+		}
+
+		params.languages = append(params.languages, lang)
+	}
+
+	return &params, nil
+}
+
+func (w *watchTranslationsParams) validate() error {
+	if w.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	return nil
+}
+
+// match reports whether the event is watched.
+func (w *watchTranslationsParams) match(event *messageEvent) bool {
+	if event.serviceID != w.serviceID {
+		return false
+	}
+
+	if len(w.languages) == 0 {
+		return true
+	}
+
+	for _, lang := range w.languages {
+		if lang == event.language {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TranslateServiceServer) WatchTranslations(
+	req *translatev1.WatchTranslationsRequest,
+	stream translatev1.TranslateService_WatchTranslationsServer,
+) error {
+	params, err := parseWatchTranslationsRequestParams(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := stream.Context()
+
+	_, err = t.repo.LoadService(ctx, params.serviceID)
+
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		return status.Error(codes.NotFound, "service not found")
+	case err != nil:
+		return status.Error(codes.Internal, "")
+	}
+
+	// Watch before reading the events, so that no event is missed.
+	notify, seq, stop := t.events.watch()
+	defer stop()
+
+	if params.resumeToken != "" {
+		seq, err = t.events.parseResumeToken(params.resumeToken)
+
+		switch {
+		case errors.Is(err, errEventsExpired):
+			return status.Error(codes.OutOfRange, "resume token expired")
+		case err != nil:
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	// Headers are sent once watching, a client can wait for the headers before making changes.
+	err = stream.SendHeader(nil)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for {
+		events, err := t.events.after(seq)
+		if err != nil {
+			return status.Error(codes.OutOfRange, "resume token expired")
+		}
+
+		for i := range events {
+			seq = events[i].seq
+
+			if !params.match(&events[i]) {
+				continue
+			}
+
+			err = stream.Send(&translatev1.TranslationEvent{
+				Type:        events[i].kind,
+				Language:    languageToProto(events[i].language),
+				Message:     messageToProto(&events[i].message),
+				ResumeToken: t.events.resumeToken(&events[i]),
+			})
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err() //nolint:wrapcheck
+		case <-notify:
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type mockWatchStream struct {
+	grpc.ServerStream
+
+	ctx    context.Context //nolint:containedctx
+	events chan *translatev1.TranslationEvent
+}
+
+func (m *mockWatchStream) Context() context.Context { return m.ctx }
+
+func (m *mockWatchStream) SendHeader(metadata.MD) error { return nil }
+
+func (m *mockWatchStream) Send(event *translatev1.TranslationEvent) error {
+	select {
+	case m.events <- event:
+		return nil
+	case <-m.ctx.Done():
+		return m.ctx.Err()
+	}
+}
+
+// watch runs WatchTranslations until n events are received.
+func watch(
+	ctx context.Context,
+	t *testing.T,
+	translateSrv *TranslateServiceServer,
+	req *translatev1.WatchTranslationsRequest,
+	n int,
+) []*translatev1.TranslationEvent {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &mockWatchStream{ctx: ctx, events: make(chan *translatev1.TranslationEvent)}
+	done := make(chan error, 1)
+
+	go func() { done <- translateSrv.WatchTranslations(req, stream) }()
+
+	events := make([]*translatev1.TranslationEvent, 0, n)
+
+	for len(events) < n {
+		select {
+		case event := <-stream.events:
+			events = append(events, event)
+		case err := <-done:
+			cancel()
+			t.Fatalf("want %d events, got %d and error '%s'", n, len(events), err)
+		}
+	}
+
+	cancel()
+
+	// Canceled either while waiting for events or while sending.
+	if err := <-done; status.Code(err) != codes.Canceled && !errors.Is(err, context.Canceled) {
+		t.Errorf("want status '%s', got '%s'", codes.Canceled, status.Code(err))
+	}
+
+	return events
+}
+
+func Test_WatchTranslations(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Changes made through the server are published.
+	steps := []*model.Translation{
+		{
+			Language: language.Latvian,
+			Messages: []model.Message{
+				{ID: "greeting", Message: "Sveiki", Status: model.MessageStatusFuzzy},
+				{ID: "bye", Message: "Atā", Status: model.MessageStatusFuzzy},
+			},
+		},
+		{
+			Language: language.Latvian,
+			Messages: []model.Message{
+				{ID: "greeting", Message: "Sveiki", Status: model.MessageStatusTranslated},
+				{ID: "bye", Message: "Uz redzēšanos", Status: model.MessageStatusFuzzy},
+			},
+		},
+		{
+			Language: language.English, // not watched
+			Messages: []model.Message{{ID: "greeting", Message: "Hello"}},
+		},
+		{
+			Language: language.Latvian,
+			Messages: []model.Message{{ID: "greeting", Message: "Sveiki", Status: model.MessageStatusTranslated}},
+		},
+	}
+
+	for _, translation := range steps {
+		err = translateSrv.repo.SaveTranslation(ctx, service.ID, translation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	err = translateSrv.repo.DeleteTranslation(ctx, service.ID, language.Latvian)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Watch from the first event.
+	req := &translatev1.WatchTranslationsRequest{
+		ServiceId:   service.ID.String(),
+		Languages:   []string{language.Latvian.String()},
+		ResumeToken: translateSrv.events.resumeToken(&messageEvent{}),
+	}
+
+	events := watch(ctx, t, translateSrv, req, 6)
+
+	want := []struct {
+		messageID string
+		kind      translatev1.TranslationEvent_Type
+	}{
+		{messageID: "greeting", kind: translatev1.TranslationEvent_CREATED},
+		{messageID: "bye", kind: translatev1.TranslationEvent_CREATED},
+		{messageID: "greeting", kind: translatev1.TranslationEvent_STATUS_CHANGED},
+		{messageID: "bye", kind: translatev1.TranslationEvent_UPDATED},
+		{messageID: "bye", kind: translatev1.TranslationEvent_DELETED},
+		{messageID: "greeting", kind: translatev1.TranslationEvent_DELETED},
+	}
+
+	for i, event := range events {
+		if event.GetType() != want[i].kind || event.GetMessage().GetId() != want[i].messageID {
+			t.Errorf("event %d: want %s '%s', got %s '%s'",
+				i, want[i].kind, want[i].messageID, event.GetType(), event.GetMessage().GetId())
+		}
+
+		if event.GetLanguage() != language.Latvian.String() {
+			t.Errorf("event %d: want language '%s', got '%s'", i, language.Latvian, event.GetLanguage())
+		}
+	}
+
+	// Resume after the update of "bye".
+	req.ResumeToken = events[3].GetResumeToken()
+
+	resumed := watch(ctx, t, translateSrv, req, 1)
+	if resumed[0].GetResumeToken() != events[4].GetResumeToken() {
+		t.Errorf("want resumed event '%s', got '%s'", events[4].GetResumeToken(), resumed[0].GetResumeToken())
+	}
+}
+
+func Test_WatchTranslationsError(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	tests := []struct {
+		req      *translatev1.WatchTranslationsRequest
+		name     string
+		wantCode codes.Code
+	}{
+		{
+			name:     "Missing service_id",
+			req:      &translatev1.WatchTranslationsRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Service not found",
+			req:      &translatev1.WatchTranslationsRequest{ServiceId: uuid.NewString()},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Malformed resume token",
+			req:      &translatev1.WatchTranslationsRequest{ServiceId: service.ID.String(), ResumeToken: "token"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Resume token of a restarted server",
+			req:      &translatev1.WatchTranslationsRequest{ServiceId: service.ID.String(), ResumeToken: "epoch.1"},
+			wantCode: codes.OutOfRange,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			stream := &mockWatchStream{ctx: ctx, events: make(chan *translatev1.TranslationEvent)}
+
+			err := translateSrv.WatchTranslations(test.req, stream)
+			if status.Code(err) != test.wantCode {
+				t.Errorf("want status '%s', got '%s'", test.wantCode, status.Code(err))
+			}
+		})
+	}
+}
+
+func Test_eventBusExpired(t *testing.T) {
+	t.Parallel()
+
+	bus := newEventBus()
+	bus.publish(make([]messageEvent, eventBufferSize+1))
+
+	_, err := bus.after(0)
+	if err == nil {
+		t.Error("want error for evicted events")
+	}
+
+	events, err := bus.after(1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(events) != eventBufferSize {
+		t.Errorf("want %d events, got %d", eventBufferSize, len(events))
+	}
+}
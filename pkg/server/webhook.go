@@ -0,0 +1,676 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// webhookMaxAttempts is the number of requests sent before a delivery fails.
+	webhookMaxAttempts = 5
+	// webhookRetryBackoff is the delay before the first retry of a delivery, doubled for every next retry.
+	webhookRetryBackoff = 10 * time.Second
+	// webhookTimeout limits the time of a webhook request.
+	webhookTimeout = 10 * time.Second
+	// webhookClaim is the time other servers skip a delivery claimed for sending, longer than a request.
+	webhookClaim = 2 * webhookTimeout
+	// webhookSignatureHeader is the header with the HMAC-SHA256 signature of the request body.
+	webhookSignatureHeader = "X-Translate-Signature"
+)
+
+// ----------------------CreateWebhook-------------------------------
+
+type createWebhookParams struct {
+	webhook   *model.Webhook
+	serviceID uuid.UUID
+}
+
+func parseCreateWebhookRequestParams(req *translatev1.CreateWebhookRequest) (*createWebhookParams, error) {
+	var (
+		params createWebhookParams
+		err    error
+	)
+
+	params.serviceID, err = uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	params.webhook, err = webhookFromProto(req.GetWebhook())
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook: %w", err)
+	}
+
+	return &params, nil
+}
+
+func (c *createWebhookParams) validate() error {
+	if c.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if c.webhook == nil {
+		return errors.New("'webhook' is required")
+	}
+
+	if c.webhook.URL == "" {
+		return errors.New("'webhook.url' is required")
+	}
+
+	u, err := url.Parse(c.webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid 'webhook.url' '%s', want absolute http or https URL", c.webhook.URL)
+	}
+
+	if c.webhook.Secret == "" {
+		return errors.New("'webhook.secret' is required")
+	}
+
+	if len(c.webhook.Events) == 0 {
+		return errors.New("'webhook.events' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) CreateWebhook(
+	ctx context.Context,
+	req *translatev1.CreateWebhookRequest,
+) (*translatev1.Webhook, error) {
+	params, err := parseCreateWebhookRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = t.repo.LoadService(ctx, params.serviceID)
+
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "service not found")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	params.webhook.ServiceID = params.serviceID
+
+	err = t.repo.SaveWebhook(ctx, params.webhook)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return webhookToProto(params.webhook), nil
+}
+
+// ----------------------ListWebhooks-------------------------------
+
+type listWebhooksParams struct {
+	serviceID uuid.UUID
+}
+
+func parseListWebhooksRequestParams(req *translatev1.ListWebhooksRequest) (*listWebhooksParams, error) {
+	serviceID, err := uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	return &listWebhooksParams{serviceID: serviceID}, nil
+}
+
+func (l *listWebhooksParams) validate() error {
+	if l.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) ListWebhooks(
+	ctx context.Context,
+	req *translatev1.ListWebhooksRequest,
+) (*translatev1.ListWebhooksResponse, error) {
+	params, err := parseListWebhooksRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = t.repo.LoadService(ctx, params.serviceID)
+
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "service not found")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	webhooks, err := t.repo.LoadWebhooks(ctx, params.serviceID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &translatev1.ListWebhooksResponse{Webhooks: webhooksToProto(webhooks)}, nil
+}
+
+// ----------------------DeleteWebhook-------------------------------
+
+type deleteWebhookParams struct {
+	serviceID uuid.UUID
+	id        uuid.UUID
+}
+
+func parseDeleteWebhookRequestParams(req *translatev1.DeleteWebhookRequest) (*deleteWebhookParams, error) {
+	var (
+		params deleteWebhookParams
+		err    error
+	)
+
+	params.serviceID, err = uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	params.id, err = uuidFromProto(req.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("parse id: %w", err)
+	}
+
+	return &params, nil
+}
+
+func (d *deleteWebhookParams) validate() error {
+	if d.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if d.id == uuid.Nil {
+		return errors.New("'id' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) DeleteWebhook(
+	ctx context.Context,
+	req *translatev1.DeleteWebhookRequest,
+) (*emptypb.Empty, error) {
+	params, err := parseDeleteWebhookRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = t.repo.DeleteWebhook(ctx, params.serviceID, params.id)
+
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "webhook not found")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ----------------------ListWebhookDeliveries-------------------------------
+
+type listWebhookDeliveriesParams struct {
+	serviceID uuid.UUID
+	webhookID uuid.UUID
+}
+
+func parseListWebhookDeliveriesRequestParams(req *translatev1.ListWebhookDeliveriesRequest,
+) (*listWebhookDeliveriesParams, error) {
+	var (
+		params listWebhookDeliveriesParams
+		err    error
+	)
+
+	params.serviceID, err = uuidFromProto(req.GetServiceId())
+	if err != nil {
+		return nil, fmt.Errorf("parse service_id: %w", err)
+	}
+
+	params.webhookID, err = uuidFromProto(req.GetWebhookId())
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook_id: %w", err)
+	}
+
+	return &params, nil
+}
+
+func (l *listWebhookDeliveriesParams) validate() error {
+	if l.serviceID == uuid.Nil {
+		return errors.New("'service_id' is required")
+	}
+
+	if l.webhookID == uuid.Nil {
+		return errors.New("'webhook_id' is required")
+	}
+
+	return nil
+}
+
+func (t *TranslateServiceServer) ListWebhookDeliveries(
+	ctx context.Context,
+	req *translatev1.ListWebhookDeliveriesRequest,
+) (*translatev1.ListWebhookDeliveriesResponse, error) {
+	params, err := parseListWebhookDeliveriesRequestParams(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = params.validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = t.repo.LoadWebhook(ctx, params.serviceID, params.webhookID)
+
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "webhook not found")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	deliveries, err := t.repo.LoadWebhookDeliveries(ctx, repo.LoadWebhookDeliveriesOpts{
+		FilterServiceID: params.serviceID,
+		FilterWebhookID: params.webhookID,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	slices.Reverse(deliveries) // newest first
+
+	return &translatev1.ListWebhookDeliveriesResponse{Deliveries: webhookDeliveriesToProto(deliveries)}, nil
+}
+
+// ----------------------Webhook deliveries-------------------------------
+
+// webhookPayload is the JSON body of a webhook delivery.
+type webhookPayload struct {
+	CreateTime time.Time `json:"createTime"`
+	Event      string    `json:"event"`
+	ServiceID  string    `json:"serviceId"`
+	// Language is the completed translation of TRANSLATION_COMPLETED.
+	Language string `json:"language,omitempty"`
+	JobID    string `json:"jobId,omitempty"`
+	JobState string `json:"jobState,omitempty"`
+	Error    string `json:"error,omitempty"` // Error is the error of a failed job.
+	// Languages are the translations with untranslated messages of ORIGINAL_CHANGED,
+	// or the languages of the job of JOB_FINISHED.
+	Languages []string `json:"languages,omitempty"`
+}
+
+// notifyWebhooks wakes up the webhook worker, it never blocks.
+func (t *TranslateServiceServer) notifyWebhooks() {
+	select {
+	case t.webhooks <- struct{}{}:
+	default: // worker is already notified
+	}
+}
+
+// RunWebhooks delivers webhooks until ctx is done.
+// Deliveries are saved in the transactions of the translation changes and the finished jobs, see eventRepo.Tx.
+// Pending deliveries are sent when enqueued by this server or at every job poll interval,
+// failed requests are retried with backoff.
+func (t *TranslateServiceServer) RunWebhooks(ctx context.Context) {
+	ticker := time.NewTicker(t.jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := t.deliverPendingWebhooks(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("deliver pending webhooks: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.webhooks:
+		case <-ticker.C:
+		}
+	}
+}
+
+// enqueueWebhooks saves a pending delivery of the payload for every webhook of the service subscribed to the event.
+// The deliveries are saved using r, so they can be enqueued in the same transaction as the change.
+// It reports whether a delivery was saved.
+func enqueueWebhooks(
+	ctx context.Context,
+	r repo.Repo,
+	serviceID uuid.UUID,
+	event model.WebhookEvent,
+	payload *webhookPayload,
+) (bool, error) {
+	webhooks, err := r.LoadWebhooks(ctx, serviceID)
+	if err != nil {
+		return false, fmt.Errorf("load webhooks: %w", err)
+	}
+
+	payload.Event = event.String()
+	payload.ServiceID = serviceID.String()
+	payload.CreateTime = time.Now().UTC()
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return false, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	var enqueued bool
+
+	for _, webhook := range webhooks {
+		if !webhook.Subscribed(event) {
+			continue
+		}
+
+		err = r.SaveWebhookDelivery(ctx, &model.WebhookDelivery{
+			WebhookID: webhook.ID,
+			ServiceID: serviceID,
+			Event:     event,
+			Payload:   string(b),
+			Status:    model.WebhookDeliveryStatusPending,
+		})
+		if err != nil {
+			return false, fmt.Errorf("save webhook '%s' delivery: %w", webhook.ID, err)
+		}
+
+		enqueued = true
+	}
+
+	return enqueued, nil
+}
+
+// enqueueEventWebhooks enqueues the webhooks of the translation events of a transaction using r,
+// and reports whether a delivery was saved.
+func enqueueEventWebhooks(ctx context.Context, r repo.Repo, events []messageEvent) (bool, error) {
+	type translationKey struct {
+		language  language.Tag
+		serviceID uuid.UUID
+	}
+
+	var (
+		completed       []translationKey
+		originalChanged = make(map[uuid.UUID]bool)
+		// untranslated are the languages of the service translations with messages marked untranslated.
+		untranslated = make(map[uuid.UUID][]language.Tag)
+		services     []uuid.UUID
+		enqueued     bool
+	)
+
+	for i := range events {
+		event := &events[i]
+		key := translationKey{serviceID: event.serviceID, language: event.language}
+
+		if !slices.Contains(services, event.serviceID) {
+			services = append(services, event.serviceID)
+		}
+
+		if event.completed && !slices.Contains(completed, key) {
+			completed = append(completed, key)
+		}
+
+		switch {
+		case event.original:
+			originalChanged[event.serviceID] = true
+		case event.kind != translatev1.TranslationEvent_DELETED && statusBecame(event, model.MessageStatusUntranslated):
+			if !slices.Contains(untranslated[event.serviceID], event.language) {
+				untranslated[event.serviceID] = append(untranslated[event.serviceID], event.language)
+			}
+		}
+	}
+
+	for _, serviceID := range services {
+		if !originalChanged[serviceID] || len(untranslated[serviceID]) == 0 {
+			continue
+		}
+
+		languages := make([]string, 0, len(untranslated[serviceID]))
+		for _, lang := range untranslated[serviceID] {
+			languages = append(languages, languageToProto(lang))
+		}
+
+		ok, err := enqueueWebhooks(ctx, r, serviceID, model.WebhookEventOriginalChanged,
+			&webhookPayload{Languages: languages})
+		if err != nil {
+			return false, fmt.Errorf("enqueue original changed: %w", err)
+		}
+
+		enqueued = enqueued || ok
+	}
+
+	for _, key := range completed {
+		ok, err := enqueueWebhooks(ctx, r, key.serviceID, model.WebhookEventTranslationCompleted,
+			&webhookPayload{Language: languageToProto(key.language)})
+		if err != nil {
+			return false, fmt.Errorf("enqueue translation completed: %w", err)
+		}
+
+		enqueued = enqueued || ok
+	}
+
+	return enqueued, nil
+}
+
+// statusBecame reports whether the event gave the message the status.
+func statusBecame(event *messageEvent, status model.MessageStatus) bool {
+	if event.message.Status != status {
+		return false
+	}
+
+	return event.kind == translatev1.TranslationEvent_CREATED || event.oldStatus != status
+}
+
+// translationCompleted reports whether all messages of a translation other than the original are translated,
+// obsolete messages are ignored.
+func translationCompleted(translation *model.Translation) bool {
+	if translation.Original {
+		return false
+	}
+
+	var translated int
+
+	for _, msg := range translation.Messages {
+		switch msg.Status {
+		case model.MessageStatusObsolete:
+			continue
+		case model.MessageStatusTranslated:
+			translated++
+		default:
+			return false
+		}
+	}
+
+	return translated > 0
+}
+
+// deliverPendingWebhooks sends the pending deliveries due for an attempt, oldest first.
+// Each delivery is claimed before it is sent, so that servers sharing the database do not send it twice.
+func (t *TranslateServiceServer) deliverPendingWebhooks(ctx context.Context) error {
+	pending, err := t.repo.LoadWebhookDeliveries(ctx, repo.LoadWebhookDeliveriesOpts{
+		FilterStatuses: []model.WebhookDeliveryStatus{model.WebhookDeliveryStatusPending},
+	})
+	if err != nil {
+		return fmt.Errorf("load pending deliveries: %w", err)
+	}
+
+	now := time.Now()
+
+	for i := range pending {
+		delivery := &pending[i]
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if delivery.NextAttemptAt.After(now) {
+			continue
+		}
+
+		err = t.repo.ClaimWebhookDelivery(ctx, delivery, time.Now().Add(webhookClaim))
+
+		switch {
+		case errors.Is(err, repo.ErrConflict), errors.Is(err, repo.ErrNotFound):
+			continue // Delivery is sent by another server, or was deleted with the webhook.
+		case err != nil:
+			return fmt.Errorf("claim delivery '%s': %w", delivery.ID, err)
+		}
+
+		webhook, err := t.repo.LoadWebhook(ctx, delivery.ServiceID, delivery.WebhookID)
+
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			continue // Webhook was deleted with its deliveries.
+		case err != nil:
+			return fmt.Errorf("load webhook '%s': %w", delivery.WebhookID, err)
+		}
+
+		t.deliverWebhook(ctx, webhook, delivery)
+
+		// Leave the delivery pending if the worker is stopped, it is sent again when the claim expires.
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		err = t.repo.SaveWebhookDelivery(ctx, delivery)
+		if err != nil {
+			return fmt.Errorf("save delivery '%s': %w", delivery.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// deliverWebhook sends the delivery to the webhook and records the outcome of the attempt in the delivery.
+func (t *TranslateServiceServer) deliverWebhook(ctx context.Context, webhook *model.Webhook,
+	delivery *model.WebhookDelivery,
+) {
+	delivery.Attempts++
+
+	var err error
+
+	delivery.ResponseStatus, err = t.postWebhook(ctx, webhook, delivery)
+
+	switch {
+	default:
+		delivery.Error = err.Error()
+		delivery.NextAttemptAt = time.Now().Add(webhookRetryBackoff << (delivery.Attempts - 1))
+	case err == nil:
+		delivery.Status = model.WebhookDeliveryStatusSucceeded
+		delivery.Error = ""
+	case delivery.Attempts >= webhookMaxAttempts:
+		delivery.Status = model.WebhookDeliveryStatusFailed
+		delivery.Error = err.Error()
+	}
+}
+
+// postWebhook posts the delivery payload to the webhook URL, signed with the webhook secret.
+// Returns the status code of the response, 0 if there was no response.
+func (t *TranslateServiceServer) postWebhook(ctx context.Context, webhook *model.Webhook,
+	delivery *model.WebhookDelivery,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Translate-Event", delivery.Event.String())
+	req.Header.Set("X-Translate-Delivery", delivery.ID.String())
+	req.Header.Set(webhookSignatureHeader, signWebhookPayload(webhook.Secret, delivery.Payload))
+
+	resp, err := t.webhookClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("send request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	// The body is drained, so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16)) //nolint:mnd
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// signWebhookPayload returns the X-Translate-Signature header value, "sha256=" followed by
+// the hex encoded HMAC-SHA256 of the payload.
+func signWebhookPayload(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RunWebhookDeliveryPruning deletes the webhook deliveries older than the webhook delivery retention
+// on start and at every prune interval until ctx is done, pending deliveries are kept.
+// It returns immediately if deliveries are kept forever.
+func (t *TranslateServiceServer) RunWebhookDeliveryPruning(ctx context.Context) {
+	if t.webhookDeliveryRetention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(webhookDeliveryPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		err := t.pruneWebhookDeliveries(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("prune webhook deliveries: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pruneWebhookDeliveries deletes the webhook deliveries created more than the webhook delivery retention before now.
+func (t *TranslateServiceServer) pruneWebhookDeliveries(ctx context.Context, now time.Time) error {
+	err := t.repo.DeleteWebhookDeliveries(ctx, now.Add(-t.webhookDeliveryRetention).UTC())
+	if err != nil {
+		return fmt.Errorf("delete webhook deliveries: %w", err)
+	}
+
+	return nil
+}
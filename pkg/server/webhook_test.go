@@ -0,0 +1,628 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type webhookRequest struct {
+	header http.Header
+	body   string
+}
+
+// newWebhookServer returns the URL of a webhook endpoint responding with the status code,
+// received requests are sent to the channel.
+func newWebhookServer(t *testing.T, statusCode int) (string, <-chan webhookRequest) {
+	t.Helper()
+
+	requests := make(chan webhookRequest, 10)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- webhookRequest{header: r.Header, body: string(body)}
+
+		w.WriteHeader(statusCode)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv.URL, requests
+}
+
+func Test_CreateWebhookError(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	webhook := func(override func(*translatev1.Webhook)) *translatev1.Webhook {
+		w := &translatev1.Webhook{
+			Url:    "https://example.com/hook",
+			Secret: "secret",
+			Events: []translatev1.Webhook_Event{translatev1.Webhook_TRANSLATION_COMPLETED},
+		}
+
+		override(w)
+
+		return w
+	}
+
+	tests := []struct {
+		req      *translatev1.CreateWebhookRequest
+		name     string
+		wantCode codes.Code
+	}{
+		{
+			name:     "Missing service_id",
+			req:      &translatev1.CreateWebhookRequest{Webhook: webhook(func(*translatev1.Webhook) {})},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Missing webhook",
+			req:      &translatev1.CreateWebhookRequest{ServiceId: service.ID.String()},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Relative URL",
+			req: &translatev1.CreateWebhookRequest{
+				ServiceId: service.ID.String(),
+				Webhook:   webhook(func(w *translatev1.Webhook) { w.Url = "/hook" }),
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Missing secret",
+			req: &translatev1.CreateWebhookRequest{
+				ServiceId: service.ID.String(),
+				Webhook:   webhook(func(w *translatev1.Webhook) { w.Secret = "" }),
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Missing events",
+			req: &translatev1.CreateWebhookRequest{
+				ServiceId: service.ID.String(),
+				Webhook:   webhook(func(w *translatev1.Webhook) { w.Events = nil }),
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Unknown event",
+			req: &translatev1.CreateWebhookRequest{
+				ServiceId: service.ID.String(),
+				Webhook:   webhook(func(w *translatev1.Webhook) { w.Events = []translatev1.Webhook_Event{99} }),
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Service not found",
+			req: &translatev1.CreateWebhookRequest{
+				ServiceId: uuid.NewString(),
+				Webhook:   webhook(func(*translatev1.Webhook) {}),
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := translateSrv.CreateWebhook(ctx, test.req)
+			if status.Code(err) != test.wantCode {
+				t.Errorf("want status '%s', got '%s'", test.wantCode, status.Code(err))
+			}
+		})
+	}
+}
+
+func Test_Webhooks(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	url, requests := newWebhookServer(t, http.StatusOK)
+
+	webhook, err := translateSrv.CreateWebhook(ctx, &translatev1.CreateWebhookRequest{
+		ServiceId: service.ID.String(),
+		Webhook: &translatev1.Webhook{
+			Url:    url,
+			Secret: "secret",
+			Events: []translatev1.Webhook_Event{translatev1.Webhook_TRANSLATION_COMPLETED},
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if webhook.GetSecret() != "" {
+		t.Error("want secret omitted from the response")
+	}
+
+	list, err := translateSrv.ListWebhooks(ctx, &translatev1.ListWebhooksRequest{ServiceId: service.ID.String()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(list.GetWebhooks()) != 1 || list.GetWebhooks()[0].GetId() != webhook.GetId() {
+		t.Errorf("want webhook '%s', got %v", webhook.GetId(), list.GetWebhooks())
+	}
+
+	// Translate the last fuzzy message of a translation.
+	for _, msgStatus := range []model.MessageStatus{model.MessageStatusFuzzy, model.MessageStatusTranslated} {
+		err = translateSrv.repo.SaveTranslation(ctx, service.ID, &model.Translation{
+			Language: language.Latvian,
+			Messages: []model.Message{
+				{ID: "greeting", Message: "Sveiki", Status: model.MessageStatusTranslated},
+				{ID: "bye", Message: "Atā", Status: msgStatus},
+				{ID: "old", Message: "Vecs", Status: model.MessageStatusObsolete},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	err = translateSrv.deliverPendingWebhooks(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var req webhookRequest
+
+	select {
+	case req = <-requests:
+	default:
+		t.Error("want webhook request")
+		return
+	}
+
+	if want := signWebhookPayload("secret", req.body); req.header.Get(webhookSignatureHeader) != want {
+		t.Errorf("want signature '%s', got '%s'", want, req.header.Get(webhookSignatureHeader))
+	}
+
+	var payload webhookPayload
+
+	err = json.Unmarshal([]byte(req.body), &payload)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if payload.Event != "TRANSLATION_COMPLETED" || payload.Language != "lv" || payload.ServiceID != service.ID.String() {
+		t.Errorf("want TRANSLATION_COMPLETED of 'lv', got %v", payload)
+	}
+
+	deliveries, err := translateSrv.ListWebhookDeliveries(ctx, &translatev1.ListWebhookDeliveriesRequest{
+		ServiceId: service.ID.String(),
+		WebhookId: webhook.GetId(),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(deliveries.GetDeliveries()) != 1 ||
+		deliveries.GetDeliveries()[0].GetState() != translatev1.WebhookDelivery_SUCCEEDED ||
+		deliveries.GetDeliveries()[0].GetResponseStatus() != http.StatusOK {
+		t.Errorf("want one succeeded delivery, got %v", deliveries.GetDeliveries())
+	}
+
+	// Deleted webhooks are not found.
+	deleteReq := &translatev1.DeleteWebhookRequest{ServiceId: service.ID.String(), Id: webhook.GetId()}
+
+	_, err = translateSrv.DeleteWebhook(ctx, deleteReq)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = translateSrv.DeleteWebhook(ctx, deleteReq)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("want status '%s', got '%s'", codes.NotFound, status.Code(err))
+	}
+}
+
+func Test_enqueueEventWebhooks(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	webhook := &model.Webhook{
+		ServiceID: service.ID,
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		Events:    []model.WebhookEvent{model.WebhookEventOriginalChanged},
+	}
+
+	err = r.SaveWebhook(ctx, webhook)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// The original changes and marks the translation untranslated in the same transaction.
+	err = translateSrv.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		inErr := r.SaveTranslation(ctx, service.ID, &model.Translation{
+			Language: language.English,
+			Original: true,
+			Messages: []model.Message{{ID: "greeting", Message: "Hello"}},
+		})
+		if inErr != nil {
+			return inErr
+		}
+
+		return r.SaveTranslation(ctx, service.ID, &model.Translation{
+			Language: language.Latvian,
+			Messages: []model.Message{{ID: "greeting", Message: "Sveiki", Status: model.MessageStatusUntranslated}},
+		})
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Translation changes without the original are not notified.
+	err = translateSrv.repo.SaveTranslation(ctx, service.ID, &model.Translation{
+		Language: language.German,
+		Messages: []model.Message{{ID: "greeting", Message: "Hallo", Status: model.MessageStatusUntranslated}},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	deliveries, err := r.LoadWebhookDeliveries(ctx, repo.LoadWebhookDeliveriesOpts{FilterWebhookID: webhook.ID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(deliveries) != 1 || deliveries[0].Event != model.WebhookEventOriginalChanged {
+		t.Errorf("want one ORIGINAL_CHANGED delivery, got %v", deliveries)
+		return
+	}
+
+	var payload webhookPayload
+
+	err = json.Unmarshal([]byte(deliveries[0].Payload), &payload)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(payload.Languages) != 1 || payload.Languages[0] != "lv" {
+		t.Errorf("want languages [lv], got %v", payload.Languages)
+	}
+}
+
+func Test_enqueueEventWebhooksLargeTransaction(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	webhook := &model.Webhook{
+		ServiceID: service.ID,
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		Events:    []model.WebhookEvent{model.WebhookEventOriginalChanged, model.WebhookEventTranslationCompleted},
+	}
+
+	err = r.SaveWebhook(ctx, webhook)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Each transaction has more events than the event buffer keeps.
+	messages := func(status model.MessageStatus) []model.Message {
+		messages := make([]model.Message, eventBufferSize+1)
+		for i := range messages {
+			messages[i] = model.Message{ID: fmt.Sprintf("message%d", i), Message: "Text", Status: status}
+		}
+
+		return messages
+	}
+
+	err = translateSrv.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		inErr := r.SaveTranslation(ctx, service.ID, &model.Translation{
+			Language: language.English,
+			Original: true,
+			Messages: messages(model.MessageStatusTranslated),
+		})
+		if inErr != nil {
+			return inErr
+		}
+
+		return r.SaveTranslation(ctx, service.ID, &model.Translation{
+			Language: language.Latvian,
+			Messages: messages(model.MessageStatusUntranslated),
+		})
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = translateSrv.repo.SaveTranslation(ctx, service.ID, &model.Translation{
+		Language: language.Latvian,
+		Messages: messages(model.MessageStatusTranslated),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	deliveries, err := r.LoadWebhookDeliveries(ctx, repo.LoadWebhookDeliveriesOpts{
+		FilterServiceID: service.ID,
+		FilterWebhookID: webhook.ID,
+		FilterStatuses:  []model.WebhookDeliveryStatus{model.WebhookDeliveryStatusPending},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(deliveries) != 2 ||
+		deliveries[0].Event != model.WebhookEventOriginalChanged ||
+		deliveries[1].Event != model.WebhookEventTranslationCompleted {
+		t.Errorf("want pending ORIGINAL_CHANGED and TRANSLATION_COMPLETED deliveries, got %v", deliveries)
+	}
+}
+
+func Test_deliverPendingWebhooksRetry(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	url, requests := newWebhookServer(t, http.StatusInternalServerError)
+
+	webhook := &model.Webhook{
+		ServiceID: service.ID,
+		URL:       url,
+		Secret:    "secret",
+		Events:    []model.WebhookEvent{model.WebhookEventJobFinished},
+	}
+
+	err = r.SaveWebhook(ctx, webhook)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = enqueueWebhooks(ctx, r, service.ID, model.WebhookEventJobFinished, &webhookPayload{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// The second call is too early for a retry.
+	for range 2 {
+		err = translateSrv.deliverPendingWebhooks(ctx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	if len(requests) != 1 {
+		t.Errorf("want 1 request, got %d", len(requests))
+	}
+
+	deliveries, err := r.LoadWebhookDeliveries(ctx, repo.LoadWebhookDeliveriesOpts{FilterWebhookID: webhook.ID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(deliveries) != 1 {
+		t.Errorf("want 1 delivery, got %d", len(deliveries))
+		return
+	}
+
+	delivery := deliveries[0]
+
+	if delivery.Status != model.WebhookDeliveryStatusPending || delivery.Attempts != 1 ||
+		delivery.ResponseStatus != http.StatusInternalServerError || delivery.Error == "" {
+		t.Errorf("want pending delivery after a failed attempt, got %v", delivery)
+	}
+
+	if !delivery.NextAttemptAt.After(time.Now()) {
+		t.Errorf("want next attempt in the future, got %s", delivery.NextAttemptAt)
+	}
+}
+
+func Test_deliverPendingWebhooksConcurrently(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	url, requests := newWebhookServer(t, http.StatusOK)
+
+	webhook := &model.Webhook{
+		ServiceID: service.ID,
+		URL:       url,
+		Secret:    "secret",
+		Events:    []model.WebhookEvent{model.WebhookEventJobFinished},
+	}
+
+	err = r.SaveWebhook(ctx, webhook)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = enqueueWebhooks(ctx, r, service.ID, model.WebhookEventJobFinished, &webhookPayload{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Servers sharing the database send the delivery once.
+	var wg sync.WaitGroup
+
+	for range 5 {
+		translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+
+		wg.Go(func() {
+			deliverErr := translateSrv.deliverPendingWebhooks(ctx)
+			if deliverErr != nil {
+				t.Error(deliverErr)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if len(requests) != 1 {
+		t.Errorf("want 1 request, got %d", len(requests))
+	}
+}
+
+func Test_pruneWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{}, WithWebhookDeliveryRetention(time.Hour))
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	webhook := &model.Webhook{
+		ServiceID: service.ID,
+		URL:       "https://example.com/hook",
+		Events:    []model.WebhookEvent{model.WebhookEventJobFinished},
+	}
+
+	err = r.SaveWebhook(ctx, webhook)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, deliveryStatus := range []model.WebhookDeliveryStatus{
+		model.WebhookDeliveryStatusPending,
+		model.WebhookDeliveryStatusSucceeded,
+		model.WebhookDeliveryStatusFailed,
+	} {
+		err = r.SaveWebhookDelivery(ctx, &model.WebhookDelivery{
+			WebhookID: webhook.ID,
+			ServiceID: service.ID,
+			Event:     model.WebhookEventJobFinished,
+			Status:    deliveryStatus,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// Deliveries within the retention are kept, older deliveries are deleted unless pending.
+	for _, test := range []struct {
+		now  time.Time
+		want int
+	}{
+		{now: time.Now(), want: 3},
+		{now: time.Now().Add(2 * time.Hour), want: 1},
+	} {
+		err = translateSrv.pruneWebhookDeliveries(ctx, test.now)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		deliveries, err := r.LoadWebhookDeliveries(ctx, repo.LoadWebhookDeliveriesOpts{FilterWebhookID: webhook.ID})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(deliveries) != test.want {
+			t.Errorf("want %d deliveries at %s, got %d", test.want, test.now, len(deliveries))
+		}
+	}
+}
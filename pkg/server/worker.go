@@ -0,0 +1,400 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/model"
+	"go.expect.digital/translate/pkg/repo"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/text/language"
+)
+
+// enqueueJob saves a pending job to fuzzy translate all languages that have untranslated messages.
+// No job is created if there is nothing to translate.
+// The job is saved using r, so it can be enqueued in the same transaction as the translations.
+func enqueueJob(ctx context.Context, r repo.Repo, serviceID uuid.UUID, all model.Translations) (*model.Job, error) {
+	var languages []language.Tag
+
+	for _, translation := range all {
+		if translation.Original {
+			continue
+		}
+
+		for _, msg := range translation.Messages {
+			if msg.Status == model.MessageStatusUntranslated {
+				languages = append(languages, translation.Language)
+				break
+			}
+		}
+	}
+
+	if len(languages) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	job := &model.Job{
+		ServiceID: serviceID,
+		Languages: languages,
+		Total:     len(languages),
+		Status:    model.JobStatusPending,
+	}
+
+	err := r.SaveJob(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("save job: %w", err)
+	}
+
+	return job, nil
+}
+
+// notifyJobs wakes up the job worker, it never blocks.
+func (t *TranslateServiceServer) notifyJobs() {
+	select {
+	case t.jobs <- struct{}{}:
+	default: // worker is already notified
+	}
+}
+
+// RunJobs processes pending jobs until ctx is done.
+// Jobs are picked up when enqueued by this server or at every job poll interval.
+// Jobs are claimed before they run, so that servers sharing the repo do not run a job twice,
+// and jobs left running by a stopped server are run again once their lease expires.
+func (t *TranslateServiceServer) RunJobs(ctx context.Context) error {
+	ticker := time.NewTicker(t.jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := t.runPendingJobs(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("run pending jobs: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.jobs:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runPendingJobs claims and runs pending jobs and running jobs with an expired lease one by one, oldest first,
+// until there are none left. Jobs claimed by other servers are skipped.
+func (t *TranslateServiceServer) runPendingJobs(ctx context.Context) error {
+	for ctx.Err() == nil {
+		jobs, err := t.repo.LoadJobs(ctx, repo.LoadJobsOpts{
+			FilterStatuses: []model.JobStatus{model.JobStatusPending, model.JobStatusRunning},
+		})
+		if err != nil {
+			return fmt.Errorf("load pending jobs: %w", err)
+		}
+
+		var claimed bool
+
+		for i := range jobs {
+			job := &jobs[i]
+
+			if job.Status == model.JobStatusRunning && time.Now().Before(job.LeaseUntil) {
+				continue // claimed by a server until the lease expires
+			}
+
+			err = t.repo.ClaimJob(ctx, job, t.instanceID, time.Now().Add(jobLease))
+
+			switch {
+			case errors.Is(err, repo.ErrConflict), errors.Is(err, repo.ErrNotFound):
+				continue // claimed by another server or deleted with its service
+			case err != nil:
+				return fmt.Errorf("claim job '%s': %w", job.ID, err)
+			}
+
+			claimed = true
+
+			err = t.runJob(ctx, job)
+			if err != nil {
+				return fmt.Errorf("run job '%s': %w", job.ID, err)
+			}
+		}
+
+		if !claimed {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// runJob fuzzy translates the languages of the claimed job and records the progress and the outcome in the job.
+// The lease of the job is extended until the job is finished, the job is left running if the lease is lost.
+// Translation failures fail the job, only failures to save the job itself are returned.
+func (t *TranslateServiceServer) runJob(ctx context.Context, job *model.Job) error {
+	var (
+		mu        sync.Mutex // guards job, saved by the job progress and the lease
+		wg        sync.WaitGroup
+		leaseLost bool
+	)
+
+	jobCtx, cancel := context.WithCancel(ctx)
+
+	wg.Go(func() { leaseLost = t.extendJobLease(jobCtx, cancel, &mu, job) })
+
+	translateErr := t.translateJob(jobCtx, &mu, job)
+
+	cancel()
+	wg.Wait()
+
+	// Leave the job running if the worker is stopped, it is run again once the lease expires.
+	// A job with a lost lease is run by another server.
+	if ctx.Err() != nil || leaseLost {
+		return nil
+	}
+
+	switch {
+	default:
+		job.Status = model.JobStatusSucceeded
+	case translateErr != nil:
+		job.Status = model.JobStatusFailed
+		job.Error = translateErr.Error()
+	}
+
+	languages := make([]string, 0, len(job.Languages))
+	for _, lang := range job.Languages {
+		languages = append(languages, languageToProto(lang))
+	}
+
+	var enqueued bool
+
+	// The webhook deliveries are saved with the finished job.
+	err := t.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		inErr := r.SaveJob(ctx, job)
+		if inErr != nil {
+			return fmt.Errorf("save job: %w", inErr)
+		}
+
+		enqueued, inErr = enqueueWebhooks(ctx, r, job.ServiceID, model.WebhookEventJobFinished, &webhookPayload{
+			JobID:     job.ID.String(),
+			JobState:  jobStatusToProto(job.Status).String(),
+			Error:     job.Error,
+			Languages: languages,
+		})
+		if inErr != nil {
+			return fmt.Errorf("enqueue webhooks: %w", inErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save finished job: %w", err)
+	}
+
+	if enqueued {
+		t.notifyWebhooks()
+	}
+
+	return nil
+}
+
+// extendJobLease extends the lease of the running job at a third of the lease until ctx is done.
+// If the lease is lost, e.g. the job was claimed by another server after the lease expired,
+// cancel is called to stop the job and true is returned.
+func (t *TranslateServiceServer) extendJobLease(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	mu *sync.Mutex,
+	job *model.Job,
+) bool {
+	ticker := time.NewTicker(jobLease / 3) //nolint:mnd
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+
+		mu.Lock()
+		err := t.repo.ClaimJob(ctx, job, t.instanceID, time.Now().Add(jobLease))
+		mu.Unlock()
+
+		switch {
+		case errors.Is(err, repo.ErrConflict), errors.Is(err, repo.ErrNotFound):
+			log.Printf("job '%s' lease lost: %v", job.ID, err)
+			cancel()
+
+			return true
+		case err != nil && ctx.Err() == nil:
+			log.Printf("extend job '%s' lease: %v", job.ID, err)
+		}
+	}
+}
+
+// translateJob fuzzy translates untranslated messages for each job language,
+// translations are saved and the job progress is updated as soon as a language is done.
+// mu guards job, as the lease of the job is extended concurrently.
+func (t *TranslateServiceServer) translateJob(ctx context.Context, mu *sync.Mutex, job *model.Job) error {
+	service, err := t.repo.LoadService(ctx, job.ServiceID)
+	if err != nil {
+		return fmt.Errorf("load service: %w", err)
+	}
+
+	all, err := t.repo.LoadTranslations(ctx, job.ServiceID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		return fmt.Errorf("load translations: %w", err)
+	}
+
+	origIdx := all.OriginalIndex()
+	if origIdx == -1 {
+		return errors.New("original translation not found")
+	}
+
+	origMsgLookup := make(map[string]string, len(all[origIdx].Messages))
+	for _, msg := range all[origIdx].Messages {
+		origMsgLookup[msg.ID] = msg.Message
+	}
+
+	mu.Lock()
+	job.Done = 0
+	mu.Unlock()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(t.translateConcurrency)
+
+	for _, lang := range job.Languages {
+		idx := all.LanguageIndex(lang)
+		if idx == -1 || idx == origIdx {
+			// Translation was removed or became the original after the job was enqueued, nothing to translate.
+			mu.Lock()
+			job.Done++
+			mu.Unlock()
+
+			continue
+		}
+
+		g.Go(func() error {
+			translation := &all[idx]
+			loaded := make(map[string]string, len(translation.Messages))
+
+			for _, msg := range translation.Messages {
+				loaded[msg.ID] = msg.Message
+			}
+
+			translateErr := t.fuzzyTranslateLanguage(gctx, service, all[origIdx].Language, origMsgLookup, translation)
+			if translateErr != nil {
+				return translateErr
+			}
+
+			saveErr := t.saveFuzzyTranslated(gctx, job.ServiceID, translation, loaded)
+			if saveErr != nil {
+				return fmt.Errorf("save translation '%s': %w", lang, saveErr)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			job.Done++
+
+			saveErr = t.repo.SaveJob(gctx, job)
+			if saveErr != nil {
+				return fmt.Errorf("save job progress: %w", saveErr)
+			}
+
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if err != nil {
+		return fmt.Errorf("fuzzy translate: %w", err)
+	}
+
+	return nil
+}
+
+// saveFuzzyTranslated saves fuzzy translated messages, skipping messages changed since they were loaded,
+// so that updates made while the job was running are not overwritten.
+func (t *TranslateServiceServer) saveFuzzyTranslated(
+	ctx context.Context,
+	serviceID uuid.UUID,
+	translated *model.Translation,
+	loaded map[string]string,
+) error {
+	err := t.repo.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		current, err := r.LoadTranslations(ctx, serviceID,
+			repo.LoadTranslationsOpts{FilterLanguages: []language.Tag{translated.Language}})
+		if err != nil {
+			return fmt.Errorf("load translation: %w", err)
+		}
+
+		if len(current) == 0 {
+			return nil // Translation was removed while the job was running.
+		}
+
+		translatedLookup := make(map[string]model.Message, len(translated.Messages))
+		for _, msg := range translated.Messages {
+			translatedLookup[msg.ID] = msg
+		}
+
+		for i, msg := range current[0].Messages {
+			orig, ok := loaded[msg.ID]
+			if !ok || msg.Status != model.MessageStatusUntranslated || msg.Message != orig {
+				continue
+			}
+
+			if translatedMsg, ok := translatedLookup[msg.ID]; ok {
+				current[0].Messages[i] = translatedMsg
+			}
+		}
+
+		err = r.SaveTranslation(ctx, serviceID, &current[0])
+		if err != nil {
+			return fmt.Errorf("save translation: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repo tx: %w", err)
+	}
+
+	return nil
+}
+
+// RunJobPruning deletes the finished jobs older than the job retention
+// on start and at every prune interval until ctx is done. It returns immediately if jobs are kept forever.
+func (t *TranslateServiceServer) RunJobPruning(ctx context.Context) {
+	if t.jobRetention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(jobPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		err := t.pruneJobs(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("prune jobs: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pruneJobs deletes the finished jobs last updated more than the job retention before now.
+func (t *TranslateServiceServer) pruneJobs(ctx context.Context, now time.Time) error {
+	err := t.repo.DeleteJobs(ctx, now.Add(-t.jobRetention).UTC())
+	if err != nil {
+		return fmt.Errorf("delete jobs: %w", err)
+	}
+
+	return nil
+}
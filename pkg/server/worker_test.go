@@ -0,0 +1,644 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/google/uuid"
+	"go.expect.digital/translate/pkg/fuzzy"
+	"go.expect.digital/translate/pkg/model"
+	translatev1 "go.expect.digital/translate/pkg/pb/translate/v1"
+	"go.expect.digital/translate/pkg/repo"
+	"go.expect.digital/translate/pkg/repo/badgerdb"
+	"go.expect.digital/translate/pkg/testutil/rand"
+	"golang.org/x/text/language"
+)
+
+func Test_runPendingJobs(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	original := randOriginalTranslation(3)
+	all := append(model.Translations{*original}, randTranslations(3, 3, original)...)
+
+	for i := range all {
+		if !all[i].Original {
+			all[i].Messages[0].Status = model.MessageStatusUntranslated
+		}
+	}
+
+	var job *model.Job
+
+	err = r.Tx(ctx, func(ctx context.Context, r repo.Repo) error {
+		for i := range all {
+			inErr := r.SaveTranslation(ctx, service.ID, &all[i])
+			if inErr != nil {
+				return inErr
+			}
+		}
+
+		var inErr error
+
+		job, inErr = enqueueJob(ctx, r, service.ID, all)
+
+		return inErr
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if job == nil || job.Total != 3 || job.Status != model.JobStatusPending {
+		t.Errorf("want pending job for 3 languages, got %v", job)
+		return
+	}
+
+	err = translateSrv.runPendingJobs(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	op, err := translateSrv.GetJob(ctx, &translatev1.GetJobRequest{Id: job.ID.String()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !op.GetDone() || op.GetError() != nil {
+		t.Errorf("want successfully done operation, got %v", op)
+	}
+
+	metadata := new(translatev1.JobMetadata)
+
+	err = op.GetMetadata().UnmarshalTo(metadata)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if metadata.GetDone() != 3 || metadata.GetState() != translatev1.JobMetadata_SUCCEEDED {
+		t.Errorf("want 3 of 3 languages done, got %v", metadata)
+	}
+
+	got, err := r.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, translation := range got {
+		for _, msg := range translation.Messages {
+			if msg.Status == model.MessageStatusUntranslated {
+				t.Errorf("want no untranslated messages, got %v in '%s'", msg, translation.Language)
+			}
+		}
+	}
+}
+
+func Test_runPendingJobsFailed(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockFailingTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	original := randOriginalTranslation(1)
+	all := append(model.Translations{*original}, randTranslations(1, 1, original)...)
+	all[1].Messages[0].Status = model.MessageStatusUntranslated
+
+	for i := range all {
+		err = r.SaveTranslation(ctx, service.ID, &all[i])
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	job, err := enqueueJob(ctx, r, service.ID, all)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = translateSrv.runPendingJobs(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	resp, err := translateSrv.ListJobs(ctx, &translatev1.ListJobsRequest{ServiceId: service.ID.String()})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(resp.GetOperations()) != 1 {
+		t.Errorf("want 1 operation, got %d", len(resp.GetOperations()))
+		return
+	}
+
+	op := resp.GetOperations()[0]
+
+	if op.GetName() != "jobs/"+job.ID.String() {
+		t.Errorf("want operation name 'jobs/%s', got '%s'", job.ID, op.GetName())
+	}
+
+	if _, ok := op.GetResult().(*longrunningpb.Operation_Error); !ok || !op.GetDone() {
+		t.Errorf("want failed operation, got %v", op)
+	}
+}
+
+func Test_runPendingJobsLease(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = r.SaveTranslation(ctx, service.ID, randOriginalTranslation(1))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Both jobs are left running by another server, only the expired lease is claimed.
+	other := uuid.New()
+	claimed := &model.Job{ServiceID: service.ID, Status: model.JobStatusRunning, Owner: other,
+		LeaseUntil: time.Now().Add(time.Minute)}
+	expired := &model.Job{ServiceID: service.ID, Status: model.JobStatusRunning, Owner: other,
+		LeaseUntil: time.Now().Add(-time.Minute)}
+
+	for _, job := range []*model.Job{claimed, expired} {
+		err = r.SaveJob(ctx, job)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	err = translateSrv.runPendingJobs(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, want := range []model.Job{
+		{ID: claimed.ID, Status: model.JobStatusRunning, Owner: other},
+		{ID: expired.ID, Status: model.JobStatusSucceeded, Owner: translateSrv.instanceID},
+	} {
+		got, err := r.LoadJob(ctx, want.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if got.Status != want.Status || got.Owner != want.Owner {
+			t.Errorf("want job %s owned by %s, got %s owned by %s", &want.Status, want.Owner, &got.Status, got.Owner)
+		}
+	}
+}
+
+//nolint:gocognit
+func Test_translateJob(t *testing.T) {
+	t.Parallel()
+
+	originalTranslation1 := randOriginalTranslation(3)
+	originalTranslation2 := randOriginalTranslation(10)
+
+	tests := []struct {
+		name                string
+		originalTranslation *model.Translation
+		translations        []model.Translation
+	}{
+		{
+			name:                "Fuzzy translate untranslated messages for one translation",
+			originalTranslation: originalTranslation1,
+			translations:        randTranslations(1, 3, originalTranslation1),
+		},
+		{
+			name:                "Fuzzy translate untranslated messages for five translations",
+			originalTranslation: originalTranslation2,
+			translations:        randTranslations(5, 5, originalTranslation2),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			translateSrv := NewTranslateServiceServer(newInMemoryRepo(t), &mockTranslator{})
+
+			allTranslations := append(model.Translations{*test.originalTranslation}, test.translations...)
+			untranslatedMessageIDLookup := randomUntranslatedMessageStatus(t, allTranslations)
+
+			job, got := runTranslateJob(t, translateSrv, rand.ModelService(), allTranslations)
+			if job.Status != model.JobStatusSucceeded {
+				t.Errorf("want succeeded job, got %v", job)
+			}
+
+			// Check that untranslated messages have been translated and marked as fuzzy for all translations.
+			for _, translation := range got {
+				if translation.Original {
+					if !reflect.DeepEqual(test.originalTranslation.Messages, translation.Messages) {
+						t.Errorf("\nwant %v\ngot  %v", test.originalTranslation.Messages, translation.Messages)
+					}
+
+					continue
+				}
+
+				for _, message := range translation.Messages {
+					if _, ok := untranslatedMessageIDLookup[message.ID]; ok {
+						if mockTranslation != message.Message {
+							t.Errorf("want message '%s', got '%s'", mockTranslation, message.Message)
+						}
+
+						if model.MessageStatusFuzzy != message.Status {
+							t.Errorf("want message status '%d', got '%d'", model.MessageStatusFuzzy, message.Status)
+						}
+					} else if model.MessageStatusTranslated != message.Status {
+						t.Errorf("want message status '%d', got '%d'", model.MessageStatusTranslated, message.Status)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_translateJobConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	const limit = 2
+
+	translator := &mockConcurrentTranslator{wantInFlight: limit}
+	translateSrv := NewTranslateServiceServer(newInMemoryRepo(t), translator, WithTranslateConcurrency(limit))
+
+	originalTranslation := randOriginalTranslation(3)
+	allTranslations := append(model.Translations{*originalTranslation}, randTranslations(6, 3, originalTranslation)...)
+
+	for i := range allTranslations {
+		if allTranslations[i].Original {
+			continue
+		}
+
+		for j := range allTranslations[i].Messages {
+			allTranslations[i].Messages[j].Status = model.MessageStatusUntranslated
+		}
+	}
+
+	job, got := runTranslateJob(t, translateSrv, rand.ModelService(), allTranslations)
+	if job.Status != model.JobStatusSucceeded || job.Done != 6 {
+		t.Errorf("want succeeded job for 6 languages, got %v", job)
+	}
+
+	// Translations wait for each other, so fewer concurrent translations mean that the limit is not reached.
+	if got := translator.maxInFlight.Load(); got != limit {
+		t.Errorf("want %d concurrent translations, got %d", limit, got)
+	}
+
+	for _, translation := range got {
+		if translation.Original {
+			continue
+		}
+
+		for _, message := range translation.Messages {
+			if model.MessageStatusFuzzy != message.Status {
+				t.Errorf("want message status '%d', got '%d'", model.MessageStatusFuzzy, message.Status)
+			}
+		}
+	}
+}
+
+func Test_translateJobRouting(t *testing.T) {
+	t.Parallel()
+
+	routed := &mockConcurrentTranslator{}
+	translateSrv := NewTranslateServiceServer(newInMemoryRepo(t), &mockTranslator{},
+		WithTranslators(map[string]fuzzy.Translator{"Routed": routed}))
+
+	service := rand.ModelService()
+	service.TranslatorRouting = model.TranslatorRouting{
+		Routes: []model.TranslatorRoute{
+			{Source: language.English, Target: language.German, Translator: "Routed"},
+			{Source: language.Und, Target: language.Latvian, Translator: model.TranslatorNone},
+		},
+	}
+
+	originalTranslation := randOriginalTranslation(3)
+	allTranslations := model.Translations{*originalTranslation}
+
+	for _, lang := range []language.Tag{language.German, language.Latvian, language.French} {
+		allTranslations = append(allTranslations, *rand.ModelTranslation(
+			3,
+			[]rand.ModelMessageOption{rand.WithStatus(model.MessageStatusUntranslated)},
+			rand.WithOriginal(false),
+			rand.WithSameIDs(originalTranslation),
+			rand.WithLanguage(lang)))
+	}
+
+	job, got := runTranslateJob(t, translateSrv, service, allTranslations)
+	if job.Status != model.JobStatusSucceeded {
+		t.Errorf("want succeeded job, got %v", job)
+	}
+
+	if routed.maxInFlight.Load() == 0 {
+		t.Error("want routed translator to translate German")
+	}
+
+	wantStatus := map[language.Tag]model.MessageStatus{
+		language.German:  model.MessageStatusFuzzy,
+		language.Latvian: model.MessageStatusUntranslated,
+		language.French:  model.MessageStatusFuzzy,
+	}
+
+	for _, translation := range got {
+		if translation.Original {
+			continue
+		}
+
+		for _, message := range translation.Messages {
+			if wantStatus[translation.Language] != message.Status {
+				t.Errorf("want '%s' message status '%d', got '%d'",
+					translation.Language, wantStatus[translation.Language], message.Status)
+			}
+		}
+	}
+
+	// Route to a translator that is not available fails the job.
+	service.TranslatorRouting.Routes[0].Translator = "Unavailable"
+
+	for i := range got {
+		if got[i].Language == language.German {
+			for j := range got[i].Messages {
+				got[i].Messages[j].Status = model.MessageStatusUntranslated
+			}
+		}
+	}
+
+	job, _ = runTranslateJob(t, translateSrv, service, got)
+	if job.Status != model.JobStatusFailed {
+		t.Errorf("want failed job for unavailable translator, got %v", job)
+	}
+}
+
+// helpers
+
+// mockFailingTranslator is a translator that always fails.
+type mockFailingTranslator struct{}
+
+func (m *mockFailingTranslator) Translate(context.Context, *model.Translation, language.Tag,
+) (*model.Translation, error) {
+	return nil, errors.New("translator unavailable")
+}
+
+// mockConcurrentTranslator is a mockTranslator, that records the maximum number of concurrent translations.
+// Translations wait a while for wantInFlight concurrent translations, so that a serial run is detected.
+type mockConcurrentTranslator struct {
+	mockTranslator
+
+	inFlight, maxInFlight atomic.Int64
+	wantInFlight          int64
+}
+
+func (m *mockConcurrentTranslator) Translate(ctx context.Context,
+	translation *model.Translation,
+	targetLanguage language.Tag,
+) (*model.Translation, error) {
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+
+	for {
+		maxN := m.maxInFlight.Load()
+		if n <= maxN || m.maxInFlight.CompareAndSwap(maxN, n) {
+			break
+		}
+	}
+
+	waitInFlight(&m.maxInFlight, m.wantInFlight)
+
+	return m.mockTranslator.Translate(ctx, translation, targetLanguage)
+}
+
+// waitInFlight waits up to 100ms for the maximum number of concurrent calls to reach want.
+func waitInFlight(maxInFlight *atomic.Int64, want int64) {
+	for deadline := time.Now().Add(100 * time.Millisecond); time.Now().Before(deadline); {
+		if maxInFlight.Load() >= want {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// runTranslateJob saves the service with the translations, runs the job to fuzzy translate them,
+// and returns the finished job with the translations loaded after the job.
+func runTranslateJob(
+	t *testing.T,
+	translateSrv *TranslateServiceServer,
+	service *model.Service,
+	all model.Translations,
+) (*model.Job, model.Translations) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	err := translateSrv.repo.SaveService(ctx, service)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range all {
+		err = translateSrv.repo.SaveTranslation(ctx, service.ID, &all[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	job, err := enqueueJob(ctx, translateSrv.repo, service.ID, all)
+	if err != nil || job == nil {
+		t.Fatalf("want enqueued job, got %v, error %v", job, err)
+	}
+
+	err = translateSrv.runPendingJobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job, err = translateSrv.repo.LoadJob(ctx, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := translateSrv.repo.LoadTranslations(ctx, service.ID, repo.LoadTranslationsOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return job, got
+}
+
+// newInMemoryRepo creates a new in-memory BadgerDB repo, closed when the test ends.
+func newInMemoryRepo(t *testing.T) *badgerdb.Repo {
+	t.Helper()
+
+	r, err := badgerdb.NewRepo(badgerdb.WithDefaultDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { r.Close() })
+
+	return r
+}
+
+func Test_ListJobsPagination(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{})
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := make([]string, 5)
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	for i := range want {
+		// Distinct creation times keep the jobs in the saved order.
+		job := &model.Job{
+			ServiceID: service.ID,
+			Status:    model.JobStatusPending,
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Second),
+		}
+
+		err = r.SaveJob(ctx, job)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		want[i] = jobOperationPrefix + job.ID.String()
+	}
+
+	var (
+		got       []string
+		pageToken string
+	)
+
+	for range len(want) {
+		resp, err := translateSrv.ListJobs(ctx, &translatev1.ListJobsRequest{
+			ServiceId: service.ID.String(),
+			PageSize:  2,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		for _, op := range resp.GetOperations() {
+			got = append(got, op.GetName())
+		}
+
+		pageToken = resp.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("\nwant %v\ngot  %v", want, got)
+	}
+}
+
+func Test_pruneJobs(t *testing.T) {
+	t.Parallel()
+
+	r := newInMemoryRepo(t)
+	translateSrv := NewTranslateServiceServer(r, &mockTranslator{}, WithJobRetention(time.Hour))
+	ctx := t.Context()
+
+	service := rand.ModelService()
+
+	err := r.SaveService(ctx, service)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, jobStatus := range []model.JobStatus{
+		model.JobStatusPending,
+		model.JobStatusRunning,
+		model.JobStatusSucceeded,
+		model.JobStatusFailed,
+	} {
+		err = r.SaveJob(ctx, &model.Job{ServiceID: service.ID, Status: jobStatus})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// Jobs within the retention are kept, older jobs are deleted unless pending or running.
+	for _, test := range []struct {
+		now  time.Time
+		want int
+	}{
+		{now: time.Now(), want: 4},
+		{now: time.Now().Add(2 * time.Hour), want: 2},
+	} {
+		err = translateSrv.pruneJobs(ctx, test.now)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		jobs, err := r.LoadJobs(ctx, repo.LoadJobsOpts{FilterServiceID: service.ID})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(jobs) != test.want {
+			t.Errorf("want %d jobs at %s, got %d", test.want, test.now, len(jobs))
+		}
+	}
+}
@@ -66,6 +66,46 @@ func WithID(id uuid.UUID) ModelServiceOption {
 	}
 }
 
+// WithProjectID sets the project ID of the model.Service.
+func WithProjectID(id uuid.UUID) ModelServiceOption {
+	return func(s *model.Service) {
+		s.ProjectID = id
+	}
+}
+
+// WithMonthlyCharacterQuota sets the monthly character quota of the model.Service.
+func WithMonthlyCharacterQuota(quota int64) ModelServiceOption {
+	return func(s *model.Service) {
+		s.MonthlyCharacterQuota = quota
+	}
+}
+
+// WithTranslatorRouting sets random translator routes of the model.Service,
+// routes use the given translator names and random or any (language.Und) languages.
+func WithTranslatorRouting(translators ...string) ModelServiceOption {
+	return func(s *model.Service) {
+		routes := make([]model.TranslatorRoute, gofakeit.IntRange(1, 5)) //nolint:mnd
+
+		for i := range routes {
+			routes[i] = model.TranslatorRoute{
+				Translator: translators[gofakeit.IntN(len(translators))],
+				Source:     language.Und,
+				Target:     language.Und,
+			}
+
+			if gofakeit.Bool() {
+				routes[i].Source = Language()
+			}
+
+			if gofakeit.Bool() {
+				routes[i].Target = Language()
+			}
+		}
+
+		s.TranslatorRouting = model.TranslatorRouting{Routes: routes}
+	}
+}
+
 // ------------------Message------------------
 
 // modelMessage generates a random model.Message.
@@ -106,8 +146,6 @@ func modelMessage() *model.Message {
 
 // ModelMessage generates a random model.Message using provided options.
 func ModelMessage(opts ...ModelMessageOption) *model.Message {
-	opts = append(opts, WithPluralID("")) // PluralID is ignored as it is not used in the repo yet
-
 	return mdl(modelMessage, opts...)
 }
 
@@ -126,7 +164,7 @@ func MessageStatus() model.MessageStatus {
 
 type ModelMessageOption func(*model.Message)
 
-// WithPluralID sets the PluralID of the model.Message to "".
+// WithPluralID sets the PluralID of the model.Message.
 func WithPluralID(pluralID string) ModelMessageOption {
 	return func(m *model.Message) {
 		m.PluralID = pluralID
@@ -160,7 +198,15 @@ func modelTranslation(msgCount uint, msgOpts ...ModelMessageOption) *model.Trans
 	}
 
 	msgs := ModelMessages(msgCount, msgOpts...)
+	ids := make(map[string]bool, msgCount)
+
 	for i, msg := range msgs {
+		// Message IDs are unique in a translation.
+		for ids[msg.ID] {
+			msg.ID = gofakeit.Sentence()
+		}
+
+		ids[msg.ID] = true
 		translation.Messages[i] = *msg
 	}
 